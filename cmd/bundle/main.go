@@ -0,0 +1,135 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+// bundle packages the list files into a signed bundle for hosts without network access.
+//
+//	bundle -generate-key -private-key bundle.key -public-key bundle.pub
+//	bundle -private-key bundle.key -output ./bundle
+//
+// Files are read from -data-directory, or downloaded when it's empty.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/bundle"
+	"github.com/moov-io/watchman/pkg/download"
+	"github.com/moov-io/watchman/pkg/source"
+
+	// Lists register their Source when imported
+	_ "github.com/moov-io/watchman/pkg/csl"
+	_ "github.com/moov-io/watchman/pkg/dpl"
+	_ "github.com/moov-io/watchman/pkg/eu"
+	_ "github.com/moov-io/watchman/pkg/ofac"
+	_ "github.com/moov-io/watchman/pkg/uk"
+	_ "github.com/moov-io/watchman/pkg/un"
+)
+
+var (
+	flagDataDirectory = flag.String("data-directory", "", "Directory of list files to bundle, they're downloaded when empty")
+	flagOutput        = flag.String("output", "./bundle", "Directory to write the bundle into")
+	flagPrivateKey    = flag.String("private-key", "", "PEM encoded Ed25519 private key to sign the bundle with")
+	flagPublicKey     = flag.String("public-key", "", "Where to write the public key with -generate-key")
+	flagGenerateKey   = flag.Bool("generate-key", false, "Generate an Ed25519 key pair instead of a bundle")
+)
+
+func main() {
+	flag.Parse()
+	logger := log.NewDefaultLogger()
+
+	if *flagPrivateKey == "" {
+		logger.Fatal().Log("-private-key is required")
+		os.Exit(1)
+	}
+
+	if *flagGenerateKey {
+		if *flagPublicKey == "" {
+			logger.Fatal().Log("-public-key is required with -generate-key")
+			os.Exit(1)
+		}
+		if err := bundle.GenerateKey(*flagPrivateKey, *flagPublicKey); err != nil {
+			logger.Fatal().LogErrorf("generating key: %v", err)
+			os.Exit(1)
+		}
+		logger.Logf("wrote %s and %s", *flagPrivateKey, *flagPublicKey)
+		return
+	}
+
+	key, err := bundle.ReadPrivateKey(*flagPrivateKey)
+	if err != nil {
+		logger.Fatal().LogErrorf("reading private key: %v", err)
+		os.Exit(1)
+	}
+
+	download.HTTPClient, err = download.NewHTTPClient(download.ConfigFromEnvironment())
+	if err != nil {
+		logger.Fatal().LogErrorf("download HTTP client: %v", err)
+		os.Exit(1)
+	}
+
+	download.DefaultCache, err = download.CacheFromEnvironment()
+	if err != nil {
+		logger.Fatal().LogError(err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	files, err := listFiles(ctx, logger, *flagDataDirectory)
+	if err != nil {
+		logger.Fatal().LogErrorf("ERROR: %v", err)
+		os.Exit(1)
+	}
+
+	manifest, err := bundle.Create(*flagOutput, files, key)
+	if err != nil {
+		logger.Fatal().LogErrorf("ERROR: %v", err)
+		os.Exit(1)
+	}
+	for _, file := range manifest.Files {
+		logger.With(log.Fields{
+			"size":   log.Int64(file.Size),
+			"sha256": log.String(file.SHA256),
+		}).Logf("bundled %s", file.Name)
+	}
+	logger.Logf("wrote bundle of %d files to %s", len(manifest.Files), *flagOutput)
+}
+
+// listFiles returns the list files to bundle, downloading any which are missing from initialDir.
+func listFiles(ctx context.Context, logger log.Logger, initialDir string) ([]string, error) {
+	// Only bundle list files (once each), not whatever else is in the directory
+	var files []string
+	listFiles := make(map[string]bool)
+
+	for _, src := range source.Sources() {
+		more, err := src.Download(ctx, logger, initialDir)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", src.Name(), err)
+		}
+		files = append(files, more...)
+
+		for _, name := range src.Filenames() {
+			listFiles[name] = true
+		}
+	}
+
+	seen := make(map[string]bool)
+	var out []string
+	for i := range files {
+		name := filepath.Base(files[i])
+		if listFiles[name] && !seen[name] {
+			seen[name] = true
+			out = append(out, files[i])
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,417 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"crypto/sha1"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/ofac"
+	"github.com/moov-io/watchman/pkg/source"
+)
+
+// entityChanges holds the SDN entity IDs which were added, modified or removed between
+// the previously recorded snapshot and the current data.
+type entityChanges struct {
+	Added    []string `json:"added"`
+	Modified []string `json:"modified"`
+	Removed  []string `json:"removed"`
+}
+
+// changed returns the set of entity IDs whose records are new or different and need to be
+// screened again.
+func (c *entityChanges) changed() map[string]bool {
+	out := make(map[string]bool, len(c.Added)+len(c.Modified))
+	for _, id := range c.Added {
+		out[id] = true
+	}
+	for _, id := range c.Modified {
+		out[id] = true
+	}
+	return out
+}
+
+// sdnHash returns a stable fingerprint of an SDN record used to detect modifications between refreshes.
+func sdnHash(sdn *ofac.SDN) string {
+	bs, _ := json.Marshal(sdn)
+	sum := sha1.Sum(bs)
+	return hex.EncodeToString(sum[:])
+}
+
+// snapshotSDNs returns the fingerprint of each SDN keyed by its EntityID. Only OFAC's SDN and Consolidated
+// records are fingerprinted, so delta runs screen rows against every other list in full.
+func snapshotSDNs(sdns []*SDN) map[string]string {
+	out := make(map[string]string, len(sdns))
+	for i := range sdns {
+		if sdns[i] == nil || sdns[i].SDN == nil {
+			continue
+		}
+		out[sdns[i].EntityID] = sdnHash(sdns[i].SDN)
+	}
+	return out
+}
+
+// diffSnapshot compares two snapshots and returns which entities were added, modified or removed.
+func diffSnapshot(previous, current map[string]string) *entityChanges {
+	changes := &entityChanges{}
+	for id, hash := range current {
+		prev, exists := previous[id]
+		switch {
+		case !exists:
+			changes.Added = append(changes.Added, id)
+		case prev != hash:
+			changes.Modified = append(changes.Modified, id)
+		}
+	}
+	for id := range previous {
+		if _, exists := current[id]; !exists {
+			changes.Removed = append(changes.Removed, id)
+		}
+	}
+	sortEntityIDs(changes.Added)
+	sortEntityIDs(changes.Modified)
+	sortEntityIDs(changes.Removed)
+	return changes
+}
+
+func sortEntityIDs(ids []string) {
+	sort.Slice(ids, func(i, j int) bool {
+		return lessEntityID(ids[i], ids[j])
+	})
+}
+
+// lessEntityID orders numeric IDs by their value, shorter IDs first
+func lessEntityID(x, y string) bool {
+	if len(x) != len(y) {
+		return len(x) < len(y)
+	}
+	return x < y
+}
+
+// matchKey identifies a match across every list by the list and ID of its record, or its name when
+// the record has no ID (e.g. Unverified List records of the older CSL format)
+func matchKey(entity source.Entity) string {
+	if entity.SourceID == "" {
+		return entity.SourceList + ":" + entity.Name
+	}
+	return entity.SourceList + ":" + entity.SourceID
+}
+
+// sdnMatchID returns the EntityID of an SDN matched with key, or false when key is from another list.
+func sdnMatchID(key string) (string, bool) {
+	list, id, ok := strings.Cut(key, ":")
+	if !ok || (list != ofac.SDNList && list != ofac.ConsolidatedList) {
+		return "", false
+	}
+	return id, true
+}
+
+// withSDNs returns a searcher which only contains the SDNs whose EntityID is in ids (and their Alts),
+// along with every other list of s. It shares the pipeline and worker gate of s.
+func (s *searcher) withSDNs(ids map[string]bool) *searcher {
+	s.RLock()
+	defer s.RUnlock()
+
+	out := &searcher{
+		Addresses:       s.Addresses,
+		sdnAlts:         &sdnAltGroups{positions: make(map[string]int, len(ids))},
+		sources:         s.sources,
+		sourceData:      s.sourceData,
+		lastRefreshedAt: s.lastRefreshedAt,
+		snapshot:        s.snapshot,
+		missingSources:  s.missingSources,
+		Gate:            s.Gate,
+		pipe:            s.pipe,
+		scorer:          s.scorer,
+		explainMatches:  s.explainMatches,
+		serial:          s.serial,
+		logger:          s.logger,
+	}
+	// SDNs are found by their position rather than scanning every SDN, and kept in the order of s
+	groups := s.altGroups()
+	positions := make([]int, 0, len(ids))
+	for id := range ids {
+		if i, ok := groups.positions[id]; ok {
+			positions = append(positions, i)
+		}
+	}
+	sort.Ints(positions)
+
+	for _, i := range positions {
+		out.SDNs = append(out.SDNs, s.SDNs[i])
+		out.sdnAlts.positions[s.SDNs[i].EntityID] = len(out.SDNs) - 1
+		out.sdnAlts.alts = append(out.sdnAlts.alts, groups.alts[i])
+		for _, alt := range groups.alts[i] {
+			out.Alts = append(out.Alts, alt)
+			out.sdnAlts.sdns = append(out.sdnAlts.sdns, len(out.SDNs)-1)
+		}
+	}
+	return out
+}
+
+// screenedInput is a previously screened row from an input file along with the records it matched.
+type screenedInput struct {
+	InputID string
+	Name    string
+	Email   string
+	Hash    string
+
+	// Matches holds the candidates TopEntities and TopSDNs returned (up to the limit of each search) keyed
+	// by their matchKey, not just the top matches included in the response, so the SDNs among them are
+	// scored again by delta runs and hits can be recomputed when a better match is removed.
+	Matches map[string]float64
+
+	ScreenedAt time.Time
+}
+
+func newScreenedInput(inputID string, resp *searchResponse) *screenedInput {
+	in := &screenedInput{
+		InputID:    inputID,
+		Name:       resp.FullName,
+		Email:      resp.Email,
+		Hash:       resp.Hash,
+		Matches:    resp.matchKeys(),
+		ScreenedAt: time.Now(),
+	}
+	return in
+}
+
+// deltaResponse describes how the hits for a previously screened input changed after a list update.
+type deltaResponse struct {
+	ID       string                   `json:"id"`
+	Email    string                   `json:"email"`
+	FullName string                   `json:"fullName"`
+	SDNs     []*SDN                   `json:"SDNs"`
+	Entities []*Result[source.Entity] `json:"entities"`
+	Match    *float64                 `json:"match"`
+
+	Hash         string `json:"hash"`
+	PreviousHash string `json:"previousHash,omitempty"`
+	Changed      bool   `json:"changed"`
+
+	// Added and Removed are the hits which appeared or disappeared, as list:ID (see matchKey)
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+
+	// Metadata
+	RefreshedAt time.Time `json:"refreshedAt"`
+}
+
+// buildDeltaSearchResponse re-screens a previously screened input only against the SDNs it matched before
+// and those which changed since it was last screened, along with every other list. When prior is nil, or
+// the input's name or email differ from when it was screened, the input is screened against every SDN.
+func buildDeltaSearchResponse(searcher *searcher, changes *entityChanges, prior *screenedInput, limit int, minMatch float64, name string, email string) (*searchResponse, *deltaResponse) {
+	var resp *searchResponse
+	if prior == nil || prior.Name != name || prior.Email != email {
+		resp = buildFullSearchResponse(searcher, limit, minMatch, name, email)
+	} else {
+		// SDNs which were removed since aren't found
+		ids := changes.changed()
+		for key := range prior.Matches {
+			if entityID, ok := sdnMatchID(key); ok {
+				ids[entityID] = true
+			}
+		}
+		subset := searcher.withSDNs(ids)
+		resp = newSearchResponse(searcher, name, email, subset.TopEntities(limit, minMatch, name), subset.TopSDNs(limit, minMatch, name))
+	}
+
+	delta := &deltaResponse{
+		Email:       resp.Email,
+		FullName:    resp.FullName,
+		SDNs:        resp.SDNs,
+		Entities:    resp.Entities,
+		Match:       resp.Match,
+		Hash:        resp.Hash,
+		Added:       make([]string, 0),
+		Removed:     make([]string, 0),
+		RefreshedAt: resp.RefreshedAt,
+	}
+
+	previous := make(map[string]bool)
+	if prior != nil {
+		delta.PreviousHash = prior.Hash
+		for key := range prior.Matches {
+			previous[key] = true
+		}
+	}
+	current := resp.matchKeys()
+	for key := range current {
+		if !previous[key] {
+			delta.Added = append(delta.Added, key)
+		}
+	}
+	for key := range previous {
+		if _, ok := current[key]; !ok {
+			delta.Removed = append(delta.Removed, key)
+		}
+	}
+	sort.Strings(delta.Added)
+	sort.Strings(delta.Removed)
+
+	delta.Changed = delta.Hash != delta.PreviousHash || len(delta.Added) > 0 || len(delta.Removed) > 0
+
+	return resp, delta
+}
+
+// appendScreenedRows adds every previously screened input missing from rows so a delta run covers
+// all inputs screened before, not only those in the latest input file.
+func appendScreenedRows(repo screeningRepository, rows []FileRow) ([]FileRow, error) {
+	inputs, err := repo.listScreenedInputs()
+	if err != nil {
+		return rows, err
+	}
+	seen := make(map[string]bool, len(rows))
+	for i := range rows {
+		seen[rows[i].Id] = true
+	}
+	for _, in := range inputs {
+		if seen[in.InputID] {
+			continue
+		}
+		rows = append(rows, FileRow{Id: in.InputID, Name: in.Name, Email: in.Email})
+	}
+	return rows, nil
+}
+
+type screeningRepository interface {
+	snapshotHashes() (map[string]string, error)
+	replaceSnapshot(hashes map[string]string) error
+
+	getScreenedInput(inputID string) (*screenedInput, error)
+	listScreenedInputs() ([]*screenedInput, error)
+	recordScreenedInputs(inputs []*screenedInput) error
+}
+
+type sqliteScreeningRepository struct {
+	db     *sql.DB
+	logger log.Logger
+}
+
+func (r *sqliteScreeningRepository) close() error {
+	return r.db.Close()
+}
+
+func (r *sqliteScreeningRepository) snapshotHashes() (map[string]string, error) {
+	rows, err := r.db.Query(`select entity_id, hash from sdn_snapshot;`)
+	if err != nil {
+		return nil, fmt.Errorf("snapshotHashes: %v", err)
+	}
+	defer rows.Close()
+
+	out := make(map[string]string)
+	for rows.Next() {
+		var entityID, hash string
+		if err := rows.Scan(&entityID, &hash); err == nil {
+			out[entityID] = hash
+		}
+	}
+	return out, rows.Err()
+}
+
+func (r *sqliteScreeningRepository) replaceSnapshot(hashes map[string]string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("replaceSnapshot: begin: %v", err)
+	}
+	if _, err := tx.Exec(`delete from sdn_snapshot;`); err != nil {
+		return fmt.Errorf("replaceSnapshot: delete error=%v rollback=%v", err, tx.Rollback())
+	}
+	stmt, err := tx.Prepare(`insert into sdn_snapshot (entity_id, hash) values (?, ?);`)
+	if err != nil {
+		return fmt.Errorf("replaceSnapshot: prepare error=%v rollback=%v", err, tx.Rollback())
+	}
+	defer stmt.Close()
+
+	for entityID, hash := range hashes {
+		if _, err := stmt.Exec(entityID, hash); err != nil {
+			return fmt.Errorf("replaceSnapshot: insert error=%v rollback=%v", err, tx.Rollback())
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *sqliteScreeningRepository) getScreenedInput(inputID string) (*screenedInput, error) {
+	if inputID == "" {
+		return nil, errors.New("getScreenedInput: no input ID")
+	}
+	query := `select name, email, hash, matches, screened_at from screened_inputs where input_id = ? limit 1;`
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	in := screenedInput{InputID: inputID}
+	var matches string
+	err = stmt.QueryRow(inputID).Scan(&in.Name, &in.Email, &in.Hash, &matches, &in.ScreenedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows in result set") {
+			return nil, nil // not found
+		}
+		return nil, fmt.Errorf("getScreenedInput: %v", err)
+	}
+	if err := json.Unmarshal([]byte(matches), &in.Matches); err != nil {
+		return nil, fmt.Errorf("getScreenedInput: matches: %v", err)
+	}
+	return &in, nil
+}
+
+func (r *sqliteScreeningRepository) listScreenedInputs() ([]*screenedInput, error) {
+	rows, err := r.db.Query(`select input_id, name, email, hash, matches, screened_at from screened_inputs order by screened_at asc;`)
+	if err != nil {
+		return nil, fmt.Errorf("listScreenedInputs: %v", err)
+	}
+	defer rows.Close()
+
+	var out []*screenedInput
+	for rows.Next() {
+		var in screenedInput
+		var matches string
+		if err := rows.Scan(&in.InputID, &in.Name, &in.Email, &in.Hash, &matches, &in.ScreenedAt); err != nil {
+			continue
+		}
+		if err := json.Unmarshal([]byte(matches), &in.Matches); err != nil {
+			continue
+		}
+		out = append(out, &in)
+	}
+	return out, rows.Err()
+}
+
+func (r *sqliteScreeningRepository) recordScreenedInputs(inputs []*screenedInput) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("recordScreenedInputs: begin: %v", err)
+	}
+	query := `insert or replace into screened_inputs (input_id, name, email, hash, matches, screened_at) values (?, ?, ?, ?, ?, ?);`
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return fmt.Errorf("recordScreenedInputs: prepare error=%v rollback=%v", err, tx.Rollback())
+	}
+	defer stmt.Close()
+
+	for _, in := range inputs {
+		if in == nil || in.InputID == "" {
+			continue
+		}
+		matches, err := json.Marshal(in.Matches)
+		if err != nil {
+			return fmt.Errorf("recordScreenedInputs: matches error=%v rollback=%v", err, tx.Rollback())
+		}
+		if _, err := stmt.Exec(in.InputID, in.Name, in.Email, in.Hash, string(matches), in.ScreenedAt); err != nil {
+			return fmt.Errorf("recordScreenedInputs: insert error=%v rollback=%v", err, tx.Rollback())
+		}
+	}
+	return tx.Commit()
+}
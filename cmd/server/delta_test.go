@@ -0,0 +1,218 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/internal/database"
+	"github.com/moov-io/watchman/pkg/ofac"
+	"github.com/moov-io/watchman/pkg/source"
+
+	"github.com/stretchr/testify/require"
+)
+
+func deltaTestSearcher(sdns []*ofac.SDN) *searcher {
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.SDNs = precomputeSDNs(sdns, nil, noLogPipeliner)
+	return s
+}
+
+func TestDelta__diffSnapshot(t *testing.T) {
+	previous := map[string]string{"1": "a", "2": "b", "3": "c"}
+	current := map[string]string{"1": "a", "2": "B", "4": "d", "10": "e"}
+
+	changes := diffSnapshot(previous, current)
+	require.Equal(t, []string{"4", "10"}, changes.Added)
+	require.Equal(t, []string{"2"}, changes.Modified)
+	require.Equal(t, []string{"3"}, changes.Removed)
+
+	require.Len(t, changes.changed(), 3)
+
+	changes = diffSnapshot(nil, current)
+	require.Len(t, changes.Added, 4)
+}
+
+func TestDelta__snapshotSDNs(t *testing.T) {
+	s := deltaTestSearcher([]*ofac.SDN{
+		{EntityID: "2681", SDNName: "HAWATMA, Nayif", SDNType: "individual"},
+	})
+	first := snapshotSDNs(s.SDNs)
+	require.Len(t, first, 1)
+
+	s = deltaTestSearcher([]*ofac.SDN{
+		{EntityID: "2681", SDNName: "HAWATMA, Nayif", SDNType: "individual", Remarks: "DOB 1933."},
+	})
+	second := snapshotSDNs(s.SDNs)
+	require.NotEqual(t, first["2681"], second["2681"])
+}
+
+func TestDelta__withSDNs(t *testing.T) {
+	s := deltaTestSearcher([]*ofac.SDN{
+		{EntityID: "2681", SDNName: "HAWATMA, Nayif", SDNType: "individual"},
+		{EntityID: "559", SDNName: "CIMEX", SDNType: "entity"},
+		{EntityID: "306", SDNName: "BANCO NACIONAL DE CUBA", SDNType: "entity"},
+	})
+	s.Alts = precomputeAlts([]*ofac.AlternateIdentity{
+		{EntityID: "306", AlternateID: "220", AlternateType: "aka", AlternateName: "BNC"},
+	}, noLogPipeliner)
+	s.sdnAlts = groupSDNAlts(s.SDNs, s.Alts)
+
+	// SDNs are kept in their order, with their Alts, and unknown IDs are skipped
+	subset := s.withSDNs(map[string]bool{"306": true, "2681": true, "1": true})
+	require.Len(t, subset.SDNs, 2)
+	require.Equal(t, "2681", subset.SDNs[0].EntityID)
+	require.Equal(t, "306", subset.SDNs[1].EntityID)
+	require.Len(t, subset.Alts, 1)
+	require.Equal(t, []int{1}, subset.sdnAlts.sdns)
+	require.Equal(t, map[string]int{"2681": 0, "306": 1}, subset.sdnAlts.positions)
+
+	require.Empty(t, s.withSDNs(nil).SDNs)
+}
+
+func TestDelta__buildDeltaSearchResponse(t *testing.T) {
+	before := deltaTestSearcher([]*ofac.SDN{
+		{EntityID: "2676", SDNName: "AL ZAWAHIRI, Dr. Ayman", SDNType: "individual"},
+		{EntityID: "2681", SDNName: "HAWATMA, Nayif", SDNType: "individual"},
+	})
+
+	// Initial screening with no prior results
+	resp, delta := buildDeltaSearchResponse(before, &entityChanges{}, nil, 10, 0.90, "Nayif Hawatma", "")
+	require.Len(t, resp.SDNs, 1)
+	require.Equal(t, []string{"SDN:2681"}, delta.Added)
+	require.Empty(t, delta.Removed)
+	require.True(t, delta.Changed)
+	prior := newScreenedInput("abc", resp)
+
+	// Nothing changed on the list
+	_, delta = buildDeltaSearchResponse(before, &entityChanges{}, prior, 10, 0.90, "Nayif Hawatma", "")
+	require.Empty(t, delta.Added)
+	require.Empty(t, delta.Removed)
+	require.Equal(t, prior.Hash, delta.Hash)
+	require.False(t, delta.Changed)
+
+	// One SDN is removed and another is added
+	after := deltaTestSearcher([]*ofac.SDN{
+		{EntityID: "2676", SDNName: "AL ZAWAHIRI, Dr. Ayman", SDNType: "individual"},
+		{EntityID: "9999", SDNName: "HAWATMEH, Nayif", SDNType: "individual"},
+	})
+	changes := diffSnapshot(snapshotSDNs(before.SDNs), snapshotSDNs(after.SDNs))
+
+	resp, delta = buildDeltaSearchResponse(after, changes, prior, 10, 0.90, "Nayif Hawatma", "")
+	require.Len(t, resp.SDNs, 1)
+	require.Equal(t, "9999", resp.SDNs[0].EntityID)
+	require.Equal(t, []string{"SDN:9999"}, delta.Added)
+	require.Equal(t, []string{"SDN:2681"}, delta.Removed)
+	require.Equal(t, prior.Hash, delta.PreviousHash)
+	require.True(t, delta.Changed)
+
+	// Every SDN is screened again once the input's name changes
+	resp, delta = buildDeltaSearchResponse(before, &entityChanges{}, prior, 10, 0.90, "Ayman al Zawahiri", "")
+	require.Len(t, resp.SDNs, 1)
+	require.Equal(t, "2676", resp.SDNs[0].EntityID)
+	require.Equal(t, []string{"SDN:2676"}, delta.Added)
+	require.Equal(t, []string{"SDN:2681"}, delta.Removed)
+	require.True(t, delta.Changed)
+
+	// or its email
+	_, delta = buildDeltaSearchResponse(before, &entityChanges{}, prior, 10, 0.90, "Nayif Hawatma", "nayif@example.com")
+	require.Empty(t, delta.Added)
+	require.Empty(t, delta.Removed)
+	require.NotEqual(t, prior.Hash, delta.Hash)
+
+	// Other lists are screened in full on every delta run
+	before.setLists("EU", source.List{Name: "EU", Entities: []*source.Entity{
+		{Name: "Nayif Hawatma", SourceList: "EU", SourceID: "13"},
+	}})
+	resp, delta = buildDeltaSearchResponse(before, &entityChanges{}, prior, 10, 0.90, "Nayif Hawatma", "")
+	require.Equal(t, "EU", resp.Entities[0].Data.SourceList)
+	require.Equal(t, []string{"EU:13"}, delta.Added)
+	require.Empty(t, delta.Removed)
+	require.True(t, delta.Changed)
+}
+
+func TestDelta__matchKey(t *testing.T) {
+	require.Equal(t, "SDN:2681", matchKey(source.Entity{SourceList: ofac.SDNList, SourceID: "2681"}))
+	require.Equal(t, "DTCs:Gwendolyn Douglas", matchKey(source.Entity{SourceList: "DTCs", Name: "Gwendolyn Douglas"}))
+
+	id, ok := sdnMatchID("Consolidated:17016")
+	require.True(t, ok)
+	require.Equal(t, "17016", id)
+
+	_, ok = sdnMatchID("DPL:82 F.R. 61745 12/29/2017")
+	require.False(t, ok)
+	_, ok = sdnMatchID("2681")
+	require.False(t, ok)
+}
+
+func TestDelta__appendScreenedRows(t *testing.T) {
+	sqliteDB := database.CreateTestSqliteDB(t)
+	defer sqliteDB.Close()
+
+	repo := &sqliteScreeningRepository{sqliteDB.DB, log.NewNopLogger()}
+	err := repo.recordScreenedInputs([]*screenedInput{
+		{InputID: "1", Name: "John Doe", Matches: map[string]float64{}, ScreenedAt: time.Now()},
+		{InputID: "2", Name: "Jane Doe", Matches: map[string]float64{}, ScreenedAt: time.Now()},
+	})
+	require.NoError(t, err)
+
+	rows, err := appendScreenedRows(repo, []FileRow{{Id: "2", Name: "Jane Smith"}, {Id: "3", Name: "Jim Doe"}})
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	require.Equal(t, "Jane Smith", rows[0].Name)
+	require.Equal(t, "1", rows[2].Id)
+}
+
+func TestScreeningRepository(t *testing.T) {
+	sqliteDB := database.CreateTestSqliteDB(t)
+	defer sqliteDB.Close()
+
+	repo := &sqliteScreeningRepository{sqliteDB.DB, log.NewNopLogger()}
+
+	// snapshot
+	hashes, err := repo.snapshotHashes()
+	require.NoError(t, err)
+	require.Empty(t, hashes)
+
+	require.NoError(t, repo.replaceSnapshot(map[string]string{"1": "a", "2": "b"}))
+	require.NoError(t, repo.replaceSnapshot(map[string]string{"2": "c"}))
+
+	hashes, err = repo.snapshotHashes()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"2": "c"}, hashes)
+
+	// screened inputs
+	in, err := repo.getScreenedInput("abc")
+	require.NoError(t, err)
+	require.Nil(t, in)
+
+	_, err = repo.getScreenedInput("")
+	require.Error(t, err)
+
+	err = repo.recordScreenedInputs([]*screenedInput{
+		{
+			InputID:    "abc",
+			Name:       "Nayif Hawatma",
+			Email:      "nayif@example.com",
+			Hash:       "1234",
+			Matches:    map[string]float64{"2681": 0.97},
+			ScreenedAt: time.Now(),
+		},
+	})
+	require.NoError(t, err)
+
+	in, err = repo.getScreenedInput("abc")
+	require.NoError(t, err)
+	require.NotNil(t, in)
+	require.Equal(t, "Nayif Hawatma", in.Name)
+	require.Equal(t, "1234", in.Hash)
+	require.InDelta(t, 0.97, in.Matches["2681"], 0.001)
+
+	inputs, err := repo.listScreenedInputs()
+	require.NoError(t, err)
+	require.Len(t, inputs, 1)
+}
@@ -6,17 +6,20 @@ package main
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/moov-io/base/log"
-	"github.com/moov-io/watchman/pkg/csl"
-	"github.com/moov-io/watchman/pkg/dpl"
+	"github.com/moov-io/watchman/pkg/bundle"
 	"github.com/moov-io/watchman/pkg/ofac"
 
 	"github.com/djherbis/times"
@@ -62,10 +65,33 @@ type DownloadStats struct {
 	MilitaryEndUsers  int `json:"militaryEndUsers"`
 	SectoralSanctions int `json:"sectoralSanctions"`
 
+	// Lists holds the count of records of every list read from a Source, keyed by list name
+	Lists map[string]int `json:"lists"`
+
 	Errors      []error   `json:"-"`
 	RefreshedAt time.Time `json:"timestamp"`
 }
 
+// setListCount records the count of records read for the named list. Lists which were
+// read before Sources existed are also kept in their own fields.
+func (ss *DownloadStats) setListCount(name string, count int) {
+	if ss.Lists == nil {
+		ss.Lists = make(map[string]int)
+	}
+	ss.Lists[name] = count
+
+	switch name {
+	case "BISEntities":
+		ss.BISEntities = count
+	case "MilitaryEndUsers":
+		ss.MilitaryEndUsers = count
+	case "SSIs":
+		ss.SectoralSanctions = count
+	case "DPL":
+		ss.DeniedPersons = count
+	}
+}
+
 func (ss *DownloadStats) Error() string {
 	var buf bytes.Buffer
 	for i := range ss.Errors {
@@ -91,14 +117,18 @@ func (ss *DownloadStats) MarshalJSON() ([]byte, error) {
 
 // periodicDataRefresh will forever block for interval's duration and then download and reparse the data.
 // Download stats are recorded as part of a successful re-download and parse.
-func (s *searcher) periodicDataRefresh(interval time.Duration, downloadRepo downloadRepository, updates chan *DownloadStats) {
+func (s *searcher) periodicDataRefresh(ctx context.Context, interval time.Duration, downloadRepo downloadRepository, updates chan *DownloadStats) {
 	if interval == 0*time.Second {
 		s.logger.Logf("not scheduling periodic refreshing duration=%v", interval)
 		return
 	}
 	for {
-		time.Sleep(interval)
-		stats, err := s.refreshData("")
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+		stats, err := s.refreshData(ctx, "")
 		if err != nil {
 			if s.logger != nil {
 				s.logger.Info().Logf("ERROR: refreshing data: %v", err)
@@ -106,86 +136,27 @@ func (s *searcher) periodicDataRefresh(interval time.Duration, downloadRepo down
 		} else {
 			downloadRepo.recordStats(stats)
 			if s.logger != nil {
-				s.logger.Info().With(log.Fields{
+				fields := log.Fields{
 					// OFAC
 					"SDNs":      log.Int(stats.SDNs),
 					"AltNames":  log.Int(stats.Alts),
 					"Addresses": log.Int(stats.Addresses),
-
-					// BIS
-					"DPL": log.Int(stats.DeniedPersons),
-
-					// CSL
-					"BISEntities":      log.Int(stats.BISEntities),
-					"MilitaryEndUsers": log.Int(stats.MilitaryEndUsers),
-					"SSI":              log.Int(stats.SectoralSanctions),
-				}).Logf("data refreshed %v ago", time.Since(stats.RefreshedAt))
+				}
+				// every other list, such as DPL, BISEntities or EU
+				for name, count := range stats.Lists {
+					fields[name] = log.Int(count)
+				}
+				s.logger.Info().With(fields).Logf("data refreshed %v ago", time.Since(stats.RefreshedAt))
 			}
 			updates <- stats // send stats for re-search and watch notifications
 		}
 	}
 }
 
-func ofacRecords(logger log.Logger, initialDir string) (*ofac.Results, error) {
-	files, err := ofac.Download(logger, initialDir)
-	if err != nil {
-		return nil, fmt.Errorf("download: %v", err)
-	}
-	if len(files) == 0 {
-		return nil, errors.New("no OFAC Results")
-	}
-
-	var res *ofac.Results
-
-	for i := range files {
-		if i == 0 {
-			rr, err := ofac.Read(files[i])
-			if err != nil {
-				return nil, fmt.Errorf("read: %v", err)
-			}
-			if rr != nil {
-				res = rr
-			}
-		} else {
-			rr, err := ofac.Read(files[i])
-			if err != nil {
-				return nil, fmt.Errorf("read and replace: %v", err)
-			}
-			if rr != nil {
-				res.Addresses = append(res.Addresses, rr.Addresses...)
-				res.AlternateIdentities = append(res.AlternateIdentities, rr.AlternateIdentities...)
-				res.SDNs = append(res.SDNs, rr.SDNs...)
-				res.SDNComments = append(res.SDNComments, rr.SDNComments...)
-			}
-		}
-	}
-	return res, err
-}
-
-func dplRecords(logger log.Logger, initialDir string) ([]*dpl.DPL, error) {
-	file, err := dpl.Download(logger, initialDir)
-	if err != nil {
-		return nil, err
-	}
-	return dpl.Read(file)
-}
-
-func cslRecords(logger log.Logger, initialDir string) (*csl.CSL, error) {
-	file, err := csl.Download(logger, initialDir)
-	if err != nil {
-		logger.Warn().LogErrorf("skipping CSL download: %v", err)
-		return &csl.CSL{}, nil
-	}
-	cslRecords, err := csl.ReadFile(file)
-	if err != nil {
-		return nil, err
-	}
-	return cslRecords, err
-}
-
 // refreshData reaches out to the various websites to download the latest
-// files, runs each list's parser, and index data for searches.
-func (s *searcher) refreshData(initialDir string) (*DownloadStats, error) {
+// files, runs each list's parser, and index data for searches. Downloads are
+// abandoned once ctx is done.
+func (s *searcher) refreshData(ctx context.Context, initialDir string) (*DownloadStats, error) {
 	if s.logger != nil {
 		s.logger.Log("Starting refresh of data")
 
@@ -200,64 +171,148 @@ func (s *searcher) refreshData(initialDir string) (*DownloadStats, error) {
 
 	lastDataRefreshFailure.WithLabelValues("SDNs").Set(float64(time.Now().Unix()))
 
-	results, err := ofacRecords(s.logger, initialDir)
+	var sdns []*SDN
+	var adds []*Address
+	var alts []*Alt
+
+	var bundled []string
+	if s.bundleKey != nil {
+		// Only files listed in a verified bundle are read, nothing is downloaded
+		var err error
+		bundled, err = bundle.Verify(initialDir, s.bundleKey)
+		if err != nil {
+			stats.Errors = append(stats.Errors, fmt.Errorf("bundle: %v", err))
+			return stats, stats
+		}
+	}
+
+	// OFAC's records are precomputed into SDNs, Alts and Addresses rather than read as Lists
+	var files []string
+	var err error
+	ofacSource := s.ofacSource()
+	if ofacSource != nil {
+		files, err = s.sourceFiles(ctx, ofacSource, initialDir, bundled)
+	}
 	if err != nil {
 		lastDataRefreshFailure.WithLabelValues("SDNs").Set(float64(time.Now().Unix()))
 		stats.Errors = append(stats.Errors, fmt.Errorf("OFAC: %v", err))
 	}
 
-	sdns := precomputeSDNs(results.SDNs, results.Addresses, s.pipe)
-	adds := precomputeAddresses(results.Addresses)
-	alts := precomputeAlts(results.AlternateIdentities, s.pipe)
+	// Skip parsing and precomputing when the files are identical to our last refresh,
+	// which is the case when servers respond with 304 Not Modified. After a restart the
+	// records are loaded from the search index file instead, see searchIndexPath.
+	fingerprint, _ := filesFingerprint(files)
+	s.RLock()
+	unchanged := fingerprint != "" && fingerprint == s.ofacFingerprint
+	s.RUnlock()
+
+	switch {
+	case ofacSource == nil:
+		// disabled with DISABLED_LISTS
+
+	case err != nil:
+		// download failed, see stats.Errors
+
+	case unchanged:
+		s.logger.Log("OFAC files are unchanged, keeping existing records")
+		s.RLock()
+		sdns, adds, alts = s.SDNs, s.Addresses, s.Alts
+		s.RUnlock()
+
+	default:
+		// Load the records precomputed from these files by an earlier run
+		if s.indexFile != nil {
+			sdns, adds, alts, err = s.indexFile.load(fingerprint)
+			if err == nil {
+				s.logger.Logf("loaded precomputed OFAC records from %s", s.indexFile.path)
+				break
+			}
+			s.logger.Logf("rebuilding search index: %v", err)
+			err = nil
+		}
+
+		var results *ofac.Results
+		results, files, err = ofac.ReadFiles(files)
+		if err != nil {
+			lastDataRefreshFailure.WithLabelValues("SDNs").Set(float64(time.Now().Unix()))
+			stats.Errors = append(stats.Errors, fmt.Errorf("OFAC: %v", err))
+			break
+		}
+		sdns = precomputeSDNs(results.SDNs, results.Addresses, s.pipe)
+		adds = precomputeAddresses(results.Addresses)
+		alts = precomputeAlts(results.AlternateIdentities, s.pipe)
+
+		if s.indexFile != nil {
+			if err := s.indexFile.save(fingerprint, sdns, adds, alts); err != nil {
+				s.logger.Warn().LogErrorf("problem saving search index: %v", err)
+			}
+		}
+	}
 
-	// deniedPersons, err := dplRecords(s.logger, initialDir)
-	// if err != nil {
-	// 	lastDataRefreshFailure.WithLabelValues("DPs").Set(float64(time.Now().Unix()))
-	// 	stats.Errors = append(stats.Errors, fmt.Errorf("DPL: %v", err))
-	// }
-	// dps := precomputeDPs(deniedPersons, s.pipe)
-	// els := precomputeCSLEntities(consolidatedLists.ELs, s.pipe)
-	// meus := precomputeCSLEntities(consolidatedLists.MEUs, s.pipe)
-	// ssis := precomputeCSLEntities(consolidatedLists.SSIs, s.pipe)
+	sources, sourceErrs := s.refreshSources(ctx, initialDir, bundled)
 
 	// OFAC
+	sdns, adds, alts = enabledOFACLists(sdns, adds, alts)
 	stats.SDNs = len(sdns)
 	stats.Alts = len(alts)
 	stats.Addresses = len(adds)
-	// BIS
-	// stats.DeniedPersons = len(dps)
-	// CSL
-	// stats.BISEntities = len(els)
-	// stats.MilitaryEndUsers = len(meus)
-	// stats.SectoralSanctions = len(ssis)
+	if ofacSource != nil && len(stats.Errors) == 0 {
+		for name, count := range ofacListCounts(sdns) {
+			stats.setListCount(name, count)
+		}
+	}
+	// every other list
+	for _, data := range sources {
+		for _, list := range data.lists {
+			stats.setListCount(list.name, len(list.entities))
+		}
+	}
 
 	// record prometheus metrics
 	// lastDataRefreshCount.WithLabelValues("SDNs").Set(float64(len(sdns)))
-	// lastDataRefreshCount.WithLabelValues("SSIs").Set(float64(len(ssis)))
-	// lastDataRefreshCount.WithLabelValues("BISEntities").Set(float64(len(els)))
-	// lastDataRefreshCount.WithLabelValues("MilitaryEndUsers").Set(float64(len(meus)))
-	// lastDataRefreshCount.WithLabelValues("DPs").Set(float64(len(dps)))
+	for name, count := range stats.Lists {
+		lastDataRefreshCount.WithLabelValues(name).Set(float64(count))
+	}
 
 	if len(stats.Errors) > 0 {
+		stats.Errors = append(stats.Errors, sourceErrs...)
 		return stats, stats
 	}
 
+	// Keep a copy of the files for point-in-time searches, once every list was refreshed
+	if s.snapshots != nil && len(sourceErrs) == 0 {
+		if dir, err := s.snapshots.save(s.archivedFiles(files, sources), stats.RefreshedAt); err != nil {
+			s.logger.Warn().LogErrorf("problem archiving snapshot: %v", err)
+		} else if dir != "" {
+			s.logger.Logf("archived snapshot to %s", dir)
+		}
+	}
+
+	// Index names for searches
+	sdnIndex, altIndex := indexSDNs(sdns), indexAlts(alts)
+	sdnAlts := groupSDNAlts(sdns, alts)
+
 	// Set new records after precomputation (to minimize lock contention)
 	s.Lock()
 	// OFAC
 	s.SDNs = sdns
 	s.Addresses = adds
 	s.Alts = alts
-	// BIS
-	// s.DPs = dps
-	// CSL
-	//s.BISEntities = els
-	//s.MilitaryEndUsers = meus
-	//s.SSIs = ssis
+	s.sdnIndex, s.altIndex = sdnIndex, altIndex
+	s.sdnAlts = sdnAlts
+	s.ofacFingerprint = fingerprint
+	// every other list
+	s.sourceData = sources
 	// metadata
 	s.lastRefreshedAt = stats.RefreshedAt
 	s.Unlock()
 
+	// Lists which couldn't be refreshed kept their existing records, see refreshSource
+	if len(sourceErrs) > 0 {
+		stats.Errors = append(stats.Errors, sourceErrs...)
+		return stats, stats
+	}
+
 	if s.logger != nil {
 		s.logger.Log("Finished refresh of data")
 	}
@@ -268,6 +323,31 @@ func (s *searcher) refreshData(initialDir string) (*DownloadStats, error) {
 	return stats, nil
 }
 
+// filesFingerprint returns a SHA-256 checksum over the name and contents of every file
+// to detect when a source is unchanged between refreshes.
+func filesFingerprint(files []string) (string, error) {
+	if len(files) == 0 {
+		return "", nil
+	}
+	sorted := make([]string, len(files))
+	copy(sorted, files)
+	sort.Strings(sorted)
+
+	h := sha256.New()
+	for i := range sorted {
+		if info, err := os.Stat(sorted[i]); err == nil && info.IsDir() {
+			continue
+		}
+		sum, err := fileChecksum(sorted[i])
+		if err != nil {
+			return "", err
+		}
+		h.Write([]byte(filepath.Base(sorted[i])))
+		h.Write(sum)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 func lastRefresh(filename string) time.Time {
 	lastRefreshed := time.Now().Add(-time.Hour * 24)
 
@@ -311,7 +391,24 @@ func (r *sqliteDownloadRepository) recordStats(stats *DownloadStats) error {
 	defer stmt.Close()
 
 	_, err = stmt.Exec(stats.RefreshedAt, stats.SDNs, stats.Alts, stats.Addresses, stats.SectoralSanctions, stats.DeniedPersons, stats.BISEntities)
-	return err
+	if err != nil {
+		return err
+	}
+
+	// Lists read from each Source are kept in their own table so adding a list doesn't need a migration
+	query = `insert into download_list_stats (downloaded_at, list, records) values (?, ?, ?);`
+	listStmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer listStmt.Close()
+
+	for name, count := range stats.Lists {
+		if _, err := listStmt.Exec(stats.RefreshedAt, name, count); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (r *sqliteDownloadRepository) latestDownloads(limit int) ([]DownloadStats, error) {
@@ -335,5 +432,39 @@ func (r *sqliteDownloadRepository) latestDownloads(limit int) ([]DownloadStats,
 			downloads = append(downloads, dl)
 		}
 	}
-	return downloads, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range downloads {
+		if err := r.readListCounts(&downloads[i]); err != nil {
+			return nil, err
+		}
+	}
+	return downloads, nil
+}
+
+// readListCounts fills in the count of each list read from a Source in stats
+func (r *sqliteDownloadRepository) readListCounts(stats *DownloadStats) error {
+	query := `select list, records from download_list_stats where downloaded_at = ?;`
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(stats.RefreshedAt)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		var count int
+		if err := rows.Scan(&name, &count); err == nil {
+			stats.setListCount(name, count)
+		}
+	}
+	return rows.Err()
 }
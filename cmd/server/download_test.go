@@ -0,0 +1,390 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/internal/database"
+	"github.com/moov-io/watchman/pkg/bundle"
+	"github.com/moov-io/watchman/pkg/dpl"
+	"github.com/moov-io/watchman/pkg/ofac"
+	"github.com/moov-io/watchman/pkg/source"
+	"github.com/moov-io/watchman/pkg/un"
+	"github.com/moov-io/watchman/pkg/watchlist"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownload__filesFingerprint(t *testing.T) {
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a.csv"), filepath.Join(dir, "b.csv")
+	require.NoError(t, os.WriteFile(a, []byte("a"), 0600))
+	require.NoError(t, os.WriteFile(b, []byte("b"), 0600))
+
+	first, err := filesFingerprint([]string{a, b})
+	require.NoError(t, err)
+	require.NotEmpty(t, first)
+
+	// order doesn't matter
+	second, err := filesFingerprint([]string{b, a})
+	require.NoError(t, err)
+	require.Equal(t, first, second)
+
+	require.NoError(t, os.WriteFile(b, []byte("bb"), 0600))
+	third, err := filesFingerprint([]string{a, b})
+	require.NoError(t, err)
+	require.NotEqual(t, first, third)
+
+	empty, err := filesFingerprint(nil)
+	require.NoError(t, err)
+	require.Empty(t, empty)
+}
+
+func TestDownload__refreshDataUnchanged(t *testing.T) {
+	dir := filepath.Join("..", "..", "test", "testdata")
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+
+	stats, err := s.refreshData(context.Background(), dir)
+	require.NoError(t, err)
+	require.Greater(t, stats.SDNs, 0)
+	require.NotEmpty(t, s.ofacFingerprint)
+
+	sdns := s.SDNs
+
+	// A second refresh over the same files keeps the precomputed records
+	stats, err = s.refreshData(context.Background(), dir)
+	require.NoError(t, err)
+	require.Equal(t, len(sdns), stats.SDNs)
+	require.Same(t, sdns[0], s.SDNs[0])
+}
+
+func TestDownload__refreshDataBundle(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	src := filepath.Join("..", "..", "test", "testdata")
+	dir := t.TempDir()
+	_, err = bundle.Create(dir, []string{
+		filepath.Join(src, "add.csv"),
+		filepath.Join(src, "alt.csv"),
+		filepath.Join(src, "sdn.csv"),
+		filepath.Join(src, "sdn_comments.csv"),
+	}, priv)
+	require.NoError(t, err)
+
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.bundleKey = pub
+
+	stats, err := s.refreshData(context.Background(), dir)
+	require.NoError(t, err)
+	require.Greater(t, stats.SDNs, 0)
+
+	// A modified file is rejected before it's parsed
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sdn.csv"), []byte("1,\"EVIL\"\n"), 0600))
+	_, err = s.refreshData(context.Background(), dir)
+	require.ErrorContains(t, err, "sdn.csv: checksum mismatch")
+}
+
+func TestDownload__refreshDataConsolidated(t *testing.T) {
+	dir := filepath.Join("..", "..", "test", "testdata")
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+
+	_, err := s.refreshData(context.Background(), dir)
+	require.NoError(t, err)
+
+	// Consolidated records are searched alongside SDNs and show their list
+	sdns := s.TopSDNs(1, 0.0, "Abdul Rahman Khan")
+	require.Len(t, sdns, 1)
+	require.Equal(t, "24813", sdns[0].EntityID)
+	require.Equal(t, ofac.ConsolidatedList, sdns[0].SourceList)
+
+	bs, err := json.Marshal(sdns[0])
+	require.NoError(t, err)
+	require.Contains(t, string(bs), `"sourceList":"Consolidated"`)
+
+	sdn := s.debugSDN("17016")
+	require.NotNil(t, sdn)
+	require.Equal(t, ofac.ConsolidatedList, sdn.SourceList)
+	require.Len(t, s.FindAlts(10, "17016"), 1)
+	require.Len(t, s.FindAddresses(10, "17016"), 1)
+}
+
+func TestDownload__refreshDataOFACLists(t *testing.T) {
+	dir := filepath.Join("..", "..", "test", "testdata")
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+
+	// Each OFAC list is counted
+	stats, err := s.refreshData(context.Background(), dir)
+	require.NoError(t, err)
+	require.Greater(t, stats.Lists[ofac.SDNList], 0)
+	require.Greater(t, stats.Lists[ofac.ConsolidatedList], 0)
+	require.Equal(t, stats.SDNs, stats.Lists[ofac.SDNList]+stats.Lists[ofac.ConsolidatedList])
+
+	// and can be disabled
+	disabled := disabledLists
+	t.Cleanup(func() { disabledLists = disabled })
+	disabledLists = source.ParseDisabled("consolidated")
+
+	s = newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	stats, err = s.refreshData(context.Background(), dir)
+	require.NoError(t, err)
+	require.Greater(t, stats.Lists[ofac.SDNList], 0)
+	require.NotContains(t, stats.Lists, ofac.ConsolidatedList)
+	require.Equal(t, stats.SDNs, stats.Lists[ofac.SDNList])
+
+	require.Nil(t, s.debugSDN("17016"))
+	require.Empty(t, s.FindAlts(10, "17016"))
+	require.Empty(t, s.FindAddresses(10, "17016"))
+	for _, sdn := range s.TopSDNs(10, 0.0, "Abdul Rahman Khan") {
+		require.Equal(t, ofac.SDNList, sdn.SourceList)
+	}
+}
+
+func TestDownload__refreshDataEU(t *testing.T) {
+	dir := filepath.Join("..", "..", "test", "testdata")
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+
+	stats, err := s.refreshData(context.Background(), dir)
+	require.NoError(t, err)
+	require.Equal(t, 3, stats.Lists["EU"])
+
+	found := s.TopEUEntities(1, 0.0, "Vladimir Abdualiyevich Vasilyev")
+	require.Len(t, found, 1)
+	require.Equal(t, "6891", found[0].Data.LogicalID)
+	require.Len(t, found[0].Data.Regulations, 1)
+
+	// Rows are screened against the EU list and each hit is attributed to it
+	resp := buildFullSearchResponse(s, 10, 0.90, "Vladimir Abdualiyevich Vasilyev", "")
+	require.Len(t, resp.Entities, 1)
+	require.Equal(t, "EU", resp.Entities[0].Data.SourceList)
+	require.Equal(t, "6891", resp.Entities[0].Data.SourceID)
+	require.Empty(t, resp.SDNs)
+
+	bs, err := json.Marshal(resp)
+	require.NoError(t, err)
+	require.Contains(t, string(bs), `"sourceList":"EU"`)
+}
+
+func TestDownload__refreshDataUN(t *testing.T) {
+	dir := filepath.Join("..", "..", "test", "testdata")
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+
+	stats, err := s.refreshData(context.Background(), dir)
+	require.NoError(t, err)
+	require.Equal(t, 3, stats.Lists["UN"])
+
+	found := s.TopUNEntities(1, 0.0, "Ri Won Ho")
+	require.Len(t, found, 1)
+	require.Equal(t, "KPi.033", found[0].Data.ReferenceNumber)
+
+	// Rows are screened against the UN list along with OFAC, and each hit is attributed to its list
+	resp := buildFullSearchResponse(s, 10, 0.90, "Ri Won Ho", "")
+	require.Len(t, resp.Entities, 2)
+	require.Equal(t, ofac.SDNList, resp.Entities[0].Data.SourceList)
+	require.Equal(t, un.SourceList, resp.Entities[1].Data.SourceList)
+	require.Equal(t, "6908555", resp.Entities[1].Data.SourceID)
+	require.Equal(t, []string{"DPRK"}, resp.Entities[1].Data.Programs)
+}
+
+func TestDownload__refreshDataUK(t *testing.T) {
+	dir := filepath.Join("..", "..", "test", "testdata")
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+
+	stats, err := s.refreshData(context.Background(), dir)
+	require.NoError(t, err)
+	require.Equal(t, 2, stats.Lists["UK"])
+
+	found := s.TopUKEntities(1, 0.0, "Rosoboronexport")
+	require.Len(t, found, 1)
+	require.Equal(t, "Russia", found[0].Data.Regime)
+
+	// Rows are screened against OFSI targets along with OFAC, and each hit is attributed to its list
+	resp := buildFullSearchResponse(s, 10, 0.90, "Rosoboronexport", "")
+	require.Len(t, resp.Entities, 2)
+	require.Equal(t, ofac.SDNList, resp.Entities[0].Data.SourceList)
+	require.Equal(t, "UK", resp.Entities[1].Data.SourceList)
+	require.Equal(t, "13074", resp.Entities[1].Data.SourceID)
+
+	// Delta runs re-screen them as well
+	prior := newScreenedInput("1", resp)
+	resp, delta := buildDeltaSearchResponse(s, &entityChanges{}, prior, 10, 0.90, "Rosoboronexport", "")
+	require.Len(t, resp.Entities, 2)
+	require.Equal(t, "UK", resp.Entities[1].Data.SourceList)
+	require.Empty(t, delta.Added)
+	require.Empty(t, delta.Removed)
+}
+
+func TestDownload__refreshDataCSL(t *testing.T) {
+	dir := filepath.Join("..", "..", "test", "testdata")
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+
+	stats, err := s.refreshData(context.Background(), dir)
+	require.NoError(t, err)
+
+	// per-list counts of test/testdata/csl.csv
+	require.Equal(t, 22, stats.BISEntities)
+	require.Equal(t, 26, stats.SectoralSanctions)
+	require.Equal(t, 4, stats.Lists["UVLs"])
+	require.Equal(t, 3, stats.Lists["ISNs"])
+	require.Equal(t, 13, stats.Lists["PLCs"])
+	require.Equal(t, 1, stats.Lists["CAPs"])
+	require.Equal(t, 10, stats.Lists["DTCs"])
+
+	// Rows are screened against every sub-list and each hit is attributed to its sub-list
+	resp := buildFullSearchResponse(s, 10, 0.90, "Khalid Sulayman Fayiz Abu Hasan", "")
+	require.Len(t, resp.Entities, 2)
+	require.Equal(t, "PLCs", resp.Entities[0].Data.SourceList)
+	require.Equal(t, "9689", resp.Entities[0].Data.SourceID)
+	require.Equal(t, ofac.SDNList, resp.Entities[1].Data.SourceList)
+
+	for name, list := range map[string]string{
+		"Gwen Douglas":                      "DTCs",
+		"Karamay City Commercial Bank":      "CAPs",
+		"Gulf Modern Solutions Engineering": "UVLs",
+	} {
+		resp = buildFullSearchResponse(s, 10, 0.90, name, "")
+		require.Len(t, resp.Entities, 1, name)
+		require.Equal(t, list, resp.Entities[0].Data.SourceList, name)
+		require.Empty(t, resp.SDNs, name)
+	}
+}
+
+func TestDownload__refreshDataDPL(t *testing.T) {
+	dir := filepath.Join("..", "..", "test", "testdata")
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+
+	stats, err := s.refreshData(context.Background(), dir)
+	require.NoError(t, err)
+	require.Greater(t, stats.DeniedPersons, 0)
+	require.Equal(t, stats.DeniedPersons, stats.Lists[dpl.ListName])
+
+	found := s.TopDPs(1, 0.0, "Adrian Manuel Hernandez")
+	require.Len(t, found, 1)
+	require.Equal(t, "PHONEIX", found[0].Data.City)
+}
+
+func TestDownload__refreshDataDisabledOFAC(t *testing.T) {
+	disabled := disabledLists
+	t.Cleanup(func() { disabledLists = disabled })
+	disabledLists = source.ParseDisabled("OFAC")
+
+	dir := filepath.Join("..", "..", "test", "testdata")
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	require.Nil(t, s.ofacSource())
+
+	// Every other list is still read
+	stats, err := s.refreshData(context.Background(), dir)
+	require.NoError(t, err)
+	require.Equal(t, 0, stats.SDNs)
+	require.Empty(t, s.TopSDNs(10, 0.0, "Abdul Rahman Khan"))
+	require.Equal(t, 3, stats.Lists["EU"])
+}
+
+func TestDownload__refreshDataSourceError(t *testing.T) {
+	dir := t.TempDir()
+	src, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "watchlist_fraud.csv"))
+	require.NoError(t, err)
+	path := filepath.Join(dir, "fraud.csv")
+	require.NoError(t, os.WriteFile(path, src, 0600))
+
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.addSource(watchlist.Source{
+		Lists: []watchlist.List{
+			{
+				Name:    "fraudsters",
+				Path:    path,
+				Columns: watchlist.Columns{Name: "Full Name", Aliases: "Also Known As"},
+			},
+		},
+	})
+	ctx := context.Background()
+	_, err = s.refreshData(ctx, filepath.Join("..", "..", "test", "testdata"))
+	require.NoError(t, err)
+	require.Len(t, s.sourceEntities(watchlist.SourceName), 3)
+
+	// A source which can't be read is reported along with OFAC errors, and keeps its records
+	require.NoError(t, os.Remove(path))
+	stats, err := s.refreshData(ctx, filepath.Join("..", "..", "test", "testdata"))
+	require.Error(t, err)
+	require.Len(t, stats.Errors, 1)
+	require.Contains(t, stats.Errors[0].Error(), watchlist.SourceName)
+	require.Len(t, s.sourceEntities(watchlist.SourceName), 3)
+
+	// while the other lists are refreshed
+	require.Greater(t, len(s.SDNs), 0)
+	require.Greater(t, stats.Lists[dpl.ListName], 0)
+	require.Len(t, s.sourceEntities(dpl.ListName), stats.Lists[dpl.ListName])
+}
+
+func TestDownload__reloadSource(t *testing.T) {
+	dir := t.TempDir()
+	src, err := os.ReadFile(filepath.Join("..", "..", "test", "testdata", "watchlist_fraud.csv"))
+	require.NoError(t, err)
+	path := filepath.Join(dir, "fraud.csv")
+	require.NoError(t, os.WriteFile(path, src, 0600))
+
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.addSource(watchlist.Source{
+		Lists: []watchlist.List{
+			{
+				Name:    "fraudsters",
+				Path:    path,
+				Columns: watchlist.Columns{Name: "Full Name", Aliases: "Also Known As"},
+			},
+		},
+	})
+	ctx := context.Background()
+	require.True(t, s.reloadSource(ctx, watchlist.SourceName))
+	require.Len(t, s.sourceEntities(watchlist.SourceName), 3)
+
+	// Unchanged files aren't read again
+	require.False(t, s.reloadSource(ctx, watchlist.SourceName))
+
+	// Edits are picked up
+	src = append(src, []byte("C-1004,Petra Lindqvist,,individual,,,\n")...)
+	require.NoError(t, os.WriteFile(path, src, 0600))
+	require.True(t, s.reloadSource(ctx, watchlist.SourceName))
+	require.Len(t, s.sourceEntities(watchlist.SourceName), 4)
+
+	found := s.TopWatchlistEntities(1, 0.0, "Petra Lindqvist")
+	require.Len(t, found, 1)
+	require.Equal(t, "fraudsters", found[0].Data.ListName)
+
+	// Existing records are kept when the file can't be read
+	require.NoError(t, os.Remove(path))
+	require.False(t, s.reloadSource(ctx, watchlist.SourceName))
+	require.Len(t, s.sourceEntities(watchlist.SourceName), 4)
+
+	// Unknown sources aren't reloaded
+	require.False(t, s.reloadSource(ctx, "other"))
+}
+
+func TestDownloadRepository(t *testing.T) {
+	sqliteDB := database.CreateTestSqliteDB(t)
+	defer sqliteDB.Close()
+
+	repo := &sqliteDownloadRepository{sqliteDB.DB, log.NewNopLogger()}
+
+	stats := &DownloadStats{SDNs: 12, RefreshedAt: time.Now()}
+	stats.setListCount("SSIs", 26)
+	stats.setListCount("fraudsters", 3)
+	require.NoError(t, repo.recordStats(stats))
+
+	downloads, err := repo.latestDownloads(1)
+	require.NoError(t, err)
+	require.Len(t, downloads, 1)
+	require.Equal(t, 12, downloads[0].SDNs)
+	require.Equal(t, 26, downloads[0].SectoralSanctions)
+	require.Equal(t, map[string]int{"SSIs": 26, "fraudsters": 3}, downloads[0].Lists)
+}
@@ -6,7 +6,9 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -15,11 +17,15 @@ import (
 	"regexp"
 	"runtime"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/moov-io/base/log"
 	"github.com/moov-io/watchman/internal/database"
+	"github.com/moov-io/watchman/pkg/bundle"
+	"github.com/moov-io/watchman/pkg/download"
+	"github.com/moov-io/watchman/pkg/watchlist"
 )
 
 var (
@@ -33,6 +39,15 @@ var (
 	flagLimitFileRows = flag.Int("limit-file-rows", 0, "Limit the number of rows in the input file")
 	flagDataDirectory = flag.String("data-directory", "", "Directory to download data to")
 	flagSqliteFile    = flag.String("sqlite-db-path", "watchman.db", "Sqlite file to use")
+	flagSnapshotDir   = flag.String("snapshot-directory", "", "Directory to archive list files into after each refresh")
+	flagSearchIndex   = flag.String("search-index-file", "", "File to persist precomputed OFAC records to, loaded at startup while the list files are unchanged")
+	flagAsOf          = flag.String("as-of", "", "Screen against the archived snapshot current at this date (2006-01-02 or RFC 3339)")
+	flagBundleKey     = flag.String("bundle-public-key", "", "Ed25519 public key (PEM) to verify the data directory as a signed bundle with")
+	flagDelta         = flag.Bool("delta", false, "Only re-screen previously screened rows against OFAC SDN and Consolidated records changed since the last run, every other list is screened in full")
+	flagWatchlists    = flag.String("watchlists-config", "", "JSON file of internal lists to screen each row against, with hits attributed to the list's name")
+	flagNameVariants  = flag.String("name-variants", "", "File of name variants to score as exact matches, added to the defaults")
+	flagScorer        = flag.String("scorer", "", "Score every list with this scorer rather than the scorer of each list (e.g. token-set)")
+	flagExplain       = flag.Bool("explain", false, "Explain how each match was scored, including the processed names and word alignment")
 )
 
 type FileRow struct {
@@ -61,22 +76,74 @@ func main() {
 		*flagDataDirectory = v
 	}
 
+	if v := os.Getenv("SNAPSHOT_DIRECTORY"); v != "" && !flagPassed("snapshot-directory") {
+		*flagSnapshotDir = v
+	}
+
+	if v := os.Getenv("SEARCH_INDEX_FILE"); v != "" && !flagPassed("search-index-file") {
+		*flagSearchIndex = v
+	}
+
+	if v := os.Getenv("BUNDLE_PUBLIC_KEY"); v != "" && !flagPassed("bundle-public-key") {
+		*flagBundleKey = v
+	}
+
+	if v := os.Getenv("WATCHLISTS_CONFIG"); v != "" && !flagPassed("watchlists-config") {
+		*flagWatchlists = v
+	}
+
+	if v := os.Getenv("NAME_VARIANTS_FILE"); v != "" && !flagPassed("name-variants") {
+		*flagNameVariants = v
+	}
+
+	if v := os.Getenv("SCORER"); v != "" && !flagPassed("scorer") {
+		*flagScorer = v
+	}
+
+	if v := os.Getenv("EXPLAIN_MATCHES"); v != "" && !flagPassed("explain") {
+		explain, err := strconv.ParseBool(v)
+		if err != nil {
+			logger.LogErrorf("invalid EXPLAIN_MATCHES: %v", err)
+		}
+		*flagExplain = explain
+	}
+
+	var runScorer scorer
+	if *flagScorer != "" {
+		sc, err := lookupScorer(*flagScorer)
+		if err != nil {
+			logger.LogErrorf("invalid scorer: %v", err)
+			os.Exit(1)
+		}
+		runScorer = sc
+	}
+
+	var asOf time.Time
+	if *flagAsOf != "" {
+		when, err := parseAsOf(*flagAsOf)
+		if err != nil {
+			logger.LogErrorf("invalid as-of: %v", err)
+			os.Exit(1)
+		}
+		if *flagDelta {
+			logger.LogError(errors.New("as-of and delta screening can not be combined"))
+			os.Exit(1)
+		}
+		asOf = when
+	}
+
 	lastRefreshed := lastRefresh(*flagSqliteFile)
-	if time.Since(lastRefreshed) > (time.Hour * 12) {
+	// Delta screening needs the previous snapshot and screened rows kept in the database
+	if time.Since(lastRefreshed) > (time.Hour*12) && !*flagDelta {
 		logger.Logf("last refresh was %v ago, refreshing data", time.Since(lastRefreshed))
 		if err := os.Remove(*flagSqliteFile); err != nil {
 			logger.LogErrorf("error removing %s: %v", *flagSqliteFile, err)
 		}
 	}
 
-	// Channel for errors
-	errs := make(chan error)
-
-	go func() {
-		c := make(chan os.Signal, 1)
-		signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
-		errs <- fmt.Errorf("signal: %v", <-c)
-	}()
+	// Abandon downloads when we're asked to stop
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
 	// Setup database connection
 	db, err := database.New(os.Getenv("DATABASE_TYPE"), *flagSqliteFile)
@@ -94,6 +161,26 @@ func main() {
 	downloadRepo := &sqliteDownloadRepository{db, logger}
 	defer downloadRepo.close()
 
+	if *flagNameVariants != "" {
+		if err := nameVariants.ReadFile(*flagNameVariants); err != nil {
+			logger.LogErrorf("ERROR: %v", err)
+			os.Exit(1)
+		}
+		logger.Logf("read name variants from %s", *flagNameVariants)
+	}
+
+	// Configure downloads, keeping the last copy of each file when configured
+	download.HTTPClient, err = download.NewHTTPClient(download.ConfigFromEnvironment())
+	if err != nil {
+		logger.LogErrorf("ERROR: download HTTP client: %v", err)
+		os.Exit(1)
+	}
+	download.DefaultCache, err = download.CacheFromEnvironment()
+	if err != nil {
+		logger.LogErrorf("ERROR: %v", err)
+		os.Exit(1)
+	}
+
 	var pipeline *pipeliner
 	if debug, err := strconv.ParseBool(os.Getenv("DEBUG_NAME_PIPELINE")); debug && err == nil {
 		pipeline = newPipeliner(logger)
@@ -102,8 +189,58 @@ func main() {
 	}
 	searcher := newSearcher(logger, pipeline, *flagWorkers)
 
-	// Initial download of data
-	if stats, err := searcher.refreshData(*flagDataDirectory); err != nil {
+	snapshots, err := newSnapshotArchive(*flagSnapshotDir)
+	if err != nil {
+		logger.LogErrorf("ERROR: %v", err)
+		os.Exit(1)
+	}
+	searcher.snapshots = snapshots
+	searcher.indexFile = newSearchIndexFile(searchIndexPath(*flagSearchIndex, download.DefaultCache))
+
+	if *flagBundleKey != "" {
+		if *flagDataDirectory == "" {
+			logger.LogError(errors.New("a data directory is required to verify a bundle"))
+			os.Exit(1)
+		}
+		searcher.bundleKey, err = bundle.ReadPublicKey(*flagBundleKey)
+		if err != nil {
+			logger.LogErrorf("ERROR: reading bundle public key: %v", err)
+			os.Exit(1)
+		}
+	}
+
+	var watchlists []watchlist.List
+	if *flagWatchlists != "" {
+		watchlists, err = watchlist.ReadConfig(*flagWatchlists)
+		if err != nil {
+			logger.LogErrorf("ERROR: reading watchlists: %v", err)
+			os.Exit(1)
+		}
+		searcher.addSource(watchlist.Source{Lists: watchlists})
+	}
+
+	if !asOf.IsZero() {
+		// Load the snapshot current at the requested date instead of the latest data
+		searcher, err = snapshots.searcherAsOf(logger, pipeline, *flagWorkers, asOf)
+		if err != nil {
+			logger.LogErrorf("ERROR: failed to load snapshot: %v", err)
+			os.Exit(1)
+		}
+		// Internal lists aren't archived, so they're screened as they are now
+		if *flagWatchlists != "" {
+			searcher.addSource(watchlist.Source{Lists: watchlists})
+			searcher.reloadSource(ctx, watchlist.SourceName)
+		}
+		logger.Info().With(log.Fields{
+			"SDNs":     log.Int(len(searcher.SDNs)),
+			"snapshot": log.String(searcher.snapshot),
+		}).Logf("loaded snapshot as of %v", asOf.Format(time.RFC3339))
+		if len(searcher.missingSources) > 0 {
+			logger.Warn().Logf("snapshot %s has no files for %s, which are not screened",
+				searcher.snapshot, strings.Join(searcher.missingSources, ", "))
+		}
+	} else if stats, err := searcher.refreshData(ctx, *flagDataDirectory); err != nil {
+		// Initial download of data
 		logger.LogErrorf("ERROR: failed to download/parse initial data: %v", err)
 		os.Exit(1)
 	} else {
@@ -114,41 +251,125 @@ func main() {
 		}).Logf("data refreshed %v ago", time.Since(stats.RefreshedAt))
 	}
 
+	searcher.explainMatches = *flagExplain
+
+	if *flagWatchlists != "" {
+		go searcher.periodicSourceReload(ctx, watchlistReloadInterval(logger), watchlist.SourceName)
+	}
+
+	if runScorer != nil {
+		// Score the rows of this run with one scorer, such as to compare scorers against each other
+		searcher = searcher.withScorer(runScorer)
+		logger.Logf("scoring every list with %s", runScorer.name())
+	}
+
 	// Setup company / customer repositories
 	custRepo := &sqliteCustomerRepository{db, logger}
 	defer custRepo.close()
 
+	// Compare the refreshed SDNs against those from the previous run
+	screeningRepo := &sqliteScreeningRepository{db, logger}
+	defer screeningRepo.close()
+
+	previousSnapshot, err := screeningRepo.snapshotHashes()
+	if err != nil {
+		logger.LogErrorf("ERROR: failed to read SDN snapshot: %v", err)
+	}
+	currentSnapshot := snapshotSDNs(searcher.SDNs)
+	changes := diffSnapshot(previousSnapshot, currentSnapshot)
+	logger.Info().With(log.Fields{
+		"added":    log.Int(len(changes.Added)),
+		"modified": log.Int(len(changes.Modified)),
+		"removed":  log.Int(len(changes.Removed)),
+	}).Log("compared SDNs against previous snapshot")
+
 	// Parse input file
 	rows, err := parseFile(*flagInputFile, *flagDelimiter)
 
-	if err != nil {
+	switch {
+	case err == nil:
+		// Remove input file
+		if err = os.Remove(*flagInputFile); err != nil {
+			logger.LogErrorf("ERROR: failed to remove input file: %v", err)
+		}
+
+		rows = rows[1:]
+
+	case *flagDelta && os.IsNotExist(err):
+		// Delta runs re-screen the previously screened rows without a new input file
+
+	default:
 		logger.Fatal()
 	}
 
-	// Remove input file
-	if err = os.Remove(*flagInputFile); err != nil {
-		logger.LogErrorf("ERROR: failed to remove input file: %v", err)
+	if *flagDelta {
+		rows, err = appendScreenedRows(screeningRepo, rows)
+		if err != nil {
+			logger.LogErrorf("ERROR: failed to read previously screened rows: %v", err)
+		}
 	}
 
-	rows = rows[1:]
-
 	if *flagLimitFileRows > 0 {
 		rows = rows[:*flagLimitFileRows]
 	}
 
-	var arr []*searchResponse
+	var data []byte
+	var screened []*screenedInput
 
-	for _, row := range rows {
-		resp := buildFullSearchResponse(searcher, *flagSearchResults, *flagThreshold, row.Name, row.Email)
-		arr = append(arr, resp)
-	}
+	// Rows are screened across the workers and kept in the order of the input file
+	serial := searcher.serially()
+
+	if *flagDelta {
+		priors := make([]*screenedInput, len(rows))
+		for i, row := range rows {
+			priors[i], err = screeningRepo.getScreenedInput(row.Id)
+			if err != nil {
+				logger.LogErrorf("ERROR: failed to read previous screening of %s: %v", row.Id, err)
+			}
+		}
+
+		type screenedDelta struct {
+			resp  *searchResponse
+			delta *deltaResponse
+		}
+		results := screenRows(rows, *flagWorkers, func(i int, row FileRow) screenedDelta {
+			resp, delta := buildDeltaSearchResponse(serial, changes, priors[i], *flagSearchResults, *flagThreshold, row.Name, row.Email)
+			delta.ID = row.Id
+			return screenedDelta{resp: resp, delta: delta}
+		})
+
+		var arr []*deltaResponse
+		for i := range results {
+			arr = append(arr, results[i].delta)
+			screened = append(screened, newScreenedInput(rows[i].Id, results[i].resp))
+		}
 
-	data, err := json.Marshal(arr)
+		data, err = json.Marshal(arr)
+	} else {
+		arr := screenRows(rows, *flagWorkers, func(_ int, row FileRow) *searchResponse {
+			return buildFullSearchResponse(serial, *flagSearchResults, *flagThreshold, row.Name, row.Email)
+		})
+		for i := range arr {
+			screened = append(screened, newScreenedInput(rows[i].Id, arr[i]))
+		}
+
+		data, err = json.Marshal(arr)
+	}
 
 	if err != nil {
 		logger.LogErrorf("ERROR: failed to marshal search results: %v", err)
 	}
 
+	// Record what was screened for future delta runs, lookbacks against archived data are not recorded
+	if asOf.IsZero() {
+		if err := screeningRepo.recordScreenedInputs(screened); err != nil {
+			logger.LogErrorf("ERROR: failed to record screened rows: %v", err)
+		}
+		if err := screeningRepo.replaceSnapshot(currentSnapshot); err != nil {
+			logger.LogErrorf("ERROR: failed to record SDN snapshot: %v", err)
+		}
+	}
+
 	fmt.Printf("%s", data)
 
 	if err := os.WriteFile(*flagOutputFile, data, 0644); err != nil {
@@ -169,6 +390,23 @@ func main() {
 	}
 }
 
+// watchlistReloadInterval reads WATCHLISTS_RELOAD_INTERVAL, where "off" disables reloading
+func watchlistReloadInterval(logger log.Logger) time.Duration {
+	v := os.Getenv("WATCHLISTS_RELOAD_INTERVAL")
+	switch v {
+	case "":
+		return time.Minute
+	case "off":
+		return 0 * time.Second
+	}
+	interval, err := time.ParseDuration(v)
+	if err != nil {
+		logger.LogErrorf("invalid WATCHLISTS_RELOAD_INTERVAL %q: %v", v, err)
+		return time.Minute
+	}
+	return interval
+}
+
 func flagPassed(name string) bool {
 	found := false
 
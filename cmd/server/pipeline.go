@@ -7,10 +7,10 @@ package main
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/moov-io/base/log"
 	"github.com/moov-io/watchman/pkg/csl"
-	"github.com/moov-io/watchman/pkg/dpl"
 	"github.com/moov-io/watchman/pkg/ofac"
 )
 
@@ -27,11 +27,7 @@ type Name struct {
 	alt   *ofac.AlternateIdentity
 	sdn   *ofac.SDN
 	ssi   *csl.SSI
-	dp    *dpl.DPL
-	el    *csl.EL
 	addrs []*ofac.Address
-
-	altNames []string
 }
 
 func sdnName(sdn *ofac.SDN, addrs []*ofac.Address) *Name {
@@ -51,38 +47,6 @@ func altName(alt *ofac.AlternateIdentity) *Name {
 	}
 }
 
-func dpName(dp *dpl.DPL) *Name {
-	return &Name{
-		Original:  dp.Name,
-		Processed: dp.Name,
-		dp:        dp,
-	}
-}
-
-func cslName(item interface{}) *Name {
-	switch v := item.(type) {
-	case *csl.EL:
-		return &Name{
-			Original:  v.Name,
-			Processed: v.Name,
-			el:        v,
-		}
-	case *csl.MEU:
-		return &Name{
-			Original:  v.Name,
-			Processed: v.Name,
-		}
-	case *csl.SSI:
-		return &Name{
-			Original:  v.Name,
-			Processed: v.Name,
-			ssi:       v,
-			altNames:  v.AlternateNames,
-		}
-	}
-	return &Name{}
-}
-
 type step interface {
 	apply(*Name) error
 }
@@ -110,6 +74,7 @@ func newPipeliner(logger log.Logger) *pipeliner {
 	return &pipeliner{
 		logger: logger,
 		steps: []step{
+			&debugStep{logger: logger, step: &transliterateStep{}},
 			&debugStep{logger: logger, step: &reorderSDNStep{}},
 			&debugStep{logger: logger, step: &stopwordsStep{}},
 			&debugStep{logger: logger, step: &normalizeStep{}},
@@ -136,3 +101,41 @@ func (p *pipeliner) Do(name *Name) error {
 	}
 	return nil
 }
+
+// pipelineChange is a step which changed a name and the name it left
+type pipelineChange struct {
+	Step   string `json:"step"`
+	Result string `json:"result"`
+}
+
+// Trace runs name through each step like Do, returning the steps which changed it. Steps
+// aren't logged even when the pipeline is debugging.
+func (p *pipeliner) Trace(name *Name) ([]pipelineChange, error) {
+	if p == nil || p.steps == nil || name == nil {
+		return nil, errors.New("nil pipeliner or Name")
+	}
+	var out []pipelineChange
+	for i := range p.steps {
+		st := p.steps[i]
+		if ds, ok := st.(*debugStep); ok {
+			st = ds.step
+		}
+		before := name.Processed
+		if err := st.apply(name); err != nil {
+			return out, fmt.Errorf("pipeline: %v", err)
+		}
+		if name.Processed != before {
+			out = append(out, pipelineChange{Step: stepName(st), Result: name.Processed})
+		}
+	}
+	return out, nil
+}
+
+// stepName returns the name of a step's type without its package or suffix (e.g. "normalize")
+func stepName(st step) string {
+	name := fmt.Sprintf("%T", st)
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return strings.TrimSuffix(name, "Step")
+}
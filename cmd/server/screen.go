@@ -0,0 +1,57 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"sync"
+)
+
+// serially returns a copy of the searcher which scores the records of each search in the calling
+// goroutine rather than starting one for each record.
+func (s *searcher) serially() *searcher {
+	out := s.withScorer(s.scorer)
+	out.serial = true
+	return out
+}
+
+// screenRows screens every row with screen across workers goroutines and returns the results in
+// the order of rows.
+//
+// Rows are spread across the workers rather than the records of each search, so screen should search
+// with a serial searcher (see searcher.serially) which scores records one after another. This avoids
+// starting a goroutine for every record of every row, which dominates the time taken to screen large files.
+func screenRows[T any](rows []FileRow, workers int, screen func(i int, row FileRow) T) []T {
+	if len(rows) == 0 {
+		return nil
+	}
+	out := make([]T, len(rows))
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(rows) {
+		workers = len(rows)
+	}
+
+	next := make(chan int)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				out[i] = screen(i, rows[i])
+			}
+		}()
+	}
+	for i := range rows {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	return out
+}
@@ -0,0 +1,128 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/source"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScreenRows(t *testing.T) {
+	var rows []FileRow
+	for i := 0; i < 100; i++ {
+		rows = append(rows, FileRow{Id: fmt.Sprintf("%d", i), Name: fmt.Sprintf("name %d", i)})
+	}
+
+	// Results are in the order of rows however many workers there are
+	for _, workers := range []int{-1, 0, 1, 4, 1000} {
+		out := screenRows(rows, workers, func(i int, row FileRow) string {
+			return fmt.Sprintf("%d:%s", i, row.Name)
+		})
+		require.Len(t, out, len(rows))
+		for i := range rows {
+			require.Equal(t, fmt.Sprintf("%d:name %d", i, i), out[i])
+		}
+	}
+
+	require.Nil(t, screenRows(nil, 4, func(i int, row FileRow) string { return row.Id }))
+}
+
+func TestSearcher_serially(t *testing.T) {
+	indexed, scanned := benchSearchers(t)
+	require.False(t, scanned.serial)
+	require.True(t, scanned.serially().serial)
+	require.Equal(t, levenshteinScorer{}, scanned.withScorer(levenshteinScorer{}).serially().scorer)
+
+	// Scoring records one after another finds the same SDNs
+	for _, query := range benchQueries(scanned.SDNs, 2459) {
+		for _, s := range []*searcher{indexed, scanned} {
+			expected := s.TopSDNs(10, 0.85, query)
+			found := s.serially().TopSDNs(10, 0.85, query)
+
+			require.Len(t, found, len(expected), query)
+			for i := range expected {
+				require.Equal(t, expected[i].EntityID, found[i].EntityID, query)
+				require.Equal(t, expected[i].match, found[i].match, query)
+			}
+		}
+	}
+
+	// Delta screening keeps the searcher serial
+	require.True(t, scanned.serially().withSDNs(map[string]bool{"306": true}).serial)
+}
+
+func TestSearcher_serially__TopEntities(t *testing.T) {
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.setLists("watchlist", source.List{Name: "Internal", Entities: []*source.Entity{
+		{Name: "Acme Trading Company", AlternateNames: []string{"Acme Exports"}},
+		{Name: "Zenith Holdings"},
+		{Name: "Acme Holdings"},
+	}})
+
+	for _, query := range []string{"Acme Trading", "Zenith Holdings", "Acme"} {
+		expected := s.TopEntities(10, 0.5, query)
+		found := s.serially().TopEntities(10, 0.5, query)
+
+		require.Len(t, found, len(expected), query)
+		for i := range expected {
+			require.Equal(t, expected[i].Data.Name, found[i].Data.Name, query)
+			require.Equal(t, expected[i].match, found[i].match, query)
+		}
+	}
+}
+
+// benchRows are the bench queries as rows of an input file
+func benchRows(sdns []*SDN) []FileRow {
+	var rows []FileRow
+	for i, query := range benchQueries(sdns, 487) {
+		rows = append(rows, FileRow{Id: fmt.Sprintf("%d", i), Name: query})
+	}
+	return rows
+}
+
+func BenchmarkScreenRows(b *testing.B) {
+	indexed, scanned := benchSearchers(b)
+	rows := benchRows(scanned.SDNs)
+	workers := 4
+
+	for _, bench := range []struct {
+		name string
+		s    *searcher
+	}{
+		{"scan", scanned},
+		{"index", indexed},
+	} {
+		s := bench.s
+
+		// Rows one after another, each scored with a goroutine per record
+		b.Run(bench.name+"/sequential", func(b *testing.B) {
+			start := time.Now()
+			for n := 0; n < b.N; n++ {
+				for _, row := range rows {
+					buildFullSearchResponse(s, 10, 0.95, row.Name, row.Email)
+				}
+			}
+			b.ReportMetric(float64(b.N*len(rows))/time.Since(start).Seconds(), "rows/s")
+		})
+
+		// Rows spread across the workers, each scored serially
+		b.Run(bench.name+"/workers", func(b *testing.B) {
+			serial := s.serially()
+			start := time.Now()
+			for n := 0; n < b.N; n++ {
+				screenRows(rows, workers, func(_ int, row FileRow) *searchResponse {
+					return buildFullSearchResponse(serial, 10, 0.95, row.Name, row.Email)
+				})
+			}
+			b.ReportMetric(float64(b.N*len(rows))/time.Since(start).Seconds(), "rows/s")
+		})
+	}
+}
@@ -6,6 +6,7 @@ package main
 
 import (
 	"bytes"
+	"crypto/ed25519"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -16,9 +17,8 @@ import (
 	"time"
 
 	"github.com/moov-io/base/log"
-	"github.com/moov-io/watchman/pkg/csl"
-	"github.com/moov-io/watchman/pkg/dpl"
 	"github.com/moov-io/watchman/pkg/ofac"
+	"github.com/moov-io/watchman/pkg/source"
 
 	"github.com/xrash/smetrics"
 	"go4.org/syncutil"
@@ -32,21 +32,45 @@ type searcher struct {
 	Addresses []*Address
 	Alts      []*Alt
 
-	// BIS
-	DPs []*DP
+	// indexes narrow the SDNs and Alts scored against each query, see nameIndex
+	sdnIndex, altIndex *nameIndex
+	sdnAlts            *sdnAltGroups
 
-	// US Consolidated Screening List
-	BISEntities      []*Result[csl.EL]
-	MilitaryEndUsers []*Result[csl.MEU]
-	SSIs             []*Result[csl.SSI]
+	// Every other list, such as the DPL, CSL, EU, UN and UK lists, is read from a Source
+	sources    []source.Source
+	sourceData map[string]*sourceData // keyed by source name
 
 	// metadata
 	lastRefreshedAt time.Time
-	sync.RWMutex    // protects all above fields
-	*syncutil.Gate  // limits concurrent processing
+	snapshot        string   // set when searching archived data, see snapshotArchive
+	missingSources  []string // sources without files in the snapshot searched
+	sync.RWMutex             // protects all above fields
+	*syncutil.Gate           // limits concurrent processing
 
 	pipe *pipeliner
 
+	// scorer scores every list when set, otherwise each list uses its scorer from LIST_SCORERS
+	scorer scorer
+
+	// explainMatches attaches an explanation, along with its matchDetails, to each result
+	explainMatches bool
+
+	// serial scores the records of each search in the calling goroutine, for searchers which are
+	// used from many goroutines at once (see screenRows)
+	serial bool
+
+	// snapshots archives files after each refresh when configured
+	snapshots *snapshotArchive
+
+	// ofacFingerprint is the checksum of the OFAC files from the last refresh
+	ofacFingerprint string
+
+	// indexFile persists the precomputed OFAC records between runs when configured
+	indexFile *searchIndexFile
+
+	// bundleKey verifies the initial data directory as a signed bundle when set
+	bundleKey ed25519.PublicKey
+
 	logger log.Logger
 }
 
@@ -56,8 +80,9 @@ func newSearcher(logger log.Logger, pipeline *pipeliner, workers int) *searcher
 		logger: logger.With(log.Fields{
 			"component": log.String("pipeline"),
 		}),
-		pipe: pipeline,
-		Gate: syncutil.NewGate(workers),
+		pipe:    pipeline,
+		Gate:    syncutil.NewGate(workers),
+		sources: disabledLists.Enabled(source.Sources()),
 	}
 }
 
@@ -81,16 +106,16 @@ func (s *searcher) TopAddresses(limit int, minMatch float64, reqAddress string)
 	s.RLock()
 	defer s.RUnlock()
 
-	return TopAddressesFn(limit, minMatch, s.Addresses, topAddressesAddress(reqAddress))
+	return TopAddressesFn(limit, minMatch, s.Addresses, topAddressesAddress(s.scorerFor(ofac.SDNList), reqAddress))
 }
 
 var (
 	// topAddressesAddress is a compare method for TopAddressesFn to extract and rank .Address
-	topAddressesAddress = func(needleAddr string) func(*Address) *item {
+	topAddressesAddress = func(sc scorer, needleAddr string) func(*Address) *item {
 		return func(add *Address) *item {
 			return &item{
 				value:  add,
-				weight: jaroWinkler(add.address, precompute(needleAddr)),
+				weight: sc.score(add.address, precompute(needleAddr)),
 			}
 		}
 	}
@@ -98,21 +123,21 @@ var (
 	// topAddressesCityState is a compare method for TopAddressesFn to extract and rank
 	// .City, .State, .Providence, and .Zip to return the average match between non-empty
 	// search criteria.
-	topAddressesCityState = func(needleCityState string) func(*Address) *item {
+	topAddressesCityState = func(sc scorer, needleCityState string) func(*Address) *item {
 		return func(add *Address) *item {
 			return &item{
 				value:  add,
-				weight: jaroWinkler(add.citystate, precompute(needleCityState)),
+				weight: sc.score(add.citystate, precompute(needleCityState)),
 			}
 		}
 	}
 
 	// topAddressesCountry is a compare method for TopAddressesFn to extract and rank .Country
-	topAddressesCountry = func(needleCountry string) func(*Address) *item {
+	topAddressesCountry = func(sc scorer, needleCountry string) func(*Address) *item {
 		return func(add *Address) *item {
 			return &item{
 				value:  add,
-				weight: jaroWinkler(add.country, precompute(needleCountry)),
+				weight: sc.score(add.country, precompute(needleCountry)),
 			}
 		}
 	}
@@ -219,32 +244,50 @@ func (s *searcher) FindAlts(limit int, id string) []*ofac.AlternateIdentity {
 	return out
 }
 
-func (s *searcher) TopAltNames(limit int, minMatch float64, alt string) []Alt {
-	alt = precompute(alt)
-
-	s.RLock()
-	defer s.RUnlock()
-
-	if len(s.Alts) == 0 {
-		return nil
+// scoreCandidates calls score with each position of candidates. Each is scored in its own goroutine,
+// limited by s.Gate, unless the searcher is serial.
+func (s *searcher) scoreCandidates(candidates []int, score func(i int)) {
+	if s.serial {
+		for _, i := range candidates {
+			score(i)
+		}
+		return
 	}
-	xs := newLargest(limit, minMatch)
 
 	var wg sync.WaitGroup
-	wg.Add(len(s.Alts))
+	wg.Add(len(candidates))
 
-	for i := range s.Alts {
+	for _, i := range candidates {
 		s.Gate.Start()
 		go func(i int) {
 			defer wg.Done()
 			defer s.Gate.Done()
-			xs.add(&item{
-				value:  s.Alts[i],
-				weight: jaroWinkler(s.Alts[i].name, alt),
-			})
+			score(i)
 		}(i)
 	}
 	wg.Wait()
+}
+
+func (s *searcher) TopAltNames(limit int, minMatch float64, alt string) []Alt {
+	sc := s.scorerFor(ofac.SDNList)
+	queries := newSearchQueries(alt)
+
+	s.RLock()
+	defer s.RUnlock()
+
+	if len(s.Alts) == 0 {
+		return nil
+	}
+	xs := newLargest(limit, minMatch)
+
+	candidates := s.altIndex.candidates(sc, queries, minMatch, len(s.Alts))
+
+	s.scoreCandidates(candidates, func(i int) {
+		xs.add(&item{
+			value:  s.Alts[i],
+			weight: scoreQueries(sc, s.Alts[i].name, s.Alts[i].phonetics, queries),
+		})
+	})
 
 	out := make([]Alt, 0)
 	for i := range xs.items {
@@ -253,9 +296,13 @@ func (s *searcher) TopAltNames(limit int, minMatch float64, alt string) []Alt {
 			if !ok {
 				continue
 			}
-			alt := *aa
-			alt.match = v.weight
-			out = append(out, alt)
+			found := *aa
+			found.match = v.weight
+			if s.explainMatches {
+				found.explanation = explainQueries(sc, aa.name, aa.phonetics, queries)
+				s.explainDetails(found.explanation, sc, altName(aa.AlternateIdentity), alt)
+			}
+			out = append(out, found)
 		}
 	}
 	return out
@@ -338,7 +385,8 @@ func (s *searcher) FindSDNsByRemarksID(limit int, id string) []*SDN {
 }
 
 func (s *searcher) TopSDNs(limit int, minMatch float64, name string) []*SDN {
-	name = precompute(name)
+	sc := s.scorerFor(ofac.SDNList)
+	queries := newSearchQueries(name)
 
 	s.RLock()
 	defer s.RUnlock()
@@ -348,21 +396,14 @@ func (s *searcher) TopSDNs(limit int, minMatch float64, name string) []*SDN {
 	}
 	xs := newLargest(limit, minMatch)
 
-	var wg sync.WaitGroup
-	wg.Add(len(s.SDNs))
+	candidates := s.sdnIndex.candidates(sc, queries, minMatch, len(s.SDNs))
 
-	for i := range s.SDNs {
-		s.Gate.Start()
-		go func(i int) {
-			defer wg.Done()
-			defer s.Gate.Done()
-			xs.add(&item{
-				value:  s.SDNs[i],
-				weight: jaroWinkler(s.SDNs[i].name, name),
-			})
-		}(i)
-	}
-	wg.Wait()
+	s.scoreCandidates(candidates, func(i int) {
+		xs.add(&item{
+			value:  s.SDNs[i],
+			weight: scoreQueries(sc, s.SDNs[i].name, s.SDNs[i].phonetics, queries),
+		})
+	})
 
 	out := make([]*SDN, 0)
 	for i := range xs.items {
@@ -378,49 +419,11 @@ func (s *searcher) TopSDNs(limit int, minMatch float64, name string) []*SDN {
 
 			sdn := *ss // deref for a copy
 			sdn.match = v.weight
-			out = append(out, &sdn)
-		}
-	}
-	return out
-}
-
-func (s *searcher) TopDPs(limit int, minMatch float64, name string) []DP {
-	name = precompute(name)
-
-	s.RLock()
-	defer s.RUnlock()
-
-	if len(s.DPs) == 0 {
-		return nil
-	}
-	xs := newLargest(limit, minMatch)
-
-	var wg sync.WaitGroup
-	wg.Add(len(s.DPs))
-
-	for i := range s.DPs {
-		s.Gate.Start()
-		go func(i int) {
-			defer wg.Done()
-			defer s.Gate.Done()
-			xs.add(&item{
-				value:  s.DPs[i],
-				weight: jaroWinkler(s.DPs[i].name, name),
-			})
-		}(i)
-	}
-	wg.Wait()
-
-	out := make([]DP, 0)
-	for _, thisItem := range xs.items {
-		if v := thisItem; v != nil {
-			ss, ok := v.value.(*DP)
-			if !ok {
-				continue
+			if s.explainMatches {
+				sdn.explanation = explainQueries(sc, ss.name, ss.phonetics, queries)
+				s.explainDetails(sdn.explanation, sc, sdnName(ss.SDN, s.sdnAddresses(ss.EntityID)), name)
 			}
-			dp := *ss
-			dp.match = v.weight
-			out = append(out, dp)
+			out = append(out, &sdn)
 		}
 	}
 	return out
@@ -433,8 +436,12 @@ type SDN struct {
 	// match holds the match ratio for an SDN in search results
 	match float64
 
-	// name is precomputed for speed
-	name string
+	// name and its phonetic keys are precomputed for speed
+	name      string
+	phonetics phoneticKeys
+
+	// explanation describes how match was scored
+	explanation *explanation
 
 	// id is the parseed ID value from an SDN's remarks field. Often this
 	// is a National ID, Drivers License, or similar government value
@@ -448,10 +455,12 @@ type SDN struct {
 func (s SDN) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
 		*ofac.SDN
-		Match float64 `json:"match"`
+		Match       float64      `json:"match"`
+		Explanation *explanation `json:"explanation,omitempty"`
 	}{
 		s.SDN,
 		s.match,
+		s.explanation,
 	})
 }
 
@@ -476,9 +485,10 @@ func precomputeSDNs(sdns []*ofac.SDN, addrs []*ofac.Address, pipe *pipeliner) []
 		}
 
 		out[i] = &SDN{
-			SDN:  sdns[i],
-			name: nn.Processed,
-			id:   extractIDFromRemark(strings.TrimSpace(sdns[i].Remarks)),
+			SDN:       sdns[i],
+			name:      nn.Processed,
+			phonetics: phoneticName(nn.Processed),
+			id:        extractIDFromRemark(strings.TrimSpace(sdns[i].Remarks)),
 		}
 	}
 	return out
@@ -524,18 +534,24 @@ type Alt struct {
 
 	match float64 // match %
 
-	// name is precomputed for speed
-	name string
+	// name and its phonetic keys are precomputed for speed
+	name      string
+	phonetics phoneticKeys
+
+	// explanation describes how match was scored
+	explanation *explanation
 }
 
 // MarshalJSON is a custom method for marshaling a SDN Alternate Identity search result
 func (a Alt) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
 		*ofac.AlternateIdentity
-		Match float64 `json:"match"`
+		Match       float64      `json:"match"`
+		Explanation *explanation `json:"explanation,omitempty"`
 	}{
 		a.AlternateIdentity,
 		a.match,
+		a.explanation,
 	})
 }
 
@@ -552,40 +568,7 @@ func precomputeAlts(alts []*ofac.AlternateIdentity, pipe *pipeliner) []*Alt {
 		out[i] = &Alt{
 			AlternateIdentity: alts[i],
 			name:              an.Processed,
-		}
-	}
-	return out
-}
-
-// DP is a BIS Denied Person wrapped with precomputed search metadata
-type DP struct {
-	DeniedPerson *dpl.DPL
-	match        float64
-	name         string
-}
-
-// MarshalJSON is a custom method for marshaling a BIS Denied Person (DP)
-func (d DP) MarshalJSON() ([]byte, error) {
-	return json.Marshal(struct {
-		*dpl.DPL
-		Match float64 `json:"match"`
-	}{
-		d.DeniedPerson,
-		d.match,
-	})
-}
-
-func precomputeDPs(persons []*dpl.DPL, pipe *pipeliner) []*DP {
-	out := make([]*DP, len(persons))
-	for i := range persons {
-		nn := dpName(persons[i])
-		if err := pipe.Do(nn); err != nil {
-			pipe.logger.LogErrorf("problem pipelining DP: %v", err)
-			continue
-		}
-		out[i] = &DP{
-			DeniedPerson: persons[i],
-			name:         nn.Processed,
+			phonetics:         phoneticName(an.Processed),
 		}
 	}
 	return out
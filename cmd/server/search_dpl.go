@@ -0,0 +1,14 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"github.com/moov-io/watchman/pkg/dpl"
+)
+
+// TopDPs searches BIS Denied Persons by name
+func (s *searcher) TopDPs(limit int, minMatch float64, name string) []*Result[dpl.DPL] {
+	return topList[dpl.DPL](s, dpl.ListName, limit, minMatch, name)
+}
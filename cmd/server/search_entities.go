@@ -0,0 +1,178 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math"
+	"sort"
+
+	"github.com/moov-io/watchman/pkg/ofac"
+	"github.com/moov-io/watchman/pkg/source"
+)
+
+// TopEntities searches every list, including OFAC's SDNs, and ranks the matches together.
+// The original record of each match is kept in its SourceData.
+func (s *searcher) TopEntities(limit int, minMatch float64, name string) []*Result[source.Entity] {
+	s.RLock()
+	defer s.RUnlock()
+
+	found := topResults(s, limit, minMatch, name, s.allLists())
+	found = append(found, s.topSDNEntities(limit, minMatch, name)...)
+
+	sortResults(found)
+	if len(found) > limit {
+		found = found[:limit]
+	}
+	if s.explainMatches {
+		for i := range found {
+			s.explainEntity(found[i], name)
+		}
+	}
+	return found
+}
+
+// sortResults orders found from the best match, breaking ties by their list and ID so the order of
+// results doesn't depend on the order lists were read or records were scored in
+func sortResults(found []*Result[source.Entity]) {
+	sort.SliceStable(found, func(i, j int) bool {
+		if found[i].match != found[j].match {
+			return found[i].match > found[j].match
+		}
+		if found[i].Data.SourceList != found[j].Data.SourceList {
+			return found[i].Data.SourceList < found[j].Data.SourceList
+		}
+		return lessEntityID(found[i].Data.SourceID, found[j].Data.SourceID)
+	})
+}
+
+// allLists returns the precomputed lists read from every Source, ordered by the name of their Source.
+// Callers must hold s.RLock.
+func (s *searcher) allLists() []*listData {
+	names := make([]string, 0, len(s.sourceData))
+	for name := range s.sourceData {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var out []*listData
+	for _, name := range names {
+		out = append(out, s.sourceData[name].lists...)
+	}
+	return out
+}
+
+// topSDNEntities ranks SDNs by their name and alternate names. Only the SDNs whose name or an alternate
+// name can score minMatch are scored, see nameIndex. Callers must hold s.RLock.
+func (s *searcher) topSDNEntities(limit int, minMatch float64, name string) []*Result[source.Entity] {
+	sc := s.scorerFor(ofac.SDNList)
+	queries := newSearchQueries(name)
+	groups := s.altGroups()
+
+	candidates := s.sdnIndex.candidates(sc, queries, minMatch, len(s.SDNs))
+	found := make([]bool, len(s.SDNs))
+	for _, i := range candidates {
+		found[i] = true
+	}
+	for _, i := range s.altIndex.candidates(sc, queries, minMatch, len(s.Alts)) {
+		if j := groups.sdns[i]; j >= 0 && !found[j] {
+			found[j] = true
+			candidates = append(candidates, j)
+		}
+	}
+
+	xs := newLargest(limit, minMatch)
+	s.scoreCandidates(candidates, func(i int) {
+		sdn := s.SDNs[i]
+		if sdn == nil {
+			return
+		}
+		weight := scoreQueries(sc, sdn.name, sdn.phonetics, queries)
+		for _, alt := range groups.alts[i] {
+			weight = math.Max(weight, scoreQueries(sc, alt.name, alt.phonetics, queries))
+		}
+		xs.add(&item{value: i, weight: weight})
+	})
+
+	var out []*Result[source.Entity]
+	for _, it := range xs.items {
+		if it == nil {
+			continue
+		}
+		i, ok := it.value.(int)
+		if !ok {
+			continue
+		}
+		res := s.sdnResult(s.SDNs[i], groups.alts[i])
+		res.match = it.weight
+		if s.explainMatches {
+			res.explanation = res.explain(sc, queries)
+		}
+		out = append(out, res)
+	}
+	return out
+}
+
+// sdnResult converts sdn, along with its addresses and alts, into an entity. Callers must hold s.RLock.
+func (s *searcher) sdnResult(sdn *SDN, alts []*Alt) *Result[source.Entity] {
+	addresses := s.sdnAddresses(sdn.EntityID)
+	var identities []*ofac.AlternateIdentity
+	var altNames []string
+	var altPhonetics []phoneticKeys
+	for _, alt := range alts {
+		identities = append(identities, alt.AlternateIdentity)
+		altNames = append(altNames, alt.name)
+		altPhonetics = append(altPhonetics, alt.phonetics)
+	}
+	return &Result[source.Entity]{
+		Data:                    *ofac.ToEntity(sdn.SDN, addresses, identities),
+		precomputedName:         sdn.name,
+		precomputedAlts:         altNames,
+		precomputedPhonetics:    sdn.phonetics,
+		precomputedAltPhonetics: altPhonetics,
+		list:                    ofac.SDNList,
+	}
+}
+
+// sdnAltGroups links each SDN with its Alts by their positions, so SDNs are scored by their alternate
+// names without grouping every Alt for each search. They're built when the records are refreshed.
+type sdnAltGroups struct {
+	alts      [][]*Alt       // the Alts of each SDN
+	sdns      []int          // the position of each Alt's SDN, or -1 when it isn't listed
+	positions map[string]int // the position of each SDN keyed by its EntityID
+}
+
+func groupSDNAlts(sdns []*SDN, alts []*Alt) *sdnAltGroups {
+	positions := make(map[string]int, len(sdns))
+	for i := range sdns {
+		if sdns[i] != nil {
+			positions[sdns[i].EntityID] = i
+		}
+	}
+	out := &sdnAltGroups{
+		alts:      make([][]*Alt, len(sdns)),
+		sdns:      make([]int, len(alts)),
+		positions: positions,
+	}
+	for i := range alts {
+		out.sdns[i] = -1
+		if alts[i] == nil {
+			continue
+		}
+		if j, ok := positions[alts[i].AlternateIdentity.EntityID]; ok {
+			out.sdns[i] = j
+			out.alts[j] = append(out.alts[j], alts[i])
+		}
+	}
+	return out
+}
+
+// altGroups returns the groups built when the records were refreshed, or groups them when the records
+// were set otherwise. Callers must hold s.RLock.
+func (s *searcher) altGroups() *sdnAltGroups {
+	if g := s.sdnAlts; g != nil && len(g.alts) == len(s.SDNs) && len(g.sdns) == len(s.Alts) {
+		return g
+	}
+	return groupSDNAlts(s.SDNs, s.Alts)
+}
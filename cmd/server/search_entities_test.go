@@ -0,0 +1,133 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/csl"
+	"github.com/moov-io/watchman/pkg/dpl"
+	"github.com/moov-io/watchman/pkg/ofac"
+	"github.com/moov-io/watchman/pkg/source"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearcher_TopEntities(t *testing.T) {
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.SDNs = precomputeSDNs([]*ofac.SDN{
+		{
+			EntityID: "559",
+			SDNName:  "COMERCIAL IMPORTADORA EXPORTADORA",
+			Programs: []string{"CUBA"},
+		},
+		{
+			EntityID: "2681",
+			SDNName:  "HAWATMA, Nayif",
+			SDNType:  "individual",
+			Remarks:  "DOB 1933; Secretary General.",
+		},
+	}, nil, noLogPipeliner)
+	s.Alts = precomputeAlts([]*ofac.AlternateIdentity{
+		{EntityID: "559", AlternateID: "481", AlternateType: "aka", AlternateName: "CIMEX"},
+	}, noLogPipeliner)
+	s.Addresses = precomputeAddresses([]*ofac.Address{
+		{EntityID: "559", AddressID: "1", Address: "Avenida 1ra", Country: "Cuba"},
+	})
+	s.setLists(dpl.ListName, source.List{
+		Name: dpl.ListName,
+		Entities: source.Entities([]*dpl.DPL{
+			{Name: "AL NASER WINGS AIRLINES", City: "DUBAI", Country: "AE", FRCitation: "82 F.R. 61745"},
+		}),
+	})
+	s.setLists("CSL", source.List{
+		Name: "MilitaryEndUsers",
+		Entities: source.Entities([]*csl.MEU{
+			{EntityID: "2674", Name: "AECC Aviation Power Co. Ltd.", AlternateNames: []string{"Xi'an Aero-Engine"}},
+		}),
+	})
+
+	// Every list is ranked together
+	found := s.TopEntities(10, 0.00, "AECC Aviation Power")
+	require.Len(t, found, 4)
+	require.Equal(t, "MilitaryEndUsers", found[0].Data.SourceList)
+	require.Equal(t, "2674", found[0].Data.SourceID)
+	for i := 1; i < len(found); i++ {
+		require.GreaterOrEqual(t, found[i-1].match, found[i].match)
+	}
+
+	// SDNs are found by their alternate names, with addresses attached
+	found = s.TopEntities(1, 0.00, "CIMEX")
+	require.Len(t, found, 1)
+	require.Equal(t, ofac.SDNList, found[0].Data.SourceList)
+	require.Equal(t, source.Business, found[0].Data.Type)
+	require.Equal(t, []string{"CIMEX"}, found[0].Data.AlternateNames)
+	require.Equal(t, []string{"Avenida 1ra, Cuba"}, found[0].Data.Addresses)
+	require.InDelta(t, 1.0, found[0].match, 0.001)
+
+	found = s.TopEntities(1, 0.00, "Al Naser Wings Airlines")
+	require.Len(t, found, 1)
+	require.Equal(t, "DPL", found[0].Data.SourceList)
+	require.Equal(t, "AL NASER WINGS AIRLINES", found[0].Data.SourceData.(*dpl.DPL).Name)
+
+	// Results include the original record
+	bs, err := json.Marshal(found[0])
+	require.NoError(t, err)
+	require.Contains(t, string(bs), `"sourceList":"DPL"`)
+	require.Contains(t, string(bs), `"frCitation":"82 F.R. 61745"`)
+
+	// minMatch applies to every list
+	require.Empty(t, s.TopEntities(10, 0.99, "zzzz"))
+}
+
+func TestSearcher_TopEntities_Ties(t *testing.T) {
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 2)
+	s.setLists("UN", source.List{Name: "UN", Entities: []*source.Entity{
+		{Name: "Acme Trading", SourceList: "UN", SourceID: "12"},
+		{Name: "Acme Trading", SourceList: "UN", SourceID: "3"},
+	}})
+	s.setLists("EU", source.List{Name: "EU", Entities: []*source.Entity{
+		{Name: "Acme Trading", SourceList: "EU", SourceID: "7"},
+	}})
+
+	// Equal matches are ordered by their list and ID on every search
+	for i := 0; i < 10; i++ {
+		found := s.TopEntities(10, 0.90, "Acme Trading")
+		require.Len(t, found, 3)
+
+		var keys []string
+		for j := range found {
+			keys = append(keys, matchKey(found[j].Data))
+		}
+		require.Equal(t, []string{"EU:7", "UN:3", "UN:12"}, keys)
+	}
+}
+
+func TestGroupSDNAlts(t *testing.T) {
+	sdns := precomputeSDNs([]*ofac.SDN{
+		{EntityID: "306", SDNName: "BANCO NACIONAL DE CUBA"},
+		{EntityID: "559", SDNName: "CIMEX"},
+	}, nil, noLogPipeliner)
+	sdns = append(sdns, nil)
+	alts := precomputeAlts([]*ofac.AlternateIdentity{
+		{EntityID: "559", AlternateID: "481", AlternateName: "CORPORACION CIMEX S.A."},
+		{EntityID: "999", AlternateID: "482", AlternateName: "UNLISTED"},
+		{EntityID: "306", AlternateID: "220", AlternateName: "NATIONAL BANK OF CUBA"},
+	}, noLogPipeliner)
+
+	groups := groupSDNAlts(sdns, alts)
+	require.Equal(t, []int{1, -1, 0}, groups.sdns)
+	require.Equal(t, [][]*Alt{{alts[2]}, {alts[0]}, nil}, groups.alts)
+
+	// Groups are used when they match the records and rebuilt otherwise
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.SDNs, s.Alts, s.sdnAlts = sdns, alts, groups
+	require.Same(t, groups, s.altGroups())
+
+	s.Alts = alts[:1]
+	require.Equal(t, []int{1}, s.altGroups().sdns)
+}
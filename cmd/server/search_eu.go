@@ -0,0 +1,14 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"github.com/moov-io/watchman/pkg/eu"
+)
+
+// TopEUEntities searches EU Consolidated Financial Sanctions records by name and alias
+func (s *searcher) TopEUEntities(limit int, minMatch float64, name string) []*Result[eu.Entity] {
+	return topList[eu.Entity](s, "EU", limit, minMatch, name)
+}
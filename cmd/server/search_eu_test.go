@@ -0,0 +1,34 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/eu"
+	"github.com/moov-io/watchman/pkg/source"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearcher_TopEUEntities(t *testing.T) {
+	entities, err := eu.ReadFile(filepath.Join("..", "..", "test", "testdata", "eu_fsf.xml"))
+	require.NoError(t, err)
+
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.setLists("EU", source.List{Name: "EU", Entities: source.Entities(entities)})
+
+	found := s.TopEUEntities(1, 0.00, "Robert Mugabe")
+	require.Len(t, found, 1)
+	require.Equal(t, "EU.27.28", found[0].Data.EUReferenceNumber)
+
+	// Alternate names are searched
+	found = s.TopEUEntities(1, 0.00, "Al Rasheed Trust")
+	require.Len(t, found, 1)
+	require.Equal(t, "7420", found[0].Data.LogicalID)
+	require.InDelta(t, 1.0, found[0].match, 0.001)
+}
@@ -0,0 +1,188 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/moov-io/watchman/internal/transliterate"
+	"github.com/moov-io/watchman/pkg/ofac"
+	"github.com/moov-io/watchman/pkg/source"
+
+	"github.com/xrash/smetrics"
+)
+
+// matchDetails describe how both names were processed and, for Jaro-Winkler, how their words were
+// aligned. They're added to explanations when EXPLAIN_MATCHES or -explain is set.
+type matchDetails struct {
+	// OriginalName is the name of the record before the pipeline processed it
+	OriginalName string `json:"originalName"`
+	// NameSteps are the pipeline steps which changed the name, in order
+	NameSteps []pipelineChange `json:"nameSteps"`
+
+	// OriginalQuery is the query as it was searched
+	OriginalQuery string `json:"originalQuery"`
+	// QuerySteps are the steps which changed the query, in order
+	QuerySteps []pipelineChange `json:"querySteps"`
+
+	// Tokens align each word of the name with the word of the query it scored highest against
+	Tokens []tokenScore `json:"tokens,omitempty"`
+	// ExactMatchFavoritism is added to the score of each word which matched exactly
+	ExactMatchFavoritism float64 `json:"exactMatchFavoritism"`
+}
+
+// tokenScore is a word of the indexed name aligned with the word of the query it scored highest against
+type tokenScore struct {
+	Name  string  `json:"name"`
+	Query string  `json:"query"`
+	Score float64 `json:"score"`
+
+	// Favoritism is the exact match favoritism added to the score
+	Favoritism float64 `json:"favoritism,omitempty"`
+
+	// Dropped is set when the word wasn't averaged as the name has more words than the query
+	Dropped bool `json:"dropped,omitempty"`
+}
+
+// jaroWinklerTokens explains jaroWinkler by aligning each word of s1 with the word of s2 it has the
+// highest Jaro-Winkler score against. When s1 has more words than s2 only the highest N scores are
+// averaged, where N is the words in our query (s2), so the rest are marked as dropped.
+func jaroWinklerTokens(s1, s2 string) []tokenScore {
+	s1Parts, s2Parts := strings.Fields(s1), strings.Fields(s2)
+	if len(s1Parts) == 0 || len(s2Parts) == 0 {
+		return nil
+	}
+
+	tokens := make([]tokenScore, len(s1Parts))
+	for i := range s1Parts {
+		tokens[i] = tokenScore{
+			Name:  s1Parts[i],
+			Query: s2Parts[0],
+			Score: smetrics.JaroWinkler(s1Parts[i], s2Parts[0], boostThreshold, prefixSize),
+		}
+		for j := 1; j < len(s2Parts); j++ {
+			if score := smetrics.JaroWinkler(s1Parts[i], s2Parts[j], boostThreshold, prefixSize); score > tokens[i].Score {
+				tokens[i].Query, tokens[i].Score = s2Parts[j], score
+			}
+		}
+		if tokens[i].Score >= 1.0 {
+			tokens[i].Favoritism = exactMatchFavoritism
+		}
+	}
+
+	if len(s1Parts) > len(s2Parts) && len(s2Parts) > 2 {
+		order := make([]int, len(tokens))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			a, b := tokens[order[i]], tokens[order[j]]
+			return a.Score+a.Favoritism < b.Score+b.Favoritism
+		})
+		for _, i := range order[:len(s1Parts)-len(s2Parts)] {
+			tokens[i].Dropped = true
+		}
+	}
+	return tokens
+}
+
+// queryChanges returns the steps which turned a query into its precomputed form
+func queryChanges(query, processed string) []pipelineChange {
+	romanized := query
+	for _, r := range transliterate.Romanize(query) {
+		if precompute(r) == processed {
+			romanized = r
+			break
+		}
+	}
+
+	var out []pipelineChange
+	if romanized != query {
+		out = append(out, pipelineChange{Step: stepName(&transliterateStep{}), Result: romanized})
+	}
+	if processed != romanized {
+		out = append(out, pipelineChange{Step: stepName(&normalizeStep{}), Result: processed})
+	}
+	return out
+}
+
+// explainDetails adds matchDetails to exp. original is a fresh Name of the record's name which
+// matched, it's run through the pipeline again to find the steps which changed it.
+func (s *searcher) explainDetails(exp *explanation, sc scorer, original *Name, query string) {
+	if exp == nil || original == nil {
+		return
+	}
+	details := &matchDetails{
+		OriginalName:         original.Original,
+		OriginalQuery:        query,
+		QuerySteps:           queryChanges(query, exp.Query),
+		ExactMatchFavoritism: exactMatchFavoritism,
+	}
+	if steps, err := s.pipe.Trace(original); err == nil {
+		details.NameSteps = steps
+	}
+	if _, ok := sc.(jaroWinklerScorer); ok {
+		substituted, _ := substituteVariants(exp.Name, exp.Query, nameVariants)
+		details.Tokens = jaroWinklerTokens(exp.Name, substituted)
+	}
+	exp.matchDetails = details
+}
+
+// explainEntity adds matchDetails to the explanation of res, finding which of the entity's names
+// matched by processing each of them again. Callers must hold s.RLock.
+func (s *searcher) explainEntity(res *Result[source.Entity], query string) {
+	if res == nil || res.explanation == nil {
+		return
+	}
+	names := s.entityNames(&res.Data)
+	if len(names) == 0 {
+		return
+	}
+	matched := names[0]
+	for i := range names {
+		candidate := *names[i]
+		if s.pipe.Do(&candidate) == nil && candidate.Processed == res.explanation.Name {
+			matched = names[i]
+			break
+		}
+	}
+	s.explainDetails(res.explanation, s.scorerFor(res.list), matched, query)
+}
+
+// entityNames returns fresh Names of the primary and alternate names of entity, built the same way
+// as when its list was precomputed. Callers must hold s.RLock.
+func (s *searcher) entityNames(entity *source.Entity) []*Name {
+	switch data := entity.SourceData.(type) {
+	case *ofac.SDN:
+		out := []*Name{sdnName(data, s.sdnAddresses(data.EntityID))}
+		for i := range s.Alts {
+			if s.Alts[i] != nil && s.Alts[i].AlternateIdentity.EntityID == data.EntityID {
+				out = append(out, altName(s.Alts[i].AlternateIdentity))
+			}
+		}
+		return out
+	}
+
+	out := []*Name{entityName(entity)}
+	for i := range entity.AlternateNames {
+		out = append(out, &Name{
+			Original:  entity.AlternateNames[i],
+			Processed: entity.AlternateNames[i],
+		})
+	}
+	return out
+}
+
+// sdnAddresses returns the addresses of an SDN. Callers must hold s.RLock.
+func (s *searcher) sdnAddresses(entityID string) []*ofac.Address {
+	var out []*ofac.Address
+	for i := range s.Addresses {
+		if s.Addresses[i].Address.EntityID == entityID {
+			out = append(out, s.Addresses[i].Address)
+		}
+	}
+	return out
+}
@@ -0,0 +1,177 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/ofac"
+	"github.com/moov-io/watchman/pkg/source"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJaroWinklerTokens(t *testing.T) {
+	cases := []struct {
+		name, query string
+	}{
+		{"hussein hazem", "hussein hazem"},
+		{"nicolas maduro moros", "nicolas maduro"},
+		{"ian mckinley", "ian"},
+		{"africada financial services bureau change", "africada financial services"},
+		{"jose de la cruz", "jose cruz de la rosa"},
+	}
+	for _, tc := range cases {
+		tokens := jaroWinklerTokens(tc.name, tc.query)
+
+		// The words which weren't dropped average out to the jaroWinkler score
+		var sum float64
+		var n int
+		for i := range tokens {
+			if !tokens[i].Dropped {
+				sum += tokens[i].Score + tokens[i].Favoritism
+				n++
+			}
+		}
+		require.InDelta(t, jaroWinkler(tc.name, tc.query), sum/float64(n), 0.000001, tc.name)
+	}
+
+	require.Empty(t, jaroWinklerTokens("", "john"))
+	require.Empty(t, jaroWinklerTokens("john", ""))
+}
+
+func TestJaroWinklerTokens__Dropped(t *testing.T) {
+	tokens := jaroWinklerTokens("africada financial services bureau change", "africada financial services")
+	require.Len(t, tokens, 5)
+
+	require.Equal(t, tokenScore{Name: "africada", Query: "africada", Score: 1.0}, tokens[0])
+	require.False(t, tokens[1].Dropped)
+	require.False(t, tokens[2].Dropped)
+	require.True(t, tokens[3].Dropped)
+	require.True(t, tokens[4].Dropped)
+}
+
+func TestJaroWinklerTokens__Favoritism(t *testing.T) {
+	favoritism := exactMatchFavoritism
+	t.Cleanup(func() { exactMatchFavoritism = favoritism })
+	exactMatchFavoritism = 0.1
+
+	tokens := jaroWinklerTokens("hussein hazem", "hussein hasem")
+	require.InDelta(t, 0.1, tokens[0].Favoritism, 0.001)
+	require.Zero(t, tokens[1].Favoritism)
+}
+
+func TestPipeliner_Trace(t *testing.T) {
+	name := sdnName(&ofac.SDN{SDNName: "HAZEM, Hussein", SDNType: "individual"}, nil)
+
+	steps, err := noLogPipeliner.Trace(name)
+	require.NoError(t, err)
+	require.Equal(t, []pipelineChange{
+		{Step: "reorderSDN", Result: "Hussein HAZEM"},
+		{Step: "normalize", Result: "hussein hazem"},
+	}, steps)
+	require.Equal(t, "hussein hazem", name.Processed)
+
+	_, err = noLogPipeliner.Trace(nil)
+	require.Error(t, err)
+}
+
+func TestQueryChanges(t *testing.T) {
+	require.Empty(t, queryChanges("john smith", "john smith"))
+
+	require.Equal(t, []pipelineChange{
+		{Step: "normalize", Result: "jose garcia"},
+	}, queryChanges("José García", "jose garcia"))
+
+	require.Equal(t, []pipelineChange{
+		{Step: "transliterate", Result: "Sergei Shoigu"},
+		{Step: "normalize", Result: "sergei shoigu"},
+	}, queryChanges("Сергей Шойгу", "sergei shoigu"))
+}
+
+func TestSearcher_TopSDNs_ExplainMatches(t *testing.T) {
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.SDNs = precomputeSDNs([]*ofac.SDN{
+		{EntityID: "1", SDNName: "HAZEM, Hussein Abdul", SDNType: "individual"},
+	}, nil, noLogPipeliner)
+
+	// Explanations are optional
+	sdns := s.TopSDNs(1, 0.00, "Hussein Hazem")
+	require.Len(t, sdns, 1)
+	require.Nil(t, sdns[0].explanation)
+
+	bs, err := json.Marshal(sdns[0])
+	require.NoError(t, err)
+	require.NotContains(t, string(bs), "explanation")
+
+	s.explainMatches = true
+	sdns = s.TopSDNs(1, 0.00, "Hussein Hazem")
+	require.Len(t, sdns, 1)
+
+	details := sdns[0].explanation.matchDetails
+	require.NotNil(t, details)
+	require.Equal(t, "HAZEM, Hussein Abdul", details.OriginalName)
+	require.Equal(t, "Hussein Hazem", details.OriginalQuery)
+	require.Equal(t, []pipelineChange{
+		{Step: "reorderSDN", Result: "Hussein Abdul HAZEM"},
+		{Step: "normalize", Result: "hussein abdul hazem"},
+	}, details.NameSteps)
+	require.Equal(t, []pipelineChange{
+		{Step: "normalize", Result: "hussein hazem"},
+	}, details.QuerySteps)
+
+	require.Len(t, details.Tokens, 3)
+	require.Equal(t, "hussein", details.Tokens[0].Query)
+	require.Equal(t, "hazem", details.Tokens[2].Query)
+	require.InDelta(t, 1.0, details.Tokens[2].Score, 0.001)
+
+	bs, err = json.Marshal(sdns[0])
+	require.NoError(t, err)
+	require.Contains(t, string(bs), `"originalName":"HAZEM, Hussein Abdul"`)
+	require.Contains(t, string(bs), `"tokens":[`)
+
+	// Other scorers don't align words
+	sdns = s.withScorer(levenshteinScorer{}).TopSDNs(1, 0.00, "Hussein Hazem")
+	require.Len(t, sdns, 1)
+	require.NotNil(t, sdns[0].explanation.matchDetails)
+	require.Empty(t, sdns[0].explanation.Tokens)
+}
+
+func TestSearcher_TopEntities_ExplainMatches(t *testing.T) {
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.explainMatches = true
+	s.setLists("watchlist", source.List{Name: "Internal", Entities: []*source.Entity{{
+		Name:           "Acme Trading Company",
+		AlternateNames: []string{"Acme Exports", "Zenith-Holdings S.A."},
+	}}})
+
+	found := s.TopEntities(1, 0.00, "Zenith Holdings")
+	require.Len(t, found, 1)
+
+	// The alternate name which matched is explained
+	details := found[0].explanation.matchDetails
+	require.NotNil(t, details)
+	require.Equal(t, "Zenith-Holdings S.A.", details.OriginalName)
+	require.Equal(t, found[0].explanation.Name, details.NameSteps[len(details.NameSteps)-1].Result)
+}
+
+func TestSearcher_EntityNames(t *testing.T) {
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.SDNs = precomputeSDNs([]*ofac.SDN{
+		{EntityID: "559", SDNName: "CIMEX", SDNType: "entity"},
+	}, nil, noLogPipeliner)
+	s.Alts = precomputeAlts([]*ofac.AlternateIdentity{
+		{EntityID: "559", AlternateID: "481", AlternateType: "aka", AlternateName: "CORPORACION CIMEX S.A."},
+	}, noLogPipeliner)
+	s.Alts = append(s.Alts, nil)
+
+	// Missing alternate names are skipped
+	names := s.entityNames(ofac.ToEntity(s.SDNs[0].SDN, nil, nil))
+	require.Len(t, names, 2)
+	require.Equal(t, "CIMEX", names[0].Original)
+	require.Equal(t, "CORPORACION CIMEX S.A.", names[1].Original)
+}
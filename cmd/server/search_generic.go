@@ -7,8 +7,8 @@ package main
 import (
 	"encoding/json"
 	"math"
-	"reflect"
-	"sync"
+
+	"github.com/moov-io/watchman/pkg/source"
 )
 
 type Result[T any] struct {
@@ -17,80 +17,123 @@ type Result[T any] struct {
 	match           float64
 	precomputedName string
 	precomputedAlts []string
+
+	// phonetic keys of precomputedName and each of precomputedAlts
+	precomputedPhonetics    phoneticKeys
+	precomputedAltPhonetics []phoneticKeys
+
+	// list is the name of the list the record is from, which picks its scorer
+	list string
+
+	explanation *explanation
 }
 
+// MarshalJSON writes the fields of a source.Entity alongside its match and explanation. Other records
+// are written under "data", as type parameters can't be embedded (see golang/go#41563).
 func (e Result[T]) MarshalJSON() ([]byte, error) {
-	// Due to a problem with embedding type parameters we have to dig into
-	// the parameterized type fields and include them in one object.
-	//
-	// Helpful Tips:
-	// https://stackoverflow.com/a/64420452
-	// https://github.com/golang/go/issues/41563
-
-	v := reflect.ValueOf(e.Data)
-
-	result := make(map[string]interface{})
-	for i := 0; i < v.NumField(); i++ {
-		key := v.Type().Field(i)
-		value := v.Field(i)
-
-		if key.IsExported() {
-			result[key.Name] = value.Interface()
-		}
+	if entity, ok := any(e.Data).(source.Entity); ok {
+		return json.Marshal(struct {
+			source.Entity
+			Match       float64      `json:"match"`
+			Explanation *explanation `json:"explanation,omitempty"`
+		}{
+			entity,
+			e.match,
+			e.explanation,
+		})
 	}
-
-	result["match"] = e.match
-
-	return json.Marshal(result)
+	return json.Marshal(struct {
+		Data        T            `json:"data"`
+		Match       float64      `json:"match"`
+		Explanation *explanation `json:"explanation,omitempty"`
+	}{
+		e.Data,
+		e.match,
+		e.explanation,
+	})
 }
 
-func topResults[T any](limit int, minMatch float64, name string, data []*Result[T]) []*Result[T] {
+// topResults ranks the entities of lists against name, scoring each record with the scorer of its list.
+// Only the records each list's index finds can score minMatch are scored (see nameIndex), as s scores
+// candidates (see scoreCandidates). Callers must hold s.RLock.
+func topResults(s *searcher, limit int, minMatch float64, name string, lists []*listData) []*Result[source.Entity] {
+	queries := newSearchQueries(name)
+
+	var data []*Result[source.Entity]
+	for _, list := range lists {
+		sc := s.scorerFor(list.name)
+		for _, i := range list.index.candidates(sc, queries, minMatch, len(list.entities)) {
+			data = append(data, list.entities[i])
+		}
+	}
 	if len(data) == 0 {
 		return nil
 	}
 
-	name = precompute(name)
 	xs := newLargest(limit, minMatch)
 
-	var wg sync.WaitGroup
-	wg.Add(len(data))
-
-	for i := range data {
-		go func(i int) {
-			defer wg.Done()
-
-			it := &item{
-				value:  data[i],
-				weight: jaroWinkler(data[i].precomputedName, name),
-			}
+	s.scoreCandidates(allRecords(len(data)), func(i int) {
+		sc := s.scorerFor(data[i].list)
+		it := &item{
+			value:  data[i],
+			weight: scoreQueries(sc, data[i].precomputedName, data[i].precomputedPhonetics, queries),
+		}
 
-			for _, alt := range data[i].precomputedAlts {
-				if alt == "" {
-					continue
-				}
-				it.weight = math.Max(it.weight, jaroWinkler(alt, name))
+		for j, alt := range data[i].precomputedAlts {
+			if alt == "" {
+				continue
 			}
+			it.weight = math.Max(it.weight, scoreQueries(sc, alt, data[i].altPhonetics(j), queries))
+		}
 
-			xs.add(it)
-		}(i)
-	}
-	wg.Wait()
+		xs.add(it)
+	})
 
-	out := make([]*Result[T], 0)
+	out := make([]*Result[source.Entity], 0)
 	for _, thisItem := range xs.items {
 		if v := thisItem; v != nil {
-			vv, ok := v.value.(*Result[T])
+			vv, ok := v.value.(*Result[source.Entity])
 			if !ok {
 				continue
 			}
-			res := &Result[T]{
-				Data:            vv.Data,
-				match:           v.weight,
-				precomputedName: vv.precomputedName,
-				precomputedAlts: vv.precomputedAlts,
+			res := &Result[source.Entity]{
+				Data:                    vv.Data,
+				match:                   v.weight,
+				precomputedName:         vv.precomputedName,
+				precomputedAlts:         vv.precomputedAlts,
+				precomputedPhonetics:    vv.precomputedPhonetics,
+				precomputedAltPhonetics: vv.precomputedAltPhonetics,
+				list:                    vv.list,
+			}
+			if s.explainMatches {
+				res.explanation = res.explain(s.scorerFor(vv.list), queries)
 			}
 			out = append(out, res)
 		}
 	}
+	sortResults(out)
 	return out
 }
+
+// altPhonetics returns the phonetic keys of the i'th alternate name
+func (e *Result[T]) altPhonetics(i int) phoneticKeys {
+	if i < len(e.precomputedAltPhonetics) {
+		return e.precomputedAltPhonetics[i]
+	}
+	return nil
+}
+
+// explain describes the score of the name or alternate name which matched the queries best
+func (e *Result[T]) explain(sc scorer, queries []searchQuery) *explanation {
+	name, keys := e.precomputedName, e.precomputedPhonetics
+	best := scoreQueries(sc, name, keys, queries)
+	for i, alt := range e.precomputedAlts {
+		if alt == "" {
+			continue
+		}
+		if score := scoreQueries(sc, alt, e.altPhonetics(i), queries); score > best {
+			name, keys, best = alt, e.altPhonetics(i), score
+		}
+	}
+	return explainQueries(sc, name, keys, queries)
+}
@@ -10,8 +10,10 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"sort"
-	"strconv"
 	"time"
+
+	"github.com/moov-io/watchman/pkg/ofac"
+	"github.com/moov-io/watchman/pkg/source"
 )
 
 type searchResponse struct {
@@ -22,8 +24,21 @@ type searchResponse struct {
 	Match    *float64 `json:"match"`
 	Hash     string   `json:"hash"`
 
+	// Entities holds the top matches of every list, including the SDNs above, each
+	// with the sourceList it's from
+	Entities []*Result[source.Entity] `json:"entities"`
+
 	// Metadata
 	RefreshedAt time.Time `json:"refreshedAt"`
+	Snapshot    string    `json:"snapshot,omitempty"`
+
+	// MissingLists names the sources which weren't screened as the snapshot has none of their files
+	MissingLists []string `json:"missingLists,omitempty"`
+
+	// candidates and sdnCandidates hold every match above the threshold, before only the top
+	// matches are kept
+	candidates    []*Result[source.Entity]
+	sdnCandidates []*SDN
 }
 
 func (s searchResponse) HashResponse() string {
@@ -32,12 +47,41 @@ func (s searchResponse) HashResponse() string {
 
 	s.RefreshedAt = time.Time{}
 
+	// Explanations describe how a match was scored, which isn't part of the result
+	sdns := make([]*SDN, len(s.SDNs))
+	for i := range s.SDNs {
+		sdn := *s.SDNs[i]
+		sdn.explanation = nil
+		sdns[i] = &sdn
+	}
+	s.SDNs = sdns
+
+	entities := make([]*Result[source.Entity], len(s.Entities))
+	for i := range s.Entities {
+		entity := *s.Entities[i]
+		entity.explanation = nil
+		entities[i] = &entity
+	}
+	s.Entities = entities
+
 	json.NewEncoder(buffer).Encode(s)
 	hasher.Write(buffer.Bytes())
 	return hex.EncodeToString(hasher.Sum(nil))
 }
 
-func findTopMatchValue(sdns []*SDN) float64 {
+func findTopMatchValue(found []*Result[source.Entity]) float64 {
+	var topMatch float64
+
+	for _, res := range found {
+		if res.match > topMatch {
+			topMatch = res.match
+		}
+	}
+
+	return topMatch
+}
+
+func findTopSDNMatch(sdns []*SDN) float64 {
 	var topMatch float64
 
 	for _, sdn := range sdns {
@@ -50,26 +94,51 @@ func findTopMatchValue(sdns []*SDN) float64 {
 }
 
 func buildFullSearchResponse(searcher *searcher, limit int, minMatch float64, name string, email string) *searchResponse {
+	found := searcher.TopEntities(limit, minMatch, name)
+	sdns := searcher.TopSDNs(limit, minMatch, name)
+	return newSearchResponse(searcher, name, email, found, sdns)
+}
+
+// newSearchResponse keeps the matches which share the top match value out of found, and the SDNs
+// which share the top SDN match out of sdns, and hashes the response.
+func newSearchResponse(searcher *searcher, name string, email string, found []*Result[source.Entity], sdns []*SDN) *searchResponse {
 	resp := searchResponse{
-		Email:       email,
-		FullName:    name,
-		RefreshedAt: searcher.lastRefreshedAt,
+		Email:         email,
+		FullName:      name,
+		RefreshedAt:   searcher.lastRefreshedAt,
+		Snapshot:      searcher.snapshot,
+		MissingLists:  searcher.missingSources,
+		candidates:    found,
+		sdnCandidates: sdns,
 	}
 
-	sdns := searcher.TopSDNs(limit, minMatch, name)
-	topMatch := findTopMatchValue(sdns)
+	topMatch := findTopMatchValue(found)
 
 	// Remove all values lower than topMatch
+	for _, res := range found {
+		if res.match >= topMatch {
+			resp.Entities = append(resp.Entities, res)
+		}
+	}
+
+	sort.Slice(resp.Entities, func(i, j int) bool {
+		x, y := resp.Entities[i].Data, resp.Entities[j].Data
+		if x.SourceList != y.SourceList {
+			return x.SourceList < y.SourceList
+		}
+		return lessEntityID(x.SourceID, y.SourceID)
+	})
+
+	// SDNs are ranked on their own so they're kept when another list has a better match
+	topSDNMatch := findTopSDNMatch(sdns)
 	for _, sdn := range sdns {
-		if sdn.match >= topMatch {
+		if sdn.match >= topSDNMatch {
 			resp.SDNs = append(resp.SDNs, sdn)
 		}
 	}
 
 	sort.Slice(resp.SDNs, func(i, j int) bool {
-		x, _ := strconv.Atoi(resp.SDNs[i].EntityID)
-		y, _ := strconv.Atoi(resp.SDNs[j].EntityID)
-		return x < y
+		return lessEntityID(resp.SDNs[i].EntityID, resp.SDNs[j].EntityID)
 	})
 
 	resp.Match = &topMatch
@@ -77,3 +146,15 @@ func buildFullSearchResponse(searcher *searcher, limit int, minMatch float64, na
 
 	return &resp
 }
+
+// matchKeys returns every candidate of resp by its matchKey
+func (s *searchResponse) matchKeys() map[string]float64 {
+	out := make(map[string]float64, len(s.candidates)+len(s.sdnCandidates))
+	for _, sdn := range s.sdnCandidates {
+		out[matchKey(*ofac.ToEntity(sdn.SDN, nil, nil))] = sdn.match
+	}
+	for _, res := range s.candidates {
+		out[matchKey(res.Data)] = res.match
+	}
+	return out
+}
@@ -0,0 +1,74 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/csl"
+	"github.com/moov-io/watchman/pkg/dpl"
+	"github.com/moov-io/watchman/pkg/ofac"
+	"github.com/moov-io/watchman/pkg/source"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildFullSearchResponse(t *testing.T) {
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.SDNs = precomputeSDNs([]*ofac.SDN{
+		{EntityID: "15036", SDNName: "NASER WINGS AIRLINES", SDNType: ""},
+		{EntityID: "2681", SDNName: "HAWATMA, Nayif", SDNType: "individual"},
+	}, nil, noLogPipeliner)
+	s.sdnIndex = indexSDNs(s.SDNs)
+	s.setLists(dpl.ListName, source.List{
+		Name: dpl.ListName,
+		Entities: source.Entities([]*dpl.DPL{
+			{Name: "NASER WINGS AIRLINES", City: "DUBAI", FRCitation: "82 F.R. 61745"},
+			{Name: "NAYIF HAWATMAH", City: "AMMAN", FRCitation: "82 F.R. 61746"},
+		}),
+	})
+	s.setLists("CSL", source.List{
+		Name: "MilitaryEndUsers",
+		Entities: source.Entities([]*csl.MEU{
+			{EntityID: "2674", Name: "AECC Aviation Power Co. Ltd."},
+		}),
+	})
+
+	// Every list is screened and each hit has the list it's from
+	resp := buildFullSearchResponse(s, 10, 0.90, "Naser Wings Airlines", "")
+	require.Len(t, resp.Entities, 2)
+	require.Equal(t, dpl.ListName, resp.Entities[0].Data.SourceList)
+	require.Equal(t, ofac.SDNList, resp.Entities[1].Data.SourceList)
+	require.Equal(t, "15036", resp.Entities[1].Data.SourceID)
+	require.Equal(t, 1.0, *resp.Match)
+
+	// Only individuals are included as SDNs
+	require.Empty(t, resp.SDNs)
+
+	// SDNs are ranked on their own, so they're kept when another list has a better match
+	resp = buildFullSearchResponse(s, 10, 0.90, "Nayif Hawatmah", "")
+	require.Len(t, resp.Entities, 1)
+	require.Equal(t, dpl.ListName, resp.Entities[0].Data.SourceList)
+	require.Len(t, resp.SDNs, 1)
+	require.Equal(t, "2681", resp.SDNs[0].EntityID)
+	require.Less(t, resp.SDNs[0].match, *resp.Match)
+
+	resp = buildFullSearchResponse(s, 10, 0.90, "AECC Aviation Power", "")
+	require.Len(t, resp.Entities, 1)
+	require.Equal(t, "MilitaryEndUsers", resp.Entities[0].Data.SourceList)
+	require.Empty(t, resp.SDNs)
+
+	// Explanations aren't part of the hash
+	s.explainMatches = true
+	explained := buildFullSearchResponse(s, 10, 0.90, "AECC Aviation Power", "")
+	require.NotNil(t, explained.Entities[0].explanation)
+	require.Equal(t, resp.Hash, explained.Hash)
+
+	resp = buildFullSearchResponse(s, 10, 0.90, "John Smith", "")
+	require.Empty(t, resp.Entities)
+	require.Empty(t, resp.SDNs)
+	require.Equal(t, 0.0, *resp.Match)
+}
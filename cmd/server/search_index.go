@@ -0,0 +1,376 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/moov-io/watchman/pkg/source"
+)
+
+// nameIndex is an inverted index of the precomputed names of a list, built when the list is refreshed.
+// It narrows the records a query is scored against to those which can score at or above minMatch, so
+// the results are the same as scoring every record.
+//
+// A wordScorer scores a name at or above minMatch only when a word of the name scores at least as
+// high against a word of the query, or the name holds a variant of a query word. Blending in phonetic
+// agreement lifts scores by up to phoneticWeight, so names sharing a phonetic key with the query only
+// need a word scoring a little lower. Words are found from their character bigrams, as words which
+// score highly share a minimum number of bigrams (see minSharedBigrams), and the bytes they share
+// bound their score before it's computed (see maxWordScore).
+type nameIndex struct {
+	// words are the distinct words of every name and wordRecords holds the records each is in.
+	// wordBytes holds the bytes of each word in order, see maxWordScore.
+	words       []string
+	wordIDs     map[string]int32
+	wordRecords [][]int32
+	wordBytes   []string
+
+	// bigrams holds the words each bigram is in along with how many times, see bigrams
+	bigrams map[uint16][]bigramPosting
+
+	// phonetics holds the records which have a word of each phonetic key
+	phonetics map[string][]int32
+
+	// size is how many records were indexed
+	size int
+}
+
+type bigramPosting struct {
+	word  int32
+	count int32
+}
+
+// nameIndexTolerance absorbs floating point rounding when comparing scores to minMatch
+const nameIndexTolerance = 1e-9
+
+// newNameIndex indexes size records, whose precomputed name and its phonetic keys are returned by record
+func newNameIndex(size int, record func(i int) (string, phoneticKeys)) *nameIndex {
+	idx := emptyNameIndex(size)
+	for i := 0; i < size; i++ {
+		name, keys := record(i)
+		idx.add(i, name, keys)
+	}
+	return idx
+}
+
+func emptyNameIndex(size int) *nameIndex {
+	return &nameIndex{
+		wordIDs:   make(map[string]int32),
+		bigrams:   make(map[uint16][]bigramPosting),
+		phonetics: make(map[string][]int32),
+		size:      size,
+	}
+}
+
+// add indexes a precomputed name of the i'th record along with its phonetic keys. Records are added in
+// order and a record can have several names, which makes it a candidate when any of them can match.
+func (idx *nameIndex) add(i int, name string, keys phoneticKeys) {
+	for _, word := range strings.Fields(name) {
+		id, ok := idx.wordIDs[word]
+		if !ok {
+			id = int32(len(idx.words))
+			idx.wordIDs[word] = id
+			idx.words = append(idx.words, word)
+			idx.wordRecords = append(idx.wordRecords, nil)
+			idx.wordBytes = append(idx.wordBytes, sortedBytes(word))
+
+			for gram, count := range bigrams(word) {
+				idx.bigrams[gram] = append(idx.bigrams[gram], bigramPosting{word: id, count: count})
+			}
+		}
+		idx.wordRecords[id] = appendRecord(idx.wordRecords[id], int32(i))
+	}
+	for j := range keys {
+		for _, key := range keys[j] {
+			idx.phonetics[key] = appendRecord(idx.phonetics[key], int32(i))
+		}
+	}
+}
+
+func indexSDNs(sdns []*SDN) *nameIndex {
+	return newNameIndex(len(sdns), func(i int) (string, phoneticKeys) {
+		if sdns[i] == nil {
+			return "", nil
+		}
+		return sdns[i].name, sdns[i].phonetics
+	})
+}
+
+func indexAlts(alts []*Alt) *nameIndex {
+	return newNameIndex(len(alts), func(i int) (string, phoneticKeys) {
+		if alts[i] == nil {
+			return "", nil
+		}
+		return alts[i].name, alts[i].phonetics
+	})
+}
+
+// indexEntities indexes the name and every alternate name of each entity of a list
+func indexEntities(entities []*Result[source.Entity]) *nameIndex {
+	idx := emptyNameIndex(len(entities))
+	for i, entity := range entities {
+		idx.add(i, entity.precomputedName, entity.precomputedPhonetics)
+		for j, alt := range entity.precomputedAlts {
+			idx.add(i, alt, entity.altPhonetics(j))
+		}
+	}
+	return idx
+}
+
+// candidates returns the positions of the records which can score at or above minMatch against any of
+// queries with sc. Every record is returned when the index can't narrow them, which is the case for
+// scorers which aren't a wordScorer, when minMatch is zero or when size doesn't match the index.
+func (idx *nameIndex) candidates(sc scorer, queries []searchQuery, minMatch float64, size int) []int {
+	ws, ok := sc.(wordScorer)
+	if idx == nil || idx.size != size || !ok || minMatch <= 0 {
+		return allRecords(size)
+	}
+
+	// Without phonetic agreement a word must score at least minMatch, agreement lowers that to lower
+	upper := math.Min(minMatch, 1.0) - nameIndexTolerance
+	lower := upper
+	if phoneticWeight > 0 {
+		lower = (upper-phoneticWeight)/(1-phoneticWeight) - nameIndexTolerance
+	}
+
+	matched := make([]bool, size) // records with a word scoring upper or higher
+	nearly := make([]bool, size)  // records with a word scoring lower or higher
+	agreed := make([]bool, size)  // records sharing a phonetic key with the query
+
+	shared := make([]int32, len(idx.words))
+	for _, query := range queries {
+		for i, word := range strings.Fields(query.name) {
+			// Variants of the word are substituted for the word of the name, which then matches exactly
+			for _, variant := range nameVariants.Variants(word) {
+				if id, ok := idx.wordIDs[variant]; ok {
+					markRecords(matched, idx.wordRecords[id])
+				}
+			}
+			idx.matchWord(ws, word, upper, lower, shared, matched, nearly)
+
+			if i < len(query.phonetics) {
+				for _, key := range query.phonetics[i] {
+					markRecords(agreed, idx.phonetics[key])
+				}
+			}
+		}
+	}
+
+	var out []int
+	for i := range matched {
+		if matched[i] || (nearly[i] && agreed[i]) {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// matchWord marks the records with a word scoring upper or higher against queryWord in matched, and
+// lower or higher in nearly. shared holds a zeroed count for each word of the index.
+func (idx *nameIndex) matchWord(ws wordScorer, queryWord string, upper, lower float64, shared []int32, matched, nearly []bool) {
+	queryBytes := sortedBytes(queryWord)
+	check := func(id int32) {
+		// Words can't match more bytes than the shorter of them has, or than they share
+		word := idx.words[id]
+		if maxJaroWinkler(float64(minInt(len(word), len(queryWord))), word, queryWord) < lower {
+			return
+		}
+		if maxWordScore(word, idx.wordBytes[id], queryWord, queryBytes) < lower {
+			return
+		}
+		score := ws.wordScore(word, queryWord)
+		switch {
+		case score >= upper:
+			markRecords(matched, idx.wordRecords[id])
+		case score >= lower:
+			markRecords(nearly, idx.wordRecords[id])
+		}
+	}
+
+	need := minSharedBigrams(len(queryWord), lower)
+	if need == 0 {
+		// Every word could score high enough
+		for id := range idx.words {
+			check(int32(id))
+		}
+		return
+	}
+
+	var touched []int32
+	for gram, count := range bigrams(queryWord) {
+		for _, posting := range idx.bigrams[gram] {
+			if shared[posting.word] == 0 {
+				touched = append(touched, posting.word)
+			}
+			if posting.count < count {
+				shared[posting.word] += posting.count
+			} else {
+				shared[posting.word] += count
+			}
+		}
+	}
+	for _, id := range touched {
+		if int(shared[id]) >= need {
+			check(id)
+		}
+		shared[id] = 0
+	}
+}
+
+// bigrams counts each pair of bytes in word, which is padded with a space on either side so the first
+// and last bytes are paired as well
+func bigrams(word string) map[uint16]int32 {
+	out := make(map[uint16]int32, len(word)+1)
+	prev := byte(' ')
+	for i := 0; i <= len(word); i++ {
+		next := byte(' ')
+		if i < len(word) {
+			next = word[i]
+		}
+		out[uint16(prev)<<8|uint16(next)]++
+		prev = next
+	}
+	return out
+}
+
+// maxWordScore is the highest Jaro-Winkler score words a and b can have, computed from the bytes they
+// have in common (their sorted bytes are aBytes and bBytes) as Jaro can't match more bytes than that.
+// It's much cheaper than scoring the words.
+func maxWordScore(a, aBytes, b, bBytes string) float64 {
+	var matches float64
+	for i, j := 0, 0; i < len(aBytes) && j < len(bBytes); {
+		switch {
+		case aBytes[i] == bBytes[j]:
+			matches++
+			i++
+			j++
+		case aBytes[i] < bBytes[j]:
+			i++
+		default:
+			j++
+		}
+	}
+	return maxJaroWinkler(matches, a, b)
+}
+
+// maxJaroWinkler is the highest Jaro-Winkler score a and b can have when Jaro matches this many bytes
+func maxJaroWinkler(matches float64, a, b string) float64 {
+	if matches == 0 {
+		return 0.0
+	}
+	jaro := ((matches / float64(len(a))) + (matches / float64(len(b))) + 1.0) / 3.0
+	if jaro <= boostThreshold {
+		return jaro
+	}
+	var prefix float64
+	for i := 0; i < minInt(prefixSize, minInt(len(a), len(b))) && a[i] == b[i]; i++ {
+		prefix++
+	}
+	return jaro + 0.1*prefix*(1.0-jaro)
+}
+
+// sortedBytes returns the bytes of word in order
+func sortedBytes(word string) string {
+	out := []byte(word)
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return string(out)
+}
+
+type sharedBigramsKey struct {
+	n              int
+	tau            float64
+	boostThreshold float64
+	prefixSize     int
+}
+
+var (
+	sharedBigramsMu     sync.Mutex
+	sharedBigramsBounds = make(map[sharedBigramsKey]int)
+)
+
+// minSharedBigrams returns the fewest bigrams (see bigrams) a word of n bytes shares with any word it
+// has a Jaro-Winkler score of tau or higher against.
+//
+// Say Jaro matches m bytes of the words, u of which are out of order, and the other word is n' bytes.
+// The matched bytes of either word have at most n-m and n'-m gaps between them, and each byte out of
+// order breaks at most two consecutive pairs, so at least (m-1) - (n-m) - (n'-m) - 2u pairs of bytes
+// are found in both words. Winkler boosts a common prefix of l bytes, which are l padded bigrams. The
+// fewest shared bigrams is the least of these over every m, u, l and n' which can score tau.
+func minSharedBigrams(n int, tau float64) int {
+	if n == 0 || tau <= 0 {
+		return 0
+	}
+	key := sharedBigramsKey{n: n, tau: tau, boostThreshold: boostThreshold, prefixSize: prefixSize}
+
+	sharedBigramsMu.Lock()
+	defer sharedBigramsMu.Unlock()
+
+	if bound, ok := sharedBigramsBounds[key]; ok {
+		return bound
+	}
+
+	bound := n + 1
+	for l := 0; l <= minInt(prefixSize, n); l++ {
+		// Jaro must be at least this for Winkler's boost to reach tau. It's lower than needed when
+		// Jaro is under the boost threshold, which only loosens the bound.
+		jaro := (tau - 0.1*float64(l)) / (1.0 - 0.1*float64(l))
+
+		for m := 1; m <= n; m++ {
+			for u := 0; u <= m; u++ {
+				transpositions := float64(u / 2)
+
+				// The other word's share of matched bytes must be at least this
+				need := 3*jaro - float64(m)/float64(n) - (float64(m)-transpositions)/float64(m)
+				if need > 1 {
+					continue // no word is short enough
+				}
+
+				shared := l
+				if need > 0 {
+					// The other word is at most this long
+					other := int(math.Floor(float64(m)/need + nameIndexTolerance))
+					if other < m || other < l {
+						continue
+					}
+					if pairs := 3*m - 1 - n - other - 2*u; pairs > shared {
+						shared = pairs
+					}
+				}
+				if shared < bound {
+					bound = shared
+				}
+			}
+		}
+	}
+
+	sharedBigramsBounds[key] = bound
+	return bound
+}
+
+func allRecords(size int) []int {
+	out := make([]int, size)
+	for i := range out {
+		out[i] = i
+	}
+	return out
+}
+
+// appendRecord adds record unless it's the last of records, as records are indexed in order
+func appendRecord(records []int32, record int32) []int32 {
+	if n := len(records); n > 0 && records[n-1] == record {
+		return records
+	}
+	return append(records, record)
+}
+
+func markRecords(marks []bool, records []int32) {
+	for _, i := range records {
+		marks[i] = true
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/moov-io/watchman"
+	"github.com/moov-io/watchman/pkg/download"
+	"github.com/moov-io/watchman/pkg/ofac"
+)
+
+// searchIndexVersion is bumped whenever the file layout or how names are precomputed changes,
+// so files written by older code are rebuilt rather than loaded.
+const searchIndexVersion = 1
+
+// searchIndexFilename is the name of the search index file kept in the download cache
+const searchIndexFilename = "search-index.gob"
+
+// searchIndexFile persists the precomputed OFAC records so startup can skip parsing the list
+// files and running every name through the pipeliner while the files are unchanged.
+//
+// The file holds a searchIndexHeader followed by the records. It's only loaded when the header
+// matches the running code, its settings and the fingerprint of the list files (see filesFingerprint),
+// otherwise the records are precomputed again and the file rewritten.
+type searchIndexFile struct {
+	path string
+}
+
+// searchIndexPath returns where the search index file is kept, which is path when it's set or
+// otherwise in the download cache. Keeping it with the cache means files which weren't modified
+// since they were cached aren't parsed and precomputed again after a restart.
+func searchIndexPath(path string, cache *download.Cache) string {
+	if path == "" && cache != nil {
+		return filepath.Join(cache.Dir, searchIndexFilename)
+	}
+	return path
+}
+
+func newSearchIndexFile(path string) *searchIndexFile {
+	if path == "" {
+		return nil
+	}
+	return &searchIndexFile{path: path}
+}
+
+// searchIndexHeader describes what a search index file was built from
+type searchIndexHeader struct {
+	Version         int
+	WatchmanVersion string
+	OFACFormat      string
+	KeepStopwords   bool
+	Fingerprint     string
+}
+
+func currentSearchIndexHeader(fingerprint string) searchIndexHeader {
+	return searchIndexHeader{
+		Version:         searchIndexVersion,
+		WatchmanVersion: watchman.Version,
+		OFACFormat:      ofac.DefaultFormat,
+		KeepStopwords:   keepStopwords,
+		Fingerprint:     fingerprint,
+	}
+}
+
+// searchIndexRecords are the precomputed records of a search index file. A nil SDN or Alt is
+// kept in place, as they are for names the pipeliner couldn't process.
+type searchIndexRecords struct {
+	SDNs      []indexedSDN
+	Addresses []indexedAddress
+	Alts      []indexedAlt
+}
+
+type indexedSDN struct {
+	SDN       *ofac.SDN
+	Name      string
+	Phonetics phoneticKeys
+	ID        string
+}
+
+type indexedAddress struct {
+	Address                  *ofac.Address
+	Text, CityState, Country string
+}
+
+type indexedAlt struct {
+	AlternateIdentity *ofac.AlternateIdentity
+	Name              string
+	Phonetics         phoneticKeys
+}
+
+// errStaleSearchIndex is returned by load when the file was built from other list files or settings
+var errStaleSearchIndex = errors.New("search index is stale")
+
+// load reads the precomputed records from the file when it was built from files with fingerprint.
+// errStaleSearchIndex is returned when it wasn't, and an error wrapping os.ErrNotExist when there's no file.
+func (f *searchIndexFile) load(fingerprint string) ([]*SDN, []*Address, []*Alt, error) {
+	fd, err := os.Open(f.path)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("opening search index: %w", err)
+	}
+	defer fd.Close()
+
+	dec := gob.NewDecoder(fd)
+
+	// The header is read first so stale files aren't decoded any further
+	var header searchIndexHeader
+	if err := dec.Decode(&header); err != nil {
+		return nil, nil, nil, fmt.Errorf("reading search index header: %v", err)
+	}
+	if fingerprint == "" || header != currentSearchIndexHeader(fingerprint) {
+		return nil, nil, nil, errStaleSearchIndex
+	}
+
+	var records searchIndexRecords
+	if err := dec.Decode(&records); err != nil {
+		return nil, nil, nil, fmt.Errorf("reading search index: %v", err)
+	}
+
+	sdns := make([]*SDN, len(records.SDNs))
+	for i, r := range records.SDNs {
+		if r.SDN != nil {
+			sdns[i] = &SDN{SDN: r.SDN, name: r.Name, phonetics: r.Phonetics, id: r.ID}
+		}
+	}
+	adds := make([]*Address, len(records.Addresses))
+	for i, r := range records.Addresses {
+		adds[i] = &Address{Address: r.Address, address: r.Text, citystate: r.CityState, country: r.Country}
+	}
+	alts := make([]*Alt, len(records.Alts))
+	for i, r := range records.Alts {
+		if r.AlternateIdentity != nil {
+			alts[i] = &Alt{AlternateIdentity: r.AlternateIdentity, name: r.Name, phonetics: r.Phonetics}
+		}
+	}
+	return sdns, adds, alts, nil
+}
+
+// save writes the precomputed records built from files with fingerprint. The file is replaced
+// in one step so a partially written file is never loaded.
+func (f *searchIndexFile) save(fingerprint string, sdns []*SDN, adds []*Address, alts []*Alt) error {
+	if fingerprint == "" {
+		return errors.New("missing fingerprint of list files")
+	}
+
+	var records searchIndexRecords
+	records.SDNs = make([]indexedSDN, len(sdns))
+	for i, sdn := range sdns {
+		if sdn != nil {
+			records.SDNs[i] = indexedSDN{SDN: sdn.SDN, Name: sdn.name, Phonetics: sdn.phonetics, ID: sdn.id}
+		}
+	}
+	records.Addresses = make([]indexedAddress, len(adds))
+	for i, add := range adds {
+		if add != nil {
+			records.Addresses[i] = indexedAddress{Address: add.Address, Text: add.address, CityState: add.citystate, Country: add.country}
+		}
+	}
+	records.Alts = make([]indexedAlt, len(alts))
+	for i, alt := range alts {
+		if alt != nil {
+			records.Alts[i] = indexedAlt{AlternateIdentity: alt.AlternateIdentity, Name: alt.name, Phonetics: alt.phonetics}
+		}
+	}
+
+	dir := filepath.Dir(f.path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating search index directory: %v", err)
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating search index: %v", err)
+	}
+	defer os.Remove(tmp.Name()) // cleanup when the rename doesn't happen
+
+	if err := writeSearchIndex(tmp, currentSearchIndexHeader(fingerprint), records); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing search index: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing search index: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), f.path); err != nil {
+		return fmt.Errorf("replacing search index: %v", err)
+	}
+	return nil
+}
+
+func writeSearchIndex(w io.Writer, header searchIndexHeader, records searchIndexRecords) error {
+	enc := gob.NewEncoder(w)
+	if err := enc.Encode(header); err != nil {
+		return err
+	}
+	return enc.Encode(records)
+}
@@ -0,0 +1,163 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/download"
+	"github.com/moov-io/watchman/pkg/ofac"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchIndexFile(t *testing.T) {
+	require.Nil(t, newSearchIndexFile(""))
+
+	f := newSearchIndexFile(filepath.Join(t.TempDir(), "index", "search.idx"))
+
+	_, _, _, err := f.load("abc")
+	require.True(t, errors.Is(err, os.ErrNotExist))
+
+	sdns := precomputeSDNs([]*ofac.SDN{
+		{EntityID: "1", SDNName: "MADURO MOROS, Nicolas", SDNType: "individual", Remarks: "Passport No. A123"},
+		{EntityID: "2", SDNName: "AEROCARIBBEAN AIRLINES", SDNType: ""},
+	}, nil, noLogPipeliner)
+	sdns = append(sdns, nil) // names the pipeliner couldn't process
+	adds := precomputeAddresses([]*ofac.Address{
+		{EntityID: "2", AddressID: "10", Address: "Ave. Boyeros", CityStateProvincePostalCode: "Havana", Country: "Cuba"},
+	})
+	alts := precomputeAlts([]*ofac.AlternateIdentity{
+		{EntityID: "2", AlternateID: "20", AlternateType: "aka", AlternateName: "AERO-CARIBBEAN"},
+	}, noLogPipeliner)
+
+	require.NoError(t, f.save("abc", sdns, adds, alts))
+
+	loadedSDNs, loadedAdds, loadedAlts, err := f.load("abc")
+	require.NoError(t, err)
+	requireSameRecords(t, sdns, loadedSDNs, alts, loadedAlts)
+	require.Equal(t, adds, loadedAdds)
+
+	// Files built from other list files or settings are stale
+	_, _, _, err = f.load("def")
+	require.Equal(t, errStaleSearchIndex, err)
+	_, _, _, err = f.load("")
+	require.Equal(t, errStaleSearchIndex, err)
+
+	keep := keepStopwords
+	t.Cleanup(func() { keepStopwords = keep })
+	keepStopwords = !keep
+
+	_, _, _, err = f.load("abc")
+	require.Equal(t, errStaleSearchIndex, err)
+
+	// No temporary files are left behind
+	entries, err := os.ReadDir(filepath.Dir(f.path))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	require.Error(t, f.save("", sdns, adds, alts))
+}
+
+func TestSearchIndexPath(t *testing.T) {
+	require.Equal(t, "", searchIndexPath("", nil))
+	require.Equal(t, "search.idx", searchIndexPath("search.idx", nil))
+
+	// Records are kept with the download cache unless a file is given
+	cache, err := download.NewCache(t.TempDir())
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(cache.Dir, searchIndexFilename), searchIndexPath("", cache))
+	require.Equal(t, "search.idx", searchIndexPath("search.idx", cache))
+}
+
+func TestSearchIndexFile__Corrupt(t *testing.T) {
+	f := newSearchIndexFile(filepath.Join(t.TempDir(), "search.idx"))
+	require.NoError(t, os.WriteFile(f.path, []byte("not an index"), 0600))
+
+	_, _, _, err := f.load("abc")
+	require.Error(t, err)
+	require.NotEqual(t, errStaleSearchIndex, err)
+}
+
+func TestDownload__refreshDataSearchIndex(t *testing.T) {
+	dir := filepath.Join("..", "..", "test", "testdata")
+	index := newSearchIndexFile(filepath.Join(t.TempDir(), "search.idx"))
+
+	// The first refresh precomputes the records and saves them
+	first := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	first.indexFile = index
+	_, err := first.refreshData(context.Background(), dir)
+	require.NoError(t, err)
+
+	sdns, adds, alts, err := index.load(first.ofacFingerprint)
+	require.NoError(t, err)
+	requireSameRecords(t, first.SDNs, sdns, first.Alts, alts)
+	require.Equal(t, first.Addresses, adds)
+
+	// Later refreshes over the same files load them
+	sdns[0].name = "loaded from index"
+	require.NoError(t, index.save(first.ofacFingerprint, sdns, adds, alts))
+
+	second := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	second.indexFile = index
+	stats, err := second.refreshData(context.Background(), dir)
+	require.NoError(t, err)
+	require.Equal(t, len(first.SDNs), stats.SDNs)
+	require.Equal(t, "loaded from index", second.SDNs[0].name)
+	require.NotNil(t, second.sdnIndex)
+
+	// Files built from other list files are rebuilt
+	require.NoError(t, index.save("other", sdns, adds, alts))
+
+	third := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	third.indexFile = index
+	_, err = third.refreshData(context.Background(), dir)
+	require.NoError(t, err)
+	require.Equal(t, first.SDNs[0].name, third.SDNs[0].name)
+
+	sdns, _, _, err = index.load(first.ofacFingerprint)
+	require.NoError(t, err)
+	require.Equal(t, first.SDNs[0].name, sdns[0].name)
+}
+
+// requireSameRecords compares precomputed records, where names without phonetic keys may have
+// an empty or nil slice of them
+func requireSameRecords(t *testing.T, expectedSDNs, sdns []*SDN, expectedAlts, alts []*Alt) {
+	t.Helper()
+
+	keys := func(keys phoneticKeys) phoneticKeys {
+		if len(keys) == 0 {
+			return nil
+		}
+		return keys
+	}
+
+	require.Len(t, sdns, len(expectedSDNs))
+	for i := range expectedSDNs {
+		if expectedSDNs[i] == nil {
+			require.Nil(t, sdns[i])
+			continue
+		}
+		expected, found := *expectedSDNs[i], *sdns[i]
+		expected.phonetics, found.phonetics = keys(expected.phonetics), keys(found.phonetics)
+		require.Equal(t, expected, found)
+	}
+
+	require.Len(t, alts, len(expectedAlts))
+	for i := range expectedAlts {
+		if expectedAlts[i] == nil {
+			require.Nil(t, alts[i])
+			continue
+		}
+		expected, found := *expectedAlts[i], *alts[i]
+		expected.phonetics, found.phonetics = keys(expected.phonetics), keys(found.phonetics)
+		require.Equal(t, expected, found)
+	}
+}
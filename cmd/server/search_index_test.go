@@ -0,0 +1,363 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/internal/variants"
+	"github.com/moov-io/watchman/pkg/dpl"
+	"github.com/moov-io/watchman/pkg/ofac"
+	"github.com/moov-io/watchman/pkg/source"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xrash/smetrics"
+)
+
+func TestBigrams(t *testing.T) {
+	require.Equal(t, map[uint16]int32{
+		uint16(' ')<<8 | 'a': 1,
+		'a'<<8 | 'n':         2,
+		'n'<<8 | 'a':         1,
+		'n'<<8 | ' ':         1,
+	}, bigrams("anan"))
+
+	require.Len(t, bigrams("a"), 2)
+}
+
+// sharedBigrams counts the bigrams a and b have in common
+func sharedBigrams(a, b string) int {
+	ba, bb := bigrams(a), bigrams(b)
+	var n int
+	for gram, count := range ba {
+		n += int(minInt(int(count), int(bb[gram])))
+	}
+	return n
+}
+
+func TestMinSharedBigrams(t *testing.T) {
+	// Every pair of short words over a few letters, which are the hardest to tell apart
+	var words []string
+	var grow func(prefix string)
+	grow = func(prefix string) {
+		if prefix != "" {
+			words = append(words, prefix)
+		}
+		if len(prefix) < 5 {
+			for _, r := range "abc" {
+				grow(prefix + string(r))
+			}
+		}
+	}
+	grow("")
+
+	for _, tau := range []float64{0.5, 0.7, 0.8, 0.85, 0.9, 0.941, 0.95, 1.0} {
+		for _, q := range words {
+			need := minSharedBigrams(len(q), tau)
+			for _, w := range words {
+				if smetrics.JaroWinkler(w, q, boostThreshold, prefixSize) >= tau {
+					require.GreaterOrEqual(t, sharedBigrams(q, w), need, "%s / %s at %.3f", q, w, tau)
+				}
+			}
+		}
+	}
+
+	// The bytes words share bound their score
+	for _, q := range words {
+		for _, w := range words {
+			score := smetrics.JaroWinkler(w, q, boostThreshold, prefixSize)
+			require.GreaterOrEqual(t, maxWordScore(w, sortedBytes(w), q, sortedBytes(q)), score, "%s / %s", q, w)
+			require.GreaterOrEqual(t, maxWordScore(q, sortedBytes(q), w, sortedBytes(w)), score, "%s / %s", q, w)
+		}
+	}
+
+	// Longer words still need some bigrams in common
+	require.Greater(t, minSharedBigrams(9, 0.941), 0)
+	require.Greater(t, minSharedBigrams(12, 0.95), 0)
+	require.Equal(t, 0, minSharedBigrams(5, 0))
+}
+
+func TestMinSharedBigrams__Names(t *testing.T) {
+	indexed, _ := benchSearchers(t)
+
+	seen := make(map[string]bool)
+	var words []string
+	for i := range indexed.SDNs {
+		for _, word := range strings.Fields(indexed.SDNs[i].name) {
+			if !seen[word] {
+				seen[word] = true
+				words = append(words, word)
+			}
+		}
+	}
+
+	step := 97
+	if testing.Short() {
+		step = 487
+	}
+	for i := 0; i < len(words); i += step {
+		q := words[i]
+		for _, tau := range []float64{0.8, 0.9, 0.941} {
+			need := minSharedBigrams(len(q), tau)
+			for _, w := range words {
+				if smetrics.JaroWinkler(w, q, boostThreshold, prefixSize) >= tau {
+					require.GreaterOrEqual(t, sharedBigrams(q, w), need, "%s / %s at %.3f", q, w, tau)
+				}
+			}
+		}
+	}
+}
+
+func TestNameIndex(t *testing.T) {
+	sdns := precomputeSDNs([]*ofac.SDN{
+		{EntityID: "1", SDNName: "MADURO MOROS, Nicolas", SDNType: "individual"},
+		{EntityID: "2", SDNName: "HASSAN, Muhammad", SDNType: "individual"},
+		{EntityID: "3", SDNName: "AL-ZAWAHIRI, Ayman", SDNType: "individual"},
+		{EntityID: "4", SDNName: "PUTIN, Vladimir Vladimirovich", SDNType: "individual"},
+	}, nil, noLogPipeliner)
+	idx := indexSDNs(sdns)
+	require.Equal(t, 4, idx.size)
+	require.Len(t, idx.wordRecords[idx.wordIDs["vladimir"]], 1)
+
+	sc := jaroWinklerScorer{}
+	require.Equal(t, []int{0}, idx.candidates(sc, newSearchQueries("Nicolas Maduro"), 0.9, len(sdns)))
+	require.Equal(t, []int{0}, idx.candidates(sc, newSearchQueries("Nicolas Maduor"), 0.9, len(sdns)))
+	require.Equal(t, []int{3}, idx.candidates(sc, newSearchQueries("Владимир Путин"), 0.9, len(sdns)))
+	require.Empty(t, idx.candidates(sc, newSearchQueries("John Smith"), 0.9, len(sdns)))
+
+	// Variants of a word match exactly
+	require.Equal(t, []int{1}, idx.candidates(sc, newSearchQueries("Mohamed Smith"), 0.9, len(sdns)))
+
+	// Every record is scored when the index can't narrow them
+	require.Len(t, idx.candidates(sc, newSearchQueries("John Smith"), 0.0, len(sdns)), 4)
+	require.Len(t, idx.candidates(tokenSetScorer{}, newSearchQueries("John Smith"), 0.9, len(sdns)), 4)
+	require.Len(t, idx.candidates(sc, newSearchQueries("John Smith"), 0.9, 5), 5)
+
+	var missing *nameIndex
+	require.Len(t, missing.candidates(sc, newSearchQueries("John Smith"), 0.9, 3), 3)
+}
+
+func TestNameIndex__NoVariants(t *testing.T) {
+	dict := nameVariants
+	t.Cleanup(func() { nameVariants = dict })
+	nameVariants = variants.New()
+
+	sdns := precomputeSDNs([]*ofac.SDN{
+		{EntityID: "1", SDNName: "HASSAN, Muhammad", SDNType: "individual"},
+	}, nil, noLogPipeliner)
+	idx := indexSDNs(sdns)
+	require.Empty(t, idx.candidates(jaroWinklerScorer{}, newSearchQueries("Mohamed Smith"), 0.9, len(sdns)))
+}
+
+func TestSearcher_TopEntities_Index(t *testing.T) {
+	indexed, scanned := benchSearchers(t)
+
+	queries := benchQueries(scanned.SDNs, 2459)
+	for i := 0; i < len(scanned.Alts); i += 1999 {
+		queries = append(queries, scanned.Alts[i].AlternateIdentity.AlternateName)
+	}
+
+	// SDNs found by their name or an alternate name through the indexes are those found by scanning
+	for _, query := range queries {
+		expected := scanned.TopEntities(10, 0.85, query)
+		found := indexed.TopEntities(10, 0.85, query)
+
+		require.Len(t, found, len(expected), query)
+		for i := range expected {
+			require.Equal(t, expected[i].match, found[i].match, query)
+		}
+		// Ties are returned in candidate order, so only the entities above the lowest match are compared
+		require.ElementsMatch(t, entityIDsAbove(expected), entityIDsAbove(found), query)
+	}
+}
+
+func TestNameIndex_Lists(t *testing.T) {
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.setLists("EU", source.List{Name: "EU", Entities: []*source.Entity{
+		{Name: "Nicolas Maduro Moros", SourceList: "EU", SourceID: "1"},
+		{Name: "Ri Won Ho", AlternateNames: []string{"Ri Won-ho", "Li Won Ho"}, SourceList: "EU", SourceID: "2"},
+		{Name: "Rosoboronexport", AlternateNames: []string{"Rosoboroneksport"}, SourceList: "EU", SourceID: "3"},
+	}})
+	list := s.sourceLists("EU")[0]
+	require.Equal(t, 3, list.index.size)
+
+	// Only the entities whose name or an alternate name can match are candidates
+	sc := s.scorerFor(list.name)
+	require.Equal(t, []int{0}, list.index.candidates(sc, newSearchQueries("Nicolas Maduro"), 0.9, len(list.entities)))
+	require.Equal(t, []int{1}, list.index.candidates(sc, newSearchQueries("Li Won Ho"), 0.9, len(list.entities)))
+	require.Equal(t, []int{2}, list.index.candidates(sc, newSearchQueries("Rosoboroneksport"), 0.9, len(list.entities)))
+	require.Empty(t, list.index.candidates(sc, newSearchQueries("John Smith"), 0.9, len(list.entities)))
+
+	found := s.TopEntities(10, 0.9, "Li Won Ho")
+	require.Len(t, found, 1)
+	require.Equal(t, "2", found[0].Data.SourceID)
+}
+
+func TestSearcher_TopDPs_Index(t *testing.T) {
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.setLists(dpl.ListName, source.List{
+		Name: dpl.ListName,
+		Entities: source.Entities([]*dpl.DPL{
+			{Name: "AL NASER WINGS AIRLINES", City: "DUBAI", Country: "AE"},
+			{Name: "MAHAN AIR", City: "TEHRAN", Country: "IR"},
+		}),
+	})
+
+	dps := s.TopDPs(10, 0.9, "Naser Wings Airlines")
+	require.Len(t, dps, 1)
+	require.Equal(t, "AL NASER WINGS AIRLINES", dps[0].Data.Name)
+
+	require.Empty(t, s.TopDPs(10, 0.9, "John Smith"))
+	require.Len(t, s.TopDPs(10, 0.0, "John Smith"), 2)
+}
+
+// entityIDsAbove returns the SourceID of each result whose match is above the last (lowest) match
+func entityIDsAbove(results []*Result[source.Entity]) []string {
+	var out []string
+	for i := range results {
+		if results[i].match > results[len(results)-1].match {
+			out = append(out, results[i].Data.SourceID)
+		}
+	}
+	return out
+}
+
+// benchQueries are the names of every step'th bench SDN with typos, reordered and dropped words
+// along with names which aren't listed
+func benchQueries(sdns []*SDN, step int) []string {
+	out := []string{
+		"Nicolas Maduro", "Vladimir Putin", "Mohamed Hasan", "Сергей Шойгу", "john smith",
+		"Al", "li wei", "Banco Nacional de Cuba", "AEROCARIBBEAN", "abu",
+	}
+	for i := 0; i < len(sdns); i += step {
+		name := sdns[i].SDNName
+		words := strings.Fields(name)
+
+		out = append(out, name)
+		if len(name) > 6 {
+			out = append(out, name[:3]+name[4:])                         // dropped letter
+			out = append(out, name[:4]+name[5:6]+name[4:5]+name[6:])     // swapped letters
+			out = append(out, name[:len(name)-2]+"x"+name[len(name)-1:]) // replaced letter
+		}
+		if len(words) > 1 {
+			out = append(out, strings.Join(append(words[1:], words[0]), " "))
+			out = append(out, words[len(words)-1])
+		}
+	}
+	return out
+}
+
+var (
+	benchOnce   sync.Once
+	benchSDNs   []*SDN
+	benchAlts   []*Alt
+	benchGroups *sdnAltGroups
+)
+
+// benchSearchers returns searchers of the bench SDNs and alternate names, one of which scores every record
+func benchSearchers(t testing.TB) (indexed *searcher, scanned *searcher) {
+	t.Helper()
+
+	benchOnce.Do(func() {
+		dir := filepath.Join("..", "..", "test", "testdata", "bench")
+		results, _, err := ofac.ReadFiles([]string{
+			filepath.Join(dir, "add.csv"),
+			filepath.Join(dir, "alt.csv"),
+			filepath.Join(dir, "sdn.csv"),
+		})
+		require.NoError(t, err)
+
+		benchSDNs = precomputeSDNs(results.SDNs, results.Addresses, noLogPipeliner)
+		benchAlts = precomputeAlts(results.AlternateIdentities, noLogPipeliner)
+		benchGroups = groupSDNAlts(benchSDNs, benchAlts)
+	})
+	require.NotEmpty(t, benchSDNs)
+
+	scanned = newSearcher(log.NewNopLogger(), noLogPipeliner, 4)
+	scanned.SDNs, scanned.Alts = benchSDNs, benchAlts
+	scanned.sdnAlts = benchGroups
+
+	indexed = newSearcher(log.NewNopLogger(), noLogPipeliner, 4)
+	indexed.SDNs, indexed.Alts = benchSDNs, benchAlts
+	indexed.sdnIndex, indexed.altIndex = indexSDNs(benchSDNs), indexAlts(benchAlts)
+	indexed.sdnAlts = benchGroups
+
+	return indexed, scanned
+}
+
+func TestNameIndex__Recall(t *testing.T) {
+	indexed, scanned := benchSearchers(t)
+
+	thresholds := []float64{0.95, 0.9, 0.85}
+	lowest := thresholds[len(thresholds)-1]
+	limit := 1000
+
+	step := 149
+	if testing.Short() {
+		step = 2459
+	}
+
+	var candidates, records int
+	for _, query := range benchQueries(indexed.SDNs, step) {
+		queries := newSearchQueries(query)
+
+		// Results of scanning every record at the lowest threshold hold those of the higher thresholds
+		allSDNs := scanned.TopSDNs(limit, lowest, query)
+		allAlts := scanned.TopAltNames(limit, lowest, query)
+		require.Less(t, len(allSDNs), limit, query)
+		require.Less(t, len(allAlts), limit, query)
+
+		for _, threshold := range thresholds {
+			expected := make(map[string]float64)
+			for _, sdn := range allSDNs {
+				if sdn.match >= threshold {
+					expected[sdn.EntityID] = sdn.match
+				}
+			}
+			found := make(map[string]float64)
+			for _, sdn := range indexed.TopSDNs(limit, threshold, query) {
+				found[sdn.EntityID] = sdn.match
+			}
+			require.Equal(t, expected, found, "SDNs of %q at %.2f", query, threshold)
+
+			expected = make(map[string]float64)
+			for _, alt := range allAlts {
+				if alt.match >= threshold {
+					expected[alt.AlternateIdentity.AlternateID] = alt.match
+				}
+			}
+			found = make(map[string]float64)
+			for _, alt := range indexed.TopAltNames(limit, threshold, query) {
+				found[alt.AlternateIdentity.AlternateID] = alt.match
+			}
+			require.Equal(t, expected, found, "alts of %q at %.2f", query, threshold)
+		}
+
+		candidates += len(indexed.sdnIndex.candidates(jaroWinklerScorer{}, queries, 0.95, len(indexed.SDNs)))
+		records += len(indexed.SDNs)
+	}
+	t.Logf("scored %.2f%% of SDNs at 0.95", 100*float64(candidates)/float64(records))
+	require.Less(t, candidates, records/10)
+}
+
+func BenchmarkTopSDNs(b *testing.B) {
+	indexed, scanned := benchSearchers(b)
+	queries := benchQueries(indexed.SDNs, 149)
+
+	b.Run("index", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			indexed.TopSDNs(10, 0.95, queries[i%len(queries)])
+		}
+	})
+	b.Run("scan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			scanned.TopSDNs(10, 0.95, queries[i%len(queries)])
+		}
+	})
+}
@@ -0,0 +1,144 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"os"
+	"strings"
+
+	"github.com/moov-io/watchman/internal/phonetic"
+)
+
+var (
+	// phoneticWeight is how much phonetic agreement counts towards a match, zero disables it
+	phoneticWeight = readFloat(os.Getenv("PHONETIC_WEIGHT"), 0.15)
+
+	// minPhoneticTokenLength skips short tokens such as "al", "bin" or initials, which sound alike too often
+	minPhoneticTokenLength = 3
+)
+
+// phoneticKeys are the keys of each token of a name, tokens too short to compare have none
+type phoneticKeys [][]string
+
+// phoneticName returns the phonetic keys of each token of a precomputed name
+func phoneticName(name string) phoneticKeys {
+	fields := strings.Fields(name)
+	out := make(phoneticKeys, len(fields))
+	for i := range fields {
+		if len(fields[i]) >= minPhoneticTokenLength {
+			out[i] = phonetic.Keys(fields[i])
+		}
+	}
+	return out
+}
+
+// phoneticMatch returns the first key shared by a and b
+func phoneticMatch(a, b []string) (string, bool) {
+	for i := range a {
+		for j := range b {
+			if a[i] == b[j] {
+				return a[i], true
+			}
+		}
+	}
+	return "", false
+}
+
+// phoneticAgreement returns the share of query tokens which sound like a token of the indexed name
+func phoneticAgreement(indexed, query phoneticKeys) float64 {
+	var tokens, agreed int
+	for i := range query {
+		if len(query[i]) == 0 {
+			continue
+		}
+		tokens++
+		for j := range indexed {
+			if _, ok := phoneticMatch(query[i], indexed[j]); ok {
+				agreed++
+				break
+			}
+		}
+	}
+	if tokens == 0 {
+		return 0.0
+	}
+	return float64(agreed) / float64(tokens)
+}
+
+// blendPhonetic mixes phonetic agreement into a Jaro-Winkler score. Agreement only raises a score
+// as names which sound different are already scored lower by Jaro-Winkler.
+func blendPhonetic(score, agreement float64) float64 {
+	if phoneticWeight <= 0 || agreement <= score {
+		return score
+	}
+	return (1-phoneticWeight)*score + phoneticWeight*agreement
+}
+
+// scoreName scores a precomputed query against a precomputed name along with their phonetic keys.
+// Words of the query which are variants of words in the name are substituted first.
+func scoreName(sc scorer, name string, keys phoneticKeys, query string, queryKeys phoneticKeys) float64 {
+	query, _ = substituteVariants(name, query, nameVariants)
+	return blendPhonetic(sc.score(name, query), phoneticAgreement(keys, queryKeys))
+}
+
+// explanation describes how the match of a search result was scored. It's only built when match
+// explanations are enabled, as it scores the result again.
+type explanation struct {
+	// Name is the precomputed name the query matched, which may be an alternate name
+	Name string `json:"name"`
+	// Query is the precomputed query
+	Query string `json:"query"`
+
+	// Scorer names the scorer which compared the names, and Score is its score before phonetic
+	// agreement is blended in
+	Scorer string  `json:"scorer"`
+	Score  float64 `json:"score"`
+	// Variants are the words of the query which were scored as the name variant they're equivalent to
+	Variants []variantToken `json:"variants,omitempty"`
+
+	// Phonetic is the share of query tokens which sound like a token of the name
+	Phonetic       float64 `json:"phonetic"`
+	PhoneticWeight float64 `json:"phoneticWeight"`
+	// PhoneticTokens are the query tokens which sound like a token of the name
+	PhoneticTokens []phoneticToken `json:"phoneticTokens,omitempty"`
+
+	// matchDetails describe how each name was processed and scored
+	*matchDetails
+}
+
+// phoneticToken is a query token which sounds like a token of the matched name
+type phoneticToken struct {
+	Query string `json:"query"`
+	Name  string `json:"name"`
+	Key   string `json:"key"`
+}
+
+// explainMatch returns how a precomputed query scores against a precomputed name
+func explainMatch(sc scorer, name string, keys phoneticKeys, query string, queryKeys phoneticKeys) *explanation {
+	substitutedQuery, substituted := substituteVariants(name, query, nameVariants)
+	out := &explanation{
+		Name:           name,
+		Query:          query,
+		Scorer:         sc.name(),
+		Score:          sc.score(name, substitutedQuery),
+		Variants:       substituted,
+		Phonetic:       phoneticAgreement(keys, queryKeys),
+		PhoneticWeight: phoneticWeight,
+	}
+	names, queries := strings.Fields(name), strings.Fields(query)
+	for i := range queryKeys {
+		for j := range keys {
+			if key, ok := phoneticMatch(queryKeys[i], keys[j]); ok && i < len(queries) && j < len(names) {
+				out.PhoneticTokens = append(out.PhoneticTokens, phoneticToken{
+					Query: queries[i],
+					Name:  names[j],
+					Key:   key,
+				})
+				break
+			}
+		}
+	}
+	return out
+}
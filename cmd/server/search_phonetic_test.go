@@ -0,0 +1,78 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/internal/variants"
+	"github.com/moov-io/watchman/pkg/ofac"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhoneticName(t *testing.T) {
+	keys := phoneticName("al qadhafi muammar")
+	require.Len(t, keys, 3)
+	require.Empty(t, keys[0]) // too short
+	require.Equal(t, []string{"KTF"}, keys[1])
+	require.Equal(t, []string{"MMR"}, keys[2])
+}
+
+func TestPhoneticAgreement(t *testing.T) {
+	indexed := phoneticName("gaddafi muammar")
+
+	require.InDelta(t, 1.0, phoneticAgreement(indexed, phoneticName("muammar qadhafi")), 0.001)
+	require.InDelta(t, 0.5, phoneticAgreement(indexed, phoneticName("kaddafi john")), 0.001)
+	require.InDelta(t, 0.0, phoneticAgreement(indexed, phoneticName("john smith")), 0.001)
+	require.InDelta(t, 0.0, phoneticAgreement(indexed, phoneticName("al")), 0.001)
+}
+
+func TestBlendPhonetic(t *testing.T) {
+	require.InDelta(t, 0.8, blendPhonetic(0.8, 0.0), 0.001)
+	require.InDelta(t, 0.8, blendPhonetic(0.8, 0.5), 0.001) // agreement never lowers a score
+	require.InDelta(t, 0.85*0.8+0.15, blendPhonetic(0.8, 1.0), 0.001)
+
+	weight := phoneticWeight
+	t.Cleanup(func() { phoneticWeight = weight })
+
+	phoneticWeight = 0.0
+	require.InDelta(t, 0.8, blendPhonetic(0.8, 1.0), 0.001)
+}
+
+func TestSearcher_TopSDNs_Phonetic(t *testing.T) {
+	// Score spellings by how they sound rather than as name variants
+	dict := nameVariants
+	t.Cleanup(func() { nameVariants = dict })
+	nameVariants = variants.New()
+
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.explainMatches = true
+	s.SDNs = precomputeSDNs([]*ofac.SDN{
+		{EntityID: "1", SDNName: "GADDAFI, Muammar", SDNType: "individual"},
+		{EntityID: "2", SDNName: "GARDENER, Mark", SDNType: "individual"},
+	}, nil, noLogPipeliner)
+
+	sdns := s.TopSDNs(2, 0.00, "Muammar Qadhafi")
+	require.Len(t, sdns, 2)
+	require.Equal(t, "1", sdns[0].EntityID)
+
+	// Spellings which sound alike score above their Jaro-Winkler score
+	exp := sdns[0].explanation
+	require.NotNil(t, exp)
+	require.Greater(t, sdns[0].match, exp.Score)
+	require.InDelta(t, 1.0, exp.Phonetic, 0.001)
+	require.Contains(t, exp.PhoneticTokens, phoneticToken{Query: "qadhafi", Name: "gaddafi", Key: "KTF"})
+
+	bs, err := json.Marshal(sdns[0])
+	require.NoError(t, err)
+	require.Contains(t, string(bs), `"phonetic":1`)
+
+	// Names which sound different keep their Jaro-Winkler score
+	require.Equal(t, "2", sdns[1].EntityID)
+	require.InDelta(t, sdns[1].explanation.Score, sdns[1].match, 0.001)
+}
@@ -0,0 +1,300 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/xrash/smetrics"
+)
+
+// scorer compares a precomputed name against a precomputed query. Scores range from zero to one,
+// though Jaro-Winkler can score above one with EXACT_MATCH_FAVORITISM.
+type scorer interface {
+	// name identifies the scorer in LIST_SCORERS, SCORER and explanations
+	name() string
+	score(name, query string) float64
+}
+
+// wordScorer is a scorer which never scores names higher than the best score of a word of the
+// name against a word of the query (other than EXACT_MATCH_FAVORITISM). Names of these scorers
+// can be narrowed by a nameIndex before they're scored.
+type wordScorer interface {
+	scorer
+	wordScore(nameWord, queryWord string) float64
+}
+
+var (
+	// scorers are every scorer which can be selected, keyed by name
+	scorers = map[string]scorer{
+		"jaro-winkler":        jaroWinklerScorer{},
+		"levenshtein":         levenshteinScorer{},
+		"damerau-levenshtein": levenshteinScorer{transpositions: true},
+		"token-sort":          tokenSortScorer{},
+		"token-set":           tokenSetScorer{},
+		"monge-elkan":         mongeElkanScorer{},
+	}
+
+	// defaultScorer scores every list without a scorer in LIST_SCORERS
+	defaultScorer scorer = jaroWinklerScorer{}
+
+	// listScorers are the scorers picked for lists (e.g. "SDN=jaro-winkler,DPL=token-set") keyed
+	// by lowercase list name
+	listScorers = func(raw string) map[string]scorer {
+		out, err := parseListScorers(raw)
+		if err != nil {
+			panic(fmt.Errorf("invalid LIST_SCORERS: %v", err))
+		}
+		return out
+	}(os.Getenv("LIST_SCORERS"))
+)
+
+// lookupScorer returns the scorer with name, which is case-insensitive
+func lookupScorer(name string) (scorer, error) {
+	if sc, ok := scorers[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return sc, nil
+	}
+	var names []string
+	for name := range scorers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return nil, fmt.Errorf("unknown scorer %q, expected one of: %s", name, strings.Join(names, ", "))
+}
+
+// parseListScorers reads comma separated pairs of a list name and the scorer used for it
+func parseListScorers(raw string) (map[string]scorer, error) {
+	out := make(map[string]scorer)
+	for _, pair := range strings.Split(raw, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		list, name, found := strings.Cut(pair, "=")
+		if !found || strings.TrimSpace(list) == "" {
+			return nil, fmt.Errorf("%q is not a list=scorer pair", pair)
+		}
+		sc, err := lookupScorer(name)
+		if err != nil {
+			return nil, err
+		}
+		out[strings.ToLower(strings.TrimSpace(list))] = sc
+	}
+	return out, nil
+}
+
+// scorerFor returns the scorer of the named list, unless every list is scored the same way
+// for this searcher (see withScorer)
+func (s *searcher) scorerFor(list string) scorer {
+	if s.scorer != nil {
+		return s.scorer
+	}
+	if sc, ok := listScorers[strings.ToLower(list)]; ok {
+		return sc
+	}
+	return defaultScorer
+}
+
+// withScorer returns a searcher over the same data which scores every list with sc, so one
+// request can be scored differently than the lists are configured (e.g. to compare scorers).
+func (s *searcher) withScorer(sc scorer) *searcher {
+	s.RLock()
+	defer s.RUnlock()
+
+	return &searcher{
+		SDNs:            s.SDNs,
+		Addresses:       s.Addresses,
+		Alts:            s.Alts,
+		sdnIndex:        s.sdnIndex,
+		altIndex:        s.altIndex,
+		sdnAlts:         s.sdnAlts,
+		sources:         s.sources,
+		sourceData:      s.sourceData,
+		lastRefreshedAt: s.lastRefreshedAt,
+		snapshot:        s.snapshot,
+		missingSources:  s.missingSources,
+		Gate:            s.Gate,
+		pipe:            s.pipe,
+		scorer:          sc,
+		explainMatches:  s.explainMatches,
+		serial:          s.serial,
+		logger:          s.logger,
+	}
+}
+
+// jaroWinklerScorer averages the best Jaro-Winkler score of each word, see jaroWinkler
+type jaroWinklerScorer struct{}
+
+func (jaroWinklerScorer) name() string { return "jaro-winkler" }
+
+func (jaroWinklerScorer) score(name, query string) float64 {
+	return jaroWinkler(name, query)
+}
+
+func (jaroWinklerScorer) wordScore(nameWord, queryWord string) float64 {
+	return smetrics.JaroWinkler(nameWord, queryWord, boostThreshold, prefixSize)
+}
+
+// levenshteinScorer is one minus the edit distance between the names, relative to the longer
+// name. With transpositions swapping two adjacent letters is one edit (Damerau-Levenshtein)
+// rather than two.
+type levenshteinScorer struct {
+	transpositions bool
+}
+
+func (sc levenshteinScorer) name() string {
+	if sc.transpositions {
+		return "damerau-levenshtein"
+	}
+	return "levenshtein"
+}
+
+func (sc levenshteinScorer) score(name, query string) float64 {
+	return editSimilarity(name, query, sc.transpositions)
+}
+
+// tokenSortScorer compares the names after sorting their words, so word order is ignored
+// (e.g. "castro ruz raul" and "raul castro ruz")
+type tokenSortScorer struct{}
+
+func (tokenSortScorer) name() string { return "token-sort" }
+
+func (tokenSortScorer) score(name, query string) float64 {
+	return editSimilarity(sortedWords(strings.Fields(name)), sortedWords(strings.Fields(query)), false)
+}
+
+// tokenSetScorer compares the words the names share with and without the words only one of
+// them has, keeping the best score. Extra words in either name are ignored when the rest match.
+type tokenSetScorer struct{}
+
+func (tokenSetScorer) name() string { return "token-set" }
+
+func (tokenSetScorer) score(name, query string) float64 {
+	nameWords, queryWords := strings.Fields(name), strings.Fields(query)
+	if len(nameWords) == 0 || len(queryWords) == 0 {
+		return 0.0
+	}
+
+	inName := make(map[string]bool)
+	for i := range nameWords {
+		inName[nameWords[i]] = true
+	}
+	shared := make(map[string]bool)
+	var both, onlyQuery []string
+	for i := range queryWords {
+		if inName[queryWords[i]] {
+			if !shared[queryWords[i]] {
+				both = append(both, queryWords[i])
+			}
+			shared[queryWords[i]] = true
+		} else {
+			onlyQuery = append(onlyQuery, queryWords[i])
+		}
+	}
+	var onlyName []string
+	for i := range nameWords {
+		if !shared[nameWords[i]] {
+			onlyName = append(onlyName, nameWords[i])
+		}
+	}
+
+	intersection := sortedWords(both)
+	withName := strings.TrimSpace(intersection + " " + sortedWords(onlyName))
+	withQuery := strings.TrimSpace(intersection + " " + sortedWords(onlyQuery))
+
+	best := editSimilarity(withName, withQuery, false)
+	if intersection != "" {
+		if score := editSimilarity(intersection, withName, false); score > best {
+			best = score
+		}
+		if score := editSimilarity(intersection, withQuery, false); score > best {
+			best = score
+		}
+	}
+	return best
+}
+
+// mongeElkanScorer averages the best Jaro-Winkler score of each query word against the words
+// of the name
+type mongeElkanScorer struct{}
+
+func (mongeElkanScorer) name() string { return "monge-elkan" }
+
+func (mongeElkanScorer) score(name, query string) float64 {
+	nameWords, queryWords := strings.Fields(name), strings.Fields(query)
+	if len(nameWords) == 0 || len(queryWords) == 0 {
+		return 0.0
+	}
+	var sum float64
+	for i := range queryWords {
+		var max float64
+		for j := range nameWords {
+			if score := smetrics.JaroWinkler(queryWords[i], nameWords[j], boostThreshold, prefixSize); score > max {
+				max = score
+			}
+		}
+		sum += max
+	}
+	return sum / float64(len(queryWords))
+}
+
+func (mongeElkanScorer) wordScore(nameWord, queryWord string) float64 {
+	return smetrics.JaroWinkler(queryWord, nameWord, boostThreshold, prefixSize)
+}
+
+func sortedWords(words []string) string {
+	out := make([]string, len(words))
+	copy(out, words)
+	sort.Strings(out)
+	return strings.Join(out, " ")
+}
+
+// editSimilarity is one minus the edit distance between a and b relative to the longer of them
+func editSimilarity(a, b string, transpositions bool) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 0.0
+	}
+	return 1.0 - float64(editDistance(ra, rb, transpositions))/float64(longest)
+}
+
+// editDistance counts the insertions, deletions and substitutions to turn a into b. With
+// transpositions swapping adjacent runes also counts as one edit (optimal string alignment).
+func editDistance(a, b []rune, transpositions bool) int {
+	// rows holds the distances of the previous two rows and the current row
+	rows := [3][]int{make([]int, len(b)+1), make([]int, len(b)+1), make([]int, len(b)+1)}
+	for j := range rows[1] {
+		rows[1][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		prev2, prev, cur := rows[0], rows[1], rows[2]
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, minInt(cur[j-1]+1, prev[j-1]+cost))
+			if transpositions && i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = minInt(cur[j], prev2[j-2]+1)
+			}
+		}
+		rows[0], rows[1], rows[2] = prev, cur, prev2
+	}
+	return rows[1][len(b)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,162 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/dpl"
+	"github.com/moov-io/watchman/pkg/ofac"
+	"github.com/moov-io/watchman/pkg/source"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScorers(t *testing.T) {
+	for name, sc := range scorers {
+		require.Equal(t, name, sc.name())
+
+		require.InDelta(t, 1.0, sc.score("nicolas maduro moros", "nicolas maduro moros"), 0.001, name)
+		require.InDelta(t, 0.0, sc.score("", "nicolas maduro"), 0.001, name)
+		require.InDelta(t, 0.0, sc.score("nicolas maduro", ""), 0.001, name)
+
+		score := sc.score("nicolas maduro moros", "john smith")
+		require.Less(t, score, 0.6, name)
+	}
+}
+
+func TestScorers__WordOrder(t *testing.T) {
+	name, query := "castro ruz raul", "raul castro ruz"
+
+	require.InDelta(t, 1.0, tokenSortScorer{}.score(name, query), 0.001)
+	require.InDelta(t, 1.0, tokenSetScorer{}.score(name, query), 0.001)
+	require.InDelta(t, 1.0, mongeElkanScorer{}.score(name, query), 0.001)
+	require.Less(t, levenshteinScorer{}.score(name, query), 0.5)
+}
+
+func TestScorers__ExtraWords(t *testing.T) {
+	// Extra words in the name are ignored by token-set when every query word matches
+	name, query := "banco nacional de cuba", "banco nacional"
+
+	require.InDelta(t, 1.0, tokenSetScorer{}.score(name, query), 0.001)
+	require.InDelta(t, 1.0, mongeElkanScorer{}.score(name, query), 0.001)
+	require.Less(t, tokenSortScorer{}.score(name, query), 0.7)
+}
+
+func TestEditDistance(t *testing.T) {
+	cases := []struct {
+		a, b           string
+		transpositions bool
+		expected       int
+	}{
+		{"", "", false, 0},
+		{"abc", "", false, 3},
+		{"", "abc", false, 3},
+		{"kitten", "sitting", false, 3},
+		{"hussein", "husein", false, 1},
+		{"ahmad", "ahmda", false, 2},
+		{"ahmad", "ahmda", true, 1},
+		{"путин", "путен", false, 1},
+	}
+	for _, tc := range cases {
+		require.Equal(t, tc.expected, editDistance([]rune(tc.a), []rune(tc.b), tc.transpositions), "%s / %s", tc.a, tc.b)
+	}
+
+	require.InDelta(t, 0.8, levenshteinScorer{transpositions: true}.score("ahmad", "ahmda"), 0.001)
+	require.InDelta(t, 0.6, levenshteinScorer{}.score("ahmad", "ahmda"), 0.001)
+}
+
+func TestLookupScorer(t *testing.T) {
+	sc, err := lookupScorer(" Token-Set ")
+	require.NoError(t, err)
+	require.Equal(t, "token-set", sc.name())
+
+	_, err = lookupScorer("soundex")
+	require.ErrorContains(t, err, `unknown scorer "soundex"`)
+	require.ErrorContains(t, err, "monge-elkan")
+}
+
+func TestParseListScorers(t *testing.T) {
+	out, err := parseListScorers("SDN=token-set, DPL = levenshtein,,")
+	require.NoError(t, err)
+	require.Len(t, out, 2)
+	require.Equal(t, "token-set", out["sdn"].name())
+	require.Equal(t, "levenshtein", out["dpl"].name())
+
+	out, err = parseListScorers("")
+	require.NoError(t, err)
+	require.Empty(t, out)
+
+	_, err = parseListScorers("SDN")
+	require.ErrorContains(t, err, "list=scorer")
+
+	_, err = parseListScorers("SDN=soundex")
+	require.ErrorContains(t, err, "unknown scorer")
+}
+
+func TestSearcher_ScorerFor(t *testing.T) {
+	original := listScorers
+	t.Cleanup(func() { listScorers = original })
+	listScorers = map[string]scorer{"dpl": tokenSetScorer{}}
+
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	require.Equal(t, "jaro-winkler", s.scorerFor(ofac.SDNList).name())
+	require.Equal(t, "token-set", s.scorerFor(dpl.ListName).name())
+	require.Equal(t, "token-set", s.scorerFor("dpl").name())
+
+	// A request can score every list the same way
+	other := s.withScorer(mongeElkanScorer{})
+	require.Equal(t, "monge-elkan", other.scorerFor(ofac.SDNList).name())
+	require.Equal(t, "monge-elkan", other.scorerFor(dpl.ListName).name())
+	require.Equal(t, "jaro-winkler", s.scorerFor(ofac.SDNList).name())
+
+	// which is kept when searching only some SDNs
+	require.Equal(t, "monge-elkan", other.withSDNs(nil).scorerFor(ofac.SDNList).name())
+}
+
+func TestSearcher_TopSDNs_Scorer(t *testing.T) {
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.explainMatches = true
+	s.SDNs = precomputeSDNs([]*ofac.SDN{
+		{EntityID: "1", SDNName: "MADURO MOROS, Nicolas", SDNType: "individual"},
+	}, nil, noLogPipeliner)
+
+	sdns := s.TopSDNs(1, 0.00, "Moros Maduro Nicolas")
+	require.Len(t, sdns, 1)
+	require.Equal(t, "jaro-winkler", sdns[0].explanation.Scorer)
+
+	sdns = s.withScorer(levenshteinScorer{}).TopSDNs(1, 0.00, "Moros Maduro Nicolas")
+	require.Len(t, sdns, 1)
+	require.Equal(t, "levenshtein", sdns[0].explanation.Scorer)
+	require.Less(t, sdns[0].match, 0.8)
+
+	sdns = s.withScorer(tokenSortScorer{}).TopSDNs(1, 0.00, "Moros Maduro Nicolas")
+	require.Len(t, sdns, 1)
+	require.Equal(t, "token-sort", sdns[0].explanation.Scorer)
+	require.InDelta(t, 1.0, sdns[0].match, 0.001)
+}
+
+func TestSearcher_TopEntities_ListScorers(t *testing.T) {
+	original := listScorers
+	t.Cleanup(func() { listScorers = original })
+	listScorers = map[string]scorer{"internal": tokenSetScorer{}}
+
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.explainMatches = true
+	s.setLists("watchlist",
+		source.List{Name: "Internal", Entities: []*source.Entity{{Name: "Acme Trading Company Holdings"}}},
+		source.List{Name: "Other", Entities: []*source.Entity{{Name: "Acme Trading Company Holdings"}}},
+	)
+
+	found := s.TopEntities(2, 0.00, "Acme Trading")
+	require.Len(t, found, 2)
+
+	// The internal list is scored with token-set, which ignores the extra words of the name
+	require.Equal(t, "token-set", found[0].explanation.Scorer)
+	require.InDelta(t, 1.0, found[0].match, 0.001)
+	require.Equal(t, "jaro-winkler", found[1].explanation.Scorer)
+	require.Less(t, found[1].match, 1.0)
+}
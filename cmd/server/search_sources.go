@@ -0,0 +1,406 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/csl"
+	"github.com/moov-io/watchman/pkg/ofac"
+	"github.com/moov-io/watchman/pkg/source"
+)
+
+var (
+	// disabledLists are the Sources and Lists which aren't read or searched, set with DISABLED_LISTS
+	disabledLists = source.ParseDisabled(os.Getenv("DISABLED_LISTS"))
+)
+
+// sourceData holds the precomputed lists read from one Source
+type sourceData struct {
+	// fingerprint is the checksum of the source's files when they were read
+	fingerprint string
+
+	// files are the paths of the source's files, which are archived into snapshots
+	files []string
+
+	lists []*listData
+}
+
+// listData is one precomputed List
+type listData struct {
+	name     string
+	entities []*Result[source.Entity]
+
+	// index narrows the entities scored against each query, see nameIndex
+	index *nameIndex
+}
+
+// entityName returns the primary name of entity for pipelining
+func entityName(entity *source.Entity) *Name {
+	out := &Name{
+		Original:  entity.Name,
+		Processed: entity.Name,
+	}
+	// SSI names are reordered and have stopwords removed based on their type
+	if ssi, ok := entity.SourceData.(*csl.SSI); ok {
+		out.ssi = ssi
+	}
+	return out
+}
+
+// precomputeEntities runs the name and alternate names of each entity of the named list through pipe
+func precomputeEntities(list string, entities []*source.Entity, pipe *pipeliner) []*Result[source.Entity] {
+	out := make([]*Result[source.Entity], 0, len(entities))
+
+	for _, entity := range entities {
+		name := entityName(entity)
+		if err := pipe.Do(name); err != nil {
+			pipe.logger.LogErrorf("problem pipelining %T: %v", entity.SourceData, err)
+			continue
+		}
+
+		var altNames []string
+		var altPhonetics []phoneticKeys
+		for i := range entity.AlternateNames {
+			alt := &Name{Processed: entity.AlternateNames[i]}
+			pipe.Do(alt)
+			altNames = append(altNames, alt.Processed)
+			altPhonetics = append(altPhonetics, phoneticName(alt.Processed))
+		}
+
+		out = append(out, &Result[source.Entity]{
+			Data:                    *entity,
+			precomputedName:         name.Processed,
+			precomputedAlts:         altNames,
+			precomputedPhonetics:    phoneticName(name.Processed),
+			precomputedAltPhonetics: altPhonetics,
+			list:                    list,
+		})
+	}
+	return out
+}
+
+// precomputeLists precomputes and indexes every List which isn't disabled
+func precomputeLists(lists []source.List, pipe *pipeliner) []*listData {
+	var out []*listData
+	for i := range lists {
+		if disabledLists.Contains(lists[i].Name) {
+			continue
+		}
+		entities := precomputeEntities(lists[i].Name, lists[i].Entities, pipe)
+		out = append(out, &listData{
+			name:     lists[i].Name,
+			entities: entities,
+			index:    indexEntities(entities),
+		})
+	}
+	return out
+}
+
+// setLists precomputes lists and replaces every list of the named source
+func (s *searcher) setLists(name string, lists ...source.List) {
+	data := &sourceData{
+		lists: precomputeLists(lists, s.pipe),
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	if s.sourceData == nil {
+		s.sourceData = make(map[string]*sourceData)
+	}
+	s.sourceData[name] = data
+}
+
+// ofacList returns the OFAC list sdn was published on, SDNs read without one are from the SDN list
+func ofacList(sdn *SDN) string {
+	if sdn.SourceList == "" {
+		return ofac.SDNList
+	}
+	return sdn.SourceList
+}
+
+// enabledOFACLists drops the SDNs of OFAC lists (ofac.SDNList or ofac.ConsolidatedList) which are
+// disabled, along with their addresses and alternate names, like precomputeLists does for every
+// other List.
+func enabledOFACLists(sdns []*SDN, adds []*Address, alts []*Alt) ([]*SDN, []*Address, []*Alt) {
+	if !disabledLists.Contains(ofac.SDNList) && !disabledLists.Contains(ofac.ConsolidatedList) {
+		return sdns, adds, alts
+	}
+
+	dropped := make(map[string]bool)
+	keptSDNs := make([]*SDN, 0, len(sdns))
+	for i := range sdns {
+		if disabledLists.Contains(ofacList(sdns[i])) {
+			dropped[sdns[i].EntityID] = true
+			continue
+		}
+		keptSDNs = append(keptSDNs, sdns[i])
+	}
+	keptAdds := make([]*Address, 0, len(adds))
+	for i := range adds {
+		if !dropped[adds[i].Address.EntityID] {
+			keptAdds = append(keptAdds, adds[i])
+		}
+	}
+	keptAlts := make([]*Alt, 0, len(alts))
+	for i := range alts {
+		if !dropped[alts[i].AlternateIdentity.EntityID] {
+			keptAlts = append(keptAlts, alts[i])
+		}
+	}
+	return keptSDNs, keptAdds, keptAlts
+}
+
+// ofacListCounts returns the count of SDNs on each OFAC list which isn't disabled
+func ofacListCounts(sdns []*SDN) map[string]int {
+	out := make(map[string]int)
+	for _, name := range []string{ofac.SDNList, ofac.ConsolidatedList} {
+		if !disabledLists.Contains(name) {
+			out[name] = 0
+		}
+	}
+	for i := range sdns {
+		out[ofacList(sdns[i])]++
+	}
+	return out
+}
+
+// addSource refreshes src along with the other sources, unless it's been disabled
+func (s *searcher) addSource(src source.Source) {
+	if disabledLists.Contains(src.Name()) {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+
+	s.sources = append(s.sources, src)
+}
+
+// sourceFiles returns the paths of src's files, which are downloaded unless a signed bundle is in use.
+// Bundles are read as-is, so nil is returned when none of the files were bundled. Sources without
+// Filenames read local files which aren't bundled.
+func (s *searcher) sourceFiles(ctx context.Context, src source.Source, initialDir string, bundled []string) ([]string, error) {
+	if s.bundleKey != nil && len(src.Filenames()) > 0 {
+		var out []string
+		for _, filename := range src.Filenames() {
+			for i := range bundled {
+				if filepath.Base(bundled[i]) == filename {
+					out = append(out, bundled[i])
+				}
+			}
+		}
+		return out, nil
+	}
+	return src.Download(ctx, s.logger, initialDir)
+}
+
+// refreshSource reads and precomputes the lists of src. The existing lists are kept when src's files
+// are unchanged since they were last read, or when they couldn't be read so an outage of one list's
+// servers doesn't block refreshing the others. In that case the error is returned along with them.
+func (s *searcher) refreshSource(ctx context.Context, src source.Source, initialDir string, bundled []string) (*sourceData, error) {
+	s.RLock()
+	existing := s.sourceData[src.Name()]
+	s.RUnlock()
+
+	if existing == nil {
+		existing = &sourceData{}
+	}
+
+	files, err := s.sourceFiles(ctx, src, initialDir, bundled)
+	if err == nil && len(files) == 0 {
+		return &sourceData{}, nil // not bundled
+	}
+
+	var fingerprint string
+	if err == nil {
+		fingerprint, err = filesFingerprint(files)
+	}
+	if err == nil && fingerprint == existing.fingerprint {
+		return &sourceData{
+			fingerprint: fingerprint,
+			files:       files,
+			lists:       existing.lists,
+		}, nil
+	}
+
+	var lists []source.List
+	if err == nil {
+		lists, err = src.Read(files)
+	}
+	if err != nil {
+		lastDataRefreshFailure.WithLabelValues(src.Name()).Set(float64(time.Now().Unix()))
+		s.logger.Warn().LogErrorf("keeping existing %s records: %v", src.Name(), err)
+		return existing, fmt.Errorf("%s: %v", src.Name(), err)
+	}
+
+	return &sourceData{
+		fingerprint: fingerprint,
+		files:       files,
+		lists:       precomputeLists(lists, s.pipe),
+	}, nil
+}
+
+// refreshSources refreshes every source other than OFAC (see ofacSource), keyed by their name. The
+// errors of sources which couldn't be refreshed are returned, and they keep their existing lists.
+func (s *searcher) refreshSources(ctx context.Context, initialDir string, bundled []string) (map[string]*sourceData, []error) {
+	s.RLock()
+	sources := s.sources
+	s.RUnlock()
+
+	out := make(map[string]*sourceData)
+	var errs []error
+	for _, src := range sources {
+		if src.Name() == ofac.SourceName {
+			continue
+		}
+		data, err := s.refreshSource(ctx, src, initialDir, bundled)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		out[src.Name()] = data
+	}
+	return out, errs
+}
+
+// ofacSource returns the OFAC Source, or nil when it's been disabled. Its records are precomputed
+// into the searcher's SDNs, Alts and Addresses by refreshData rather than read as Lists.
+func (s *searcher) ofacSource() source.Source {
+	s.RLock()
+	defer s.RUnlock()
+
+	for i := range s.sources {
+		if s.sources[i].Name() == ofac.SourceName {
+			return s.sources[i]
+		}
+	}
+	return nil
+}
+
+// reloadSource refreshes the named source on its own and returns true if it was re-read.
+func (s *searcher) reloadSource(ctx context.Context, name string) bool {
+	s.RLock()
+	var src source.Source
+	for i := range s.sources {
+		if s.sources[i].Name() == name && name != ofac.SourceName {
+			src = s.sources[i]
+		}
+	}
+	var previous string
+	if data := s.sourceData[name]; data != nil {
+		previous = data.fingerprint
+	}
+	s.RUnlock()
+
+	if src == nil {
+		return false
+	}
+	data, _ := s.refreshSource(ctx, src, "", nil) // failures are logged and keep the existing lists
+	for _, list := range data.lists {
+		lastDataRefreshCount.WithLabelValues(list.name).Set(float64(len(list.entities)))
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	if s.sourceData == nil {
+		s.sourceData = make(map[string]*sourceData)
+	}
+	s.sourceData[name] = data
+	return data.fingerprint != previous
+}
+
+// periodicSourceReload re-reads the named source every interval when its files have changed, so
+// edits to local files are picked up without waiting for the next refresh of every list.
+func (s *searcher) periodicSourceReload(ctx context.Context, interval time.Duration, name string) {
+	if interval == 0*time.Second {
+		s.logger.Logf("not scheduling %s reloading duration=%v", name, interval)
+		return
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+		if s.reloadSource(ctx, name) {
+			s.logger.Info().Logf("reloaded changed %s", name)
+		}
+	}
+}
+
+// namedLists returns every precomputed list with one of names, see allLists. Callers must hold s.RLock.
+func (s *searcher) namedLists(names ...string) []*listData {
+	var out []*listData
+	for _, list := range s.allLists() {
+		for i := range names {
+			if list.name == names[i] {
+				out = append(out, list)
+			}
+		}
+	}
+	return out
+}
+
+// sourceLists returns the precomputed lists of the named source. Callers must hold s.RLock.
+func (s *searcher) sourceLists(name string) []*listData {
+	if data := s.sourceData[name]; data != nil {
+		return data.lists
+	}
+	return nil
+}
+
+// sourceEntities returns the precomputed records of every list of the named source.
+// Callers must hold s.RLock.
+func (s *searcher) sourceEntities(name string) []*Result[source.Entity] {
+	var out []*Result[source.Entity]
+	for _, list := range s.sourceLists(name) {
+		out = append(out, list.entities...)
+	}
+	return out
+}
+
+// topEntities searches lists and returns the matches whose original record is a *T
+func topEntities[T any](s *searcher, limit int, minMatch float64, name string, lists func() []*listData) []*Result[T] {
+	s.RLock()
+	defer s.RUnlock()
+
+	found := topResults(s, limit, minMatch, name, lists())
+	if s.explainMatches {
+		for i := range found {
+			s.explainEntity(found[i], name)
+		}
+	}
+
+	out := make([]*Result[T], 0, len(found))
+	for i := range found {
+		data, ok := found[i].Data.SourceData.(*T)
+		if !ok {
+			continue
+		}
+		out = append(out, &Result[T]{
+			Data:                    *data,
+			match:                   found[i].match,
+			precomputedName:         found[i].precomputedName,
+			precomputedAlts:         found[i].precomputedAlts,
+			precomputedPhonetics:    found[i].precomputedPhonetics,
+			precomputedAltPhonetics: found[i].precomputedAltPhonetics,
+			list:                    found[i].list,
+			explanation:             found[i].explanation,
+		})
+	}
+	return out
+}
+
+// topList searches the named list for records of type T
+func topList[T any](s *searcher, list string, limit int, minMatch float64, name string) []*Result[T] {
+	return topEntities[T](s, limit, minMatch, name, func() []*listData {
+		return s.namedLists(list)
+	})
+}
@@ -5,6 +5,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"strings"
@@ -15,6 +16,7 @@ import (
 	"github.com/moov-io/watchman/pkg/csl"
 	"github.com/moov-io/watchman/pkg/dpl"
 	"github.com/moov-io/watchman/pkg/ofac"
+	"github.com/moov-io/watchman/pkg/source"
 )
 
 var (
@@ -95,104 +97,117 @@ func init() {
 			Remarks:  "DOB 23 Nov 1962; POB Caracas, Venezuela; citizen Venezuela; Gender Male; Cedula No. 5892464 (Venezuela); President of the Bolivarian Republic of Venezuela.",
 		},
 	}, nil, noLogPipeliner)
-	dplSearcher.DPs = precomputeDPs([]*dpl.DPL{
-		{
-			Name:           "AL NASER WINGS AIRLINES",
-			StreetAddress:  "P.O. BOX 28360",
-			City:           "DUBAI",
-			State:          "",
-			Country:        "AE",
-			PostalCode:     "",
-			EffectiveDate:  "06/05/2019",
-			ExpirationDate: "12/03/2019",
-			StandardOrder:  "Y",
-			LastUpdate:     "2019-06-12",
-			Action:         "FR NOTICE ADDED, TDO RENEWAL, F.R. NOTICE ADDED, TDO RENEWAL ADDED, TDO RENEWAL ADDED, F.R. NOTICE ADDED",
-			FRCitation:     "82 F.R. 61745 12/29/2017,  83F.R. 28801 6/21/2018, 84 F.R. 27233 6/12/2019",
-		},
-		{
-			Name:           "PRESTON JOHN ENGEBRETSON",
-			StreetAddress:  "12725 ROYAL DRIVE",
-			City:           "STAFFORD",
-			State:          "TX",
-			Country:        "US",
-			PostalCode:     "77477",
-			EffectiveDate:  "01/24/2002",
-			ExpirationDate: "01/24/2027",
-			StandardOrder:  "Y",
-			LastUpdate:     "2002-01-28",
-			Action:         "STANDARD ORDER",
-			FRCitation:     "67 F.R. 7354 2/19/02 66 F.R. 48998 9/25/01 62 F.R. 26471 5/14/97 62 F.R. 34688 6/27/97 62 F.R. 60063 11/6/97 63 F.R. 25817 5/11/98 63 F.R. 58707 11/2/98 64 F.R. 23049 4/29/99",
-		},
-	}, noLogPipeliner)
-	ssiSearcher.SSIs = precomputeCSLEntities([]*csl.SSI{
-		{
-			EntityID:       "18782",
-			Type:           "Entity",
-			Programs:       []string{"SYRIA", "UKRAINE-EO13662"},
-			Name:           "ROSOBORONEKSPORT OAO",
-			Addresses:      []string{"27 Stromynka ul., Moscow, 107076, RU"},
-			Remarks:        []string{"For more information on directives, please visit the following link: http://www.treasury.gov/resource-center/sanctions/Programs/Pages/ukraine.aspx#directives", "(Linked To: ROSTEC)"},
-			AlternateNames: []string{"RUSSIAN DEFENSE EXPORT ROSOBORONEXPORT", "KENKYUSHO", "ROSOBORONEXPORT JSC", "ROSOBORONEKSPORT OJSC", "OJSC ROSOBORONEXPORT", "ROSOBORONEXPORT"},
-			IDsOnRecord:    []string{"1117746521452, Registration ID", "56467052, Government Gazette Number", "7718852163, Tax ID No.", "Subject to Directive 3, Executive Order 13662 Directive Determination -", "www.roe.ru, Website"},
-			SourceListURL:  "http://bit.ly/1QWTIfE",
-			SourceInfoURL:  "http://bit.ly/1MLgou0",
-		},
-		{
-			EntityID:       "18736",
-			Type:           "Entity",
-			Programs:       []string{"UKRAINE-EO13662"},
-			Name:           "VTB SPECIALIZED DEPOSITORY, CJSC",
-			Addresses:      []string{"35 Myasnitskaya Street, Moscow, 101000, RU"},
-			Remarks:        []string{"For more information on directives, please visit the following link: http://www.treasury.gov/resource-center/sanctions/Programs/Pages/ukraine.aspx#directives", "(Linked To: ROSTEC)"},
-			AlternateNames: []string{"CJS VTB SPECIALIZED DEPOSITORY"},
-			IDsOnRecord:    []string{"1117746521452, Registration ID", "56467052, Government Gazette Number", "7718852163, Tax ID No.", "Subject to Directive 3, Executive Order 13662 Directive Determination -", "www.roe.ru, Website"},
-			SourceListURL:  "http://bit.ly/1QWTIfE",
-			SourceInfoURL:  "http://bit.ly/1MLgou0",
-		},
-	}, noLogPipeliner)
-	meuSearcher.MilitaryEndUsers = precomputeCSLEntities([]*csl.MEU{
-		{
-			EntityID:  "26744194bd9b5cbec49db6ee29a4b53c697c7420",
-			Name:      "AECC Aviation Power Co. Ltd.",
-			Addresses: "Xiujia Bay, Weiyong Dt, Xian, 710021, CN",
-			FRNotice:  "85 FR 83799",
-			StartDate: "2020-12-23",
-			EndDate:   "",
-		},
-		{
-			EntityID:  "d54346ef81802673c1b1daeb2ca8bd5d13755abd",
-			Name:      "AECC China Gas Turbine Establishment",
-			Addresses: "No. 1 Hangkong Road, Mianyang, Sichuan, CN",
-			FRNotice:  "85 FR 83799",
-			StartDate: "2020-12-23",
-			EndDate:   "",
-		},
-	}, noLogPipeliner)
-	bisEntitySearcher.BISEntities = precomputeCSLEntities([]*csl.EL{
-		{
-			Name:               "Mohammad Jan Khan Mangal",
-			AlternateNames:     []string{"Air I"},
-			Addresses:          []string{"Kolola Pushta, Charahi Gul-e-Surkh, Kabul, AF", "Maidan Sahr, Hetefaq Market, Paktiya, AF"},
-			StartDate:          "11/13/19",
-			LicenseRequirement: "For all items subject to the EAR (See ¬ß744.11 of the EAR). ",
-			LicensePolicy:      "Presumption of denial.",
-			FRNotice:           "81 FR 57451",
-			SourceListURL:      "http://bit.ly/1L47xrV",
-			SourceInfoURL:      "http://bit.ly/1L47xrV",
-		},
-		{
-			Name:               "Luqman Yasin Yunus Shgragi",
-			AlternateNames:     []string{"Lkemanasel Yosef", "Luqman Sehreci."},
-			Addresses:          []string{"Savcili Mahalesi Turkmenler Caddesi No:2, Sahinbey, Gaziantep, TR", "Sanayi Mahalesi 60214 Nolu Caddesi No 11, SehitKamil, Gaziantep, TR"},
-			StartDate:          "8/23/16",
-			LicenseRequirement: "For all items subject to the EAR.  (See ¬ß744.11 of the EAR)",
-			LicensePolicy:      "Presumption of denial.",
-			FRNotice:           "81 FR 57451",
-			SourceListURL:      "http://bit.ly/1L47xrV",
-			SourceInfoURL:      "http://bit.ly/1L47xrV",
-		},
-	}, noLogPipeliner)
+	dplSearcher.setLists(dpl.ListName, source.List{
+		Name: dpl.ListName,
+		Entities: source.Entities([]*dpl.DPL{
+			{
+				Name:           "AL NASER WINGS AIRLINES",
+				StreetAddress:  "P.O. BOX 28360",
+				City:           "DUBAI",
+				State:          "",
+				Country:        "AE",
+				PostalCode:     "",
+				EffectiveDate:  "06/05/2019",
+				ExpirationDate: "12/03/2019",
+				StandardOrder:  "Y",
+				LastUpdate:     "2019-06-12",
+				Action:         "FR NOTICE ADDED, TDO RENEWAL, F.R. NOTICE ADDED, TDO RENEWAL ADDED, TDO RENEWAL ADDED, F.R. NOTICE ADDED",
+				FRCitation:     "82 F.R. 61745 12/29/2017,  83F.R. 28801 6/21/2018, 84 F.R. 27233 6/12/2019",
+			},
+			{
+				Name:           "PRESTON JOHN ENGEBRETSON",
+				StreetAddress:  "12725 ROYAL DRIVE",
+				City:           "STAFFORD",
+				State:          "TX",
+				Country:        "US",
+				PostalCode:     "77477",
+				EffectiveDate:  "01/24/2002",
+				ExpirationDate: "01/24/2027",
+				StandardOrder:  "Y",
+				LastUpdate:     "2002-01-28",
+				Action:         "STANDARD ORDER",
+				FRCitation:     "67 F.R. 7354 2/19/02 66 F.R. 48998 9/25/01 62 F.R. 26471 5/14/97 62 F.R. 34688 6/27/97 62 F.R. 60063 11/6/97 63 F.R. 25817 5/11/98 63 F.R. 58707 11/2/98 64 F.R. 23049 4/29/99",
+			},
+		}),
+	})
+	ssiSearcher.setLists("CSL", source.List{
+		Name: "SSIs",
+		Entities: source.Entities([]*csl.SSI{
+			{
+				EntityID:       "18782",
+				Type:           "Entity",
+				Programs:       []string{"SYRIA", "UKRAINE-EO13662"},
+				Name:           "ROSOBORONEKSPORT OAO",
+				Addresses:      []string{"27 Stromynka ul., Moscow, 107076, RU"},
+				Remarks:        []string{"For more information on directives, please visit the following link: http://www.treasury.gov/resource-center/sanctions/Programs/Pages/ukraine.aspx#directives", "(Linked To: ROSTEC)"},
+				AlternateNames: []string{"RUSSIAN DEFENSE EXPORT ROSOBORONEXPORT", "KENKYUSHO", "ROSOBORONEXPORT JSC", "ROSOBORONEKSPORT OJSC", "OJSC ROSOBORONEXPORT", "ROSOBORONEXPORT"},
+				IDsOnRecord:    []string{"1117746521452, Registration ID", "56467052, Government Gazette Number", "7718852163, Tax ID No.", "Subject to Directive 3, Executive Order 13662 Directive Determination -", "www.roe.ru, Website"},
+				SourceListURL:  "http://bit.ly/1QWTIfE",
+				SourceInfoURL:  "http://bit.ly/1MLgou0",
+			},
+			{
+				EntityID:       "18736",
+				Type:           "Entity",
+				Programs:       []string{"UKRAINE-EO13662"},
+				Name:           "VTB SPECIALIZED DEPOSITORY, CJSC",
+				Addresses:      []string{"35 Myasnitskaya Street, Moscow, 101000, RU"},
+				Remarks:        []string{"For more information on directives, please visit the following link: http://www.treasury.gov/resource-center/sanctions/Programs/Pages/ukraine.aspx#directives", "(Linked To: ROSTEC)"},
+				AlternateNames: []string{"CJS VTB SPECIALIZED DEPOSITORY"},
+				IDsOnRecord:    []string{"1117746521452, Registration ID", "56467052, Government Gazette Number", "7718852163, Tax ID No.", "Subject to Directive 3, Executive Order 13662 Directive Determination -", "www.roe.ru, Website"},
+				SourceListURL:  "http://bit.ly/1QWTIfE",
+				SourceInfoURL:  "http://bit.ly/1MLgou0",
+			},
+		}),
+	})
+	meuSearcher.setLists("CSL", source.List{
+		Name: "MilitaryEndUsers",
+		Entities: source.Entities([]*csl.MEU{
+			{
+				EntityID:       "26744194bd9b5cbec49db6ee29a4b53c697c7420",
+				Name:           "AECC Aviation Power Co. Ltd.",
+				AlternateNames: []string{"Xi'an Aero-Engine"},
+				Addresses:      "Xiujia Bay, Weiyong Dt, Xian, 710021, CN",
+				FRNotice:       "85 FR 83799",
+				StartDate:      "2020-12-23",
+				EndDate:        "",
+			},
+			{
+				EntityID:  "d54346ef81802673c1b1daeb2ca8bd5d13755abd",
+				Name:      "AECC China Gas Turbine Establishment",
+				Addresses: "No. 1 Hangkong Road, Mianyang, Sichuan, CN",
+				FRNotice:  "85 FR 83799",
+				StartDate: "2020-12-23",
+				EndDate:   "",
+			},
+		}),
+	})
+	bisEntitySearcher.setLists("CSL", source.List{
+		Name: "BISEntities",
+		Entities: source.Entities([]*csl.EL{
+			{
+				Name:               "Mohammad Jan Khan Mangal",
+				AlternateNames:     []string{"Air I"},
+				Addresses:          []string{"Kolola Pushta, Charahi Gul-e-Surkh, Kabul, AF", "Maidan Sahr, Hetefaq Market, Paktiya, AF"},
+				StartDate:          "11/13/19",
+				LicenseRequirement: "For all items subject to the EAR (See ¬ß744.11 of the EAR). ",
+				LicensePolicy:      "Presumption of denial.",
+				FRNotice:           "81 FR 57451",
+				SourceListURL:      "http://bit.ly/1L47xrV",
+				SourceInfoURL:      "http://bit.ly/1L47xrV",
+			},
+			{
+				Name:               "Luqman Yasin Yunus Shgragi",
+				AlternateNames:     []string{"Lkemanasel Yosef", "Luqman Sehreci."},
+				Addresses:          []string{"Savcili Mahalesi Turkmenler Caddesi No:2, Sahinbey, Gaziantep, TR", "Sanayi Mahalesi 60214 Nolu Caddesi No 11, SehitKamil, Gaziantep, TR"},
+				StartDate:          "8/23/16",
+				LicenseRequirement: "For all items subject to the EAR.  (See ¬ß744.11 of the EAR)",
+				LicensePolicy:      "Presumption of denial.",
+				FRNotice:           "81 FR 57451",
+				SourceListURL:      "http://bit.ly/1L47xrV",
+				SourceInfoURL:      "http://bit.ly/1L47xrV",
+			},
+		}),
+	})
 }
 
 func createTestSearcher(t *testing.T) *searcher {
@@ -201,7 +216,7 @@ func createTestSearcher(t *testing.T) *searcher {
 	}
 
 	testSearcherOnce.Do(func() {
-		stats, err := testLiveSearcher.refreshData("")
+		stats, err := testLiveSearcher.refreshData(context.Background(), "")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -331,7 +346,7 @@ func TestSearch_liveData(t *testing.T) {
 }
 
 func TestSearch__topAddressesAddress(t *testing.T) {
-	it := topAddressesAddress("needle")(&Address{address: "needleee"})
+	it := topAddressesAddress(jaroWinklerScorer{}, "needle")(&Address{address: "needleee"})
 
 	eql(t, "topAddressesAddress", it.weight, 0.950)
 	if add, ok := it.value.(*Address); !ok || add.address != "needleee" {
@@ -340,7 +355,7 @@ func TestSearch__topAddressesAddress(t *testing.T) {
 }
 
 func TestSearch__topAddressesCountry(t *testing.T) {
-	it := topAddressesAddress("needle")(&Address{address: "needleee"})
+	it := topAddressesAddress(jaroWinklerScorer{}, "needle")(&Address{address: "needleee"})
 
 	eql(t, "topAddressesCountry", it.weight, 0.950)
 	if add, ok := it.value.(*Address); !ok || add.address != "needleee" {
@@ -350,8 +365,8 @@ func TestSearch__topAddressesCountry(t *testing.T) {
 
 func TestSearch__multiAddressCompare(t *testing.T) {
 	it := multiAddressCompare(
-		topAddressesAddress("needle"),
-		topAddressesCountry("other"),
+		topAddressesAddress(jaroWinklerScorer{}, "needle"),
+		topAddressesCountry(jaroWinklerScorer{}, "other"),
 	)(&Address{address: "needlee", country: "other"})
 
 	eql(t, "multiAddressCompare", it.weight, 0.986)
@@ -381,7 +396,7 @@ func TestSearch__TopAddresses(t *testing.T) {
 }
 
 func TestSearch__TopAddressFn(t *testing.T) {
-	addresses := TopAddressesFn(1, 0.00, addressSearcher.Addresses, topAddressesCountry("United Kingdom"))
+	addresses := TopAddressesFn(1, 0.00, addressSearcher.Addresses, topAddressesCountry(jaroWinklerScorer{}, "United Kingdom"))
 	if len(addresses) == 0 {
 		t.Fatal("empty Addresses")
 	}
@@ -436,8 +451,8 @@ func TestSearch__TopDPs(t *testing.T) {
 		t.Fatal("empty DPs")
 	}
 	// DPL doesn't have any entity IDs. Comparing expected address components instead
-	if dps[0].DeniedPerson.StreetAddress != "P.O. BOX 28360" || dps[0].DeniedPerson.City != "DUBAI" {
-		t.Errorf("%#v", dps[0].DeniedPerson)
+	if dps[0].Data.StreetAddress != "P.O. BOX 28360" || dps[0].Data.City != "DUBAI" {
+		t.Errorf("%#v", dps[0].Data)
 	}
 }
 
@@ -0,0 +1,67 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"github.com/moov-io/watchman/internal/transliterate"
+)
+
+// transliterateStep romanizes names written in Cyrillic, Greek, Arabic or Chinese script with the
+// most common convention of each, so they're compared against other names in Latin script.
+type transliterateStep struct{}
+
+func (s *transliterateStep) apply(in *Name) error {
+	if in == nil {
+		return nil
+	}
+	in.Processed = transliterate.Romanize(in.Processed)[0]
+	return nil
+}
+
+// searchQuery is one romanization of a search, precomputed along with its phonetic keys
+type searchQuery struct {
+	name      string
+	phonetics phoneticKeys
+}
+
+// newSearchQueries precomputes each romanization of name. Conventions differ (e.g. "Юрий" is
+// "Yuriy", "Iurii" or "Urij") so every one is searched and the best score is kept.
+func newSearchQueries(name string) []searchQuery {
+	romanized := transliterate.Romanize(name)
+	out := make([]searchQuery, 0, len(romanized))
+	for i := range romanized {
+		query := precompute(romanized[i])
+		out = append(out, searchQuery{
+			name:      query,
+			phonetics: phoneticName(query),
+		})
+	}
+	return out
+}
+
+// scoreQueries returns the best score of a precomputed name against any of the queries
+func scoreQueries(sc scorer, name string, keys phoneticKeys, queries []searchQuery) float64 {
+	var best float64
+	for i := range queries {
+		if score := scoreName(sc, name, keys, queries[i].name, queries[i].phonetics); score > best {
+			best = score
+		}
+	}
+	return best
+}
+
+// explainQueries explains the score of the query which matched a precomputed name best
+func explainQueries(sc scorer, name string, keys phoneticKeys, queries []searchQuery) *explanation {
+	if len(queries) == 0 {
+		return nil
+	}
+	best, bestScore := 0, -1.0
+	for i := range queries {
+		if score := scoreName(sc, name, keys, queries[i].name, queries[i].phonetics); score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return explainMatch(sc, name, keys, queries[best].name, queries[best].phonetics)
+}
@@ -0,0 +1,75 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/ofac"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransliterateStep(t *testing.T) {
+	step := &transliterateStep{}
+
+	name := &Name{Processed: "Владимир Путин"}
+	require.NoError(t, step.apply(name))
+	require.Equal(t, "Vladimir Putin", name.Processed)
+
+	name = &Name{Processed: "John Smith"}
+	require.NoError(t, step.apply(name))
+	require.Equal(t, "John Smith", name.Processed)
+
+	require.NoError(t, step.apply(nil))
+}
+
+func TestNewSearchQueries(t *testing.T) {
+	queries := newSearchQueries("Юрий")
+	require.Len(t, queries, 3)
+	require.Equal(t, "yuriy", queries[0].name)
+	require.Equal(t, "iurii", queries[1].name)
+	require.Equal(t, "urij", queries[2].name)
+	require.NotEmpty(t, queries[0].phonetics[0])
+
+	queries = newSearchQueries("John SMITH")
+	require.Len(t, queries, 1)
+	require.Equal(t, "john smith", queries[0].name)
+}
+
+func TestSearcher_TopSDNs_Transliterated(t *testing.T) {
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.explainMatches = true
+	s.SDNs = precomputeSDNs([]*ofac.SDN{
+		{EntityID: "1", SDNName: "SHOIGU, Sergei", SDNType: "individual"},
+		{EntityID: "2", SDNName: "PUTIN, Vladimir Vladimirovich", SDNType: "individual"},
+	}, nil, noLogPipeliner)
+
+	sdns := s.TopSDNs(1, 0.00, "Сергей Шойгу")
+	require.Len(t, sdns, 1)
+	require.Equal(t, "1", sdns[0].EntityID)
+	require.InDelta(t, 1.0, sdns[0].match, 0.001)
+
+	// The ALA-LC romanization matched, rather than BGN/PCGN's "sergey shoygu"
+	require.Equal(t, "sergei shoigu", sdns[0].explanation.Query)
+
+	sdns = s.TopSDNs(1, 0.00, "Владимир Владимирович Путин")
+	require.Len(t, sdns, 1)
+	require.Equal(t, "2", sdns[0].EntityID)
+	require.Greater(t, sdns[0].match, 0.95)
+}
+
+func TestSearcher_TopSDNs_TransliteratedIndex(t *testing.T) {
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.SDNs = precomputeSDNs([]*ofac.SDN{
+		{EntityID: "1", SDNName: "Νίκος Παππάς", SDNType: "individual"},
+	}, nil, noLogPipeliner)
+	require.Equal(t, "nikos pappas", s.SDNs[0].name)
+
+	sdns := s.TopSDNs(1, 0.00, "Nikos Pappas")
+	require.Len(t, sdns, 1)
+	require.InDelta(t, 1.0, sdns[0].match, 0.001)
+}
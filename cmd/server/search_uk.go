@@ -0,0 +1,14 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"github.com/moov-io/watchman/pkg/uk"
+)
+
+// TopUKEntities searches OFSI Consolidated List targets by each of their names
+func (s *searcher) TopUKEntities(limit int, minMatch float64, name string) []*Result[uk.Entity] {
+	return topList[uk.Entity](s, "UK", limit, minMatch, name)
+}
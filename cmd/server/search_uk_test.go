@@ -0,0 +1,34 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/source"
+	"github.com/moov-io/watchman/pkg/uk"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearcher_TopUKEntities(t *testing.T) {
+	entities, err := uk.ReadFile(filepath.Join("..", "..", "test", "testdata", "uk_conlist.csv"))
+	require.NoError(t, err)
+
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.setLists("UK", source.List{Name: "UK", Entities: source.Entities(entities)})
+
+	found := s.TopUKEntities(1, 0.00, "Ayman al-Zawahiri")
+	require.Len(t, found, 1)
+	require.Equal(t, "6905", found[0].Data.GroupID)
+
+	// Every row of a group is searched
+	found = s.TopUKEntities(1, 0.00, "ROE")
+	require.Len(t, found, 1)
+	require.Equal(t, "13074", found[0].Data.GroupID)
+	require.InDelta(t, 1.0, found[0].match, 0.001)
+}
@@ -0,0 +1,15 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"github.com/moov-io/watchman/pkg/un"
+)
+
+// TopUNEntities searches UN Security Council Consolidated List records by name and alias.
+// Each hit's SourceList and ListType attribute it to the UN and its sanctions committee.
+func (s *searcher) TopUNEntities(limit int, minMatch float64, name string) []*Result[un.Entity] {
+	return topList[un.Entity](s, "UN", limit, minMatch, name)
+}
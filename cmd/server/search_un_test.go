@@ -0,0 +1,41 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/source"
+	"github.com/moov-io/watchman/pkg/un"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearcher_TopUNEntities(t *testing.T) {
+	entities, err := un.ReadFile(filepath.Join("..", "..", "test", "testdata", "un_consolidated.xml"))
+	require.NoError(t, err)
+
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.setLists("UN", source.List{Name: "UN", Entities: source.Entities(entities)})
+
+	found := s.TopUNEntities(1, 0.00, "Aiman Muhammed Rabi al-Zawahiri")
+	require.Len(t, found, 1)
+	require.Equal(t, "QDi.006", found[0].Data.ReferenceNumber)
+
+	// Aliases are searched
+	found = s.TopUNEntities(1, 0.00, "KOMID")
+	require.Len(t, found, 1)
+	require.Equal(t, "110369", found[0].Data.DataID)
+	require.InDelta(t, 1.0, found[0].match, 0.001)
+
+	// Each hit is attributed to the UN and its sanctions committee
+	bs, err := json.Marshal(found[0])
+	require.NoError(t, err)
+	require.Contains(t, string(bs), `"sourceList":"UN"`)
+	require.Contains(t, string(bs), `"listType":"DPRK"`)
+}
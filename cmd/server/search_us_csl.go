@@ -5,80 +5,60 @@
 package main
 
 import (
-	"reflect"
-
 	"github.com/moov-io/watchman/pkg/csl"
 )
 
-func precomputeCSLEntities[T any](items []*T, pipe *pipeliner) []*Result[T] {
-	out := make([]*Result[T], len(items))
-
-	for i, item := range items {
-		name := cslName(item)
-		if err := pipe.Do(name); err != nil {
-			pipe.logger.LogErrorf("problem pipelining %T: %v", item, err)
-			continue
-		}
-
-		var altNames []string
-
-		elm := reflect.ValueOf(item).Elem()
-		for i := 0; i < elm.NumField(); i++ {
-			name := elm.Type().Field(i).Name
-			_type := elm.Type().Field(i).Type.String()
-
-			if name == "AlternateNames" && _type == "[]string" {
-				alts, ok := elm.Field(i).Interface().([]string)
-				if !ok {
-					continue
-				}
-				for j := range alts {
-					alt := &Name{Processed: alts[j]}
-					pipe.Do(alt)
-					altNames = append(altNames, alt.Processed)
-				}
-			}
-		}
-
-		out[i] = &Result[T]{
-			Data:            *item,
-			precomputedName: name.Processed,
-			precomputedAlts: altNames,
-		}
-	}
+// TopBISEntities searches BIS Entity List records by name and alias
+func (s *searcher) TopBISEntities(limit int, minMatch float64, name string) []*Result[csl.EL] {
+	return topList[csl.EL](s, "BISEntities", limit, minMatch, name)
+}
 
-	return out
+// TopMEUs searches Military End User records by name and alias
+func (s *searcher) TopMEUs(limit int, minMatch float64, name string) []*Result[csl.MEU] {
+	return topList[csl.MEU](s, "MilitaryEndUsers", limit, minMatch, name)
 }
 
-// TopBISEntities searches BIS Entity List records by name and alias
-func (s *searcher) TopBISEntities(limit int, minMatch float64, name string) []*Result[csl.EL] {
-	s.RLock()
-	defer s.RUnlock()
+// TopSSIs searches Sectoral Sanctions records by Name and Alias
+func (s *searcher) TopSSIs(limit int, minMatch float64, name string) []*Result[csl.SSI] {
+	return topList[csl.SSI](s, "SSIs", limit, minMatch, name)
+}
 
-	s.Gate.Start() // TODO(adam): This used to be on a pre-record gate, so this may have different perf metrics
-	defer s.Gate.Done()
+// TopUVLs searches Unverified List records by name and alias
+func (s *searcher) TopUVLs(limit int, minMatch float64, name string) []*Result[csl.UVL] {
+	return topList[csl.UVL](s, "UVLs", limit, minMatch, name)
+}
 
-	return topResults(limit, minMatch, name, s.BISEntities)
+// TopISNs searches Nonproliferation Sanctions records by name and alias
+func (s *searcher) TopISNs(limit int, minMatch float64, name string) []*Result[csl.ISN] {
+	return topList[csl.ISN](s, "ISNs", limit, minMatch, name)
 }
 
-// TopMEUs searches Military End User records by name and alias
-func (s *searcher) TopMEUs(limit int, minMatch float64, name string) []*Result[csl.MEU] {
-	s.RLock()
-	defer s.RUnlock()
+// TopFSEs searches Foreign Sanctions Evaders records by name and alias
+func (s *searcher) TopFSEs(limit int, minMatch float64, name string) []*Result[csl.FSE] {
+	return topList[csl.FSE](s, "FSEs", limit, minMatch, name)
+}
 
-	s.Gate.Start()
-	defer s.Gate.Done()
+// TopPLCs searches Palestinian Legislative Council records by name and alias
+func (s *searcher) TopPLCs(limit int, minMatch float64, name string) []*Result[csl.PLC] {
+	return topList[csl.PLC](s, "PLCs", limit, minMatch, name)
+}
 
-	return topResults(limit, minMatch, name, s.MilitaryEndUsers)
+// TopCAPs searches CAPTA List records by name and alias
+func (s *searcher) TopCAPs(limit int, minMatch float64, name string) []*Result[csl.CAP] {
+	return topList[csl.CAP](s, "CAPs", limit, minMatch, name)
 }
 
-// TopSSIs searches Sectoral Sanctions records by Name and Alias
-func (s *searcher) TopSSIs(limit int, minMatch float64, name string) []*Result[csl.SSI] {
-	s.RLock()
-	defer s.RUnlock()
+// TopNSMBSs searches Non-SDN Menu-Based Sanctions records by name and alias
+func (s *searcher) TopNSMBSs(limit int, minMatch float64, name string) []*Result[csl.NSMBS] {
+	return topList[csl.NSMBS](s, "NSMBSs", limit, minMatch, name)
+}
 
-	s.Gate.Start()
-	defer s.Gate.Done()
+// TopCMICs searches Chinese Military-Industrial Complex Companies records by name and alias
+func (s *searcher) TopCMICs(limit int, minMatch float64, name string) []*Result[csl.CMIC] {
+	return topList[csl.CMIC](s, "CMICs", limit, minMatch, name)
+}
 
-	return topResults(limit, minMatch, name, s.SSIs)
+// TopDTCs searches ITAR Debarred records by name and alias
+func (s *searcher) TopDTCs(limit int, minMatch float64, name string) []*Result[csl.DTC] {
+	return topList[csl.DTC](s, "DTCs", limit, minMatch, name)
 }
@@ -7,8 +7,12 @@ package main
 import (
 	"fmt"
 	"math"
+	"path/filepath"
 	"testing"
 
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/csl"
+
 	"github.com/stretchr/testify/require"
 )
 
@@ -44,7 +48,15 @@ func TestSearcher_TopMEUs(t *testing.T) {
 	require.Len(t, meus, 1)
 
 	require.Equal(t, "d54346ef81802673c1b1daeb2ca8bd5d13755abd", meus[0].Data.EntityID)
-	require.Equal(t, "0.70597", fmt.Sprintf("%.5f", meus[0].match))
+	require.Equal(t, "0.75008", fmt.Sprintf("%.5f", meus[0].match)) // both words of the query sound alike
+}
+
+func TestSearcher_TopMEUs_AltName(t *testing.T) {
+	meus := meuSearcher.TopMEUs(1, 0.00, "Xi'an Aero-Engine")
+	require.Len(t, meus, 1)
+
+	require.Equal(t, "26744194bd9b5cbec49db6ee29a4b53c697c7420", meus[0].Data.EntityID)
+	require.InDelta(t, 1.0, meus[0].match, 0.001)
 }
 
 func TestSearcher_TopSSIs(t *testing.T) {
@@ -83,3 +95,33 @@ func TestSearcher_TopSSIs_reportAltNameWeight(t *testing.T) {
 		t.Errorf("Expected match=1.0 for alt names: %f - %#v", ssis[0].match, ssis[0].Data)
 	}
 }
+
+func TestSearcher_TopCSLSubLists(t *testing.T) {
+	lists, err := csl.Source{}.Read([]string{filepath.Join("..", "..", "test", "testdata", "csl.csv")})
+	require.NoError(t, err)
+
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.setLists("CSL", lists...)
+
+	plcs := s.TopPLCs(1, 0.00, "Khalid Sulayman Fayiz Abu Hasan")
+	require.Len(t, plcs, 1)
+	require.Equal(t, "9689", plcs[0].Data.EntityNumber)
+
+	// Alternate names are searched
+	dtcs := s.TopDTCs(1, 0.00, "Gwen Douglas")
+	require.Len(t, dtcs, 1)
+	require.Equal(t, "Gwendolyn Douglas", dtcs[0].Data.Name)
+	require.InDelta(t, 1.0, dtcs[0].match, 0.001)
+
+	caps := s.TopCAPs(1, 0.00, "Karamay City Commercial Bank")
+	require.Len(t, caps, 1)
+	require.Equal(t, "15268", caps[0].Data.EntityNumber)
+
+	uvls := s.TopUVLs(1, 0.00, "Gulf Modern Solutions Engineering")
+	require.Len(t, uvls, 1)
+	require.Equal(t, "Gulf Modern Solutions Engineering Company", uvls[0].Data.Name)
+
+	isns := s.TopISNs(1, 0.00, "GE Plan")
+	require.Len(t, isns, 1)
+	require.Equal(t, "GE Plan", isns[0].Data.Name)
+}
@@ -0,0 +1,58 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"strings"
+
+	"github.com/moov-io/watchman/internal/variants"
+)
+
+// nameVariants are the names which score as exact matches of each other (e.g. "Mohammed" and
+// "Muhammad" or "Bill" and "William"). It's extended from NAME_VARIANTS_FILE on startup.
+var nameVariants = variants.Default()
+
+// variantToken is a word of the query which was scored as the word of the matched name it's a variant of
+type variantToken struct {
+	Query string `json:"query"`
+	Name  string `json:"name"`
+}
+
+// substituteVariants replaces each word of a precomputed query which is a variant of a word in the
+// precomputed name with that word, so any scorer treats them as an exact match. Words which already
+// match exactly are kept.
+func substituteVariants(name, query string, dict *variants.Dictionary) (string, []variantToken) {
+	if dict.Len() == 0 {
+		return query, nil
+	}
+	names, queries := strings.Fields(name), strings.Fields(query)
+
+	var substituted []variantToken
+	for i := range queries {
+		if containsWord(names, queries[i]) {
+			continue
+		}
+		for j := range names {
+			if dict.Equivalent(queries[i], names[j]) {
+				substituted = append(substituted, variantToken{Query: queries[i], Name: names[j]})
+				queries[i] = names[j]
+				break
+			}
+		}
+	}
+	if len(substituted) == 0 {
+		return query, nil
+	}
+	return strings.Join(queries, " "), substituted
+}
+
+func containsWord(words []string, word string) bool {
+	for i := range words {
+		if words[i] == word {
+			return true
+		}
+	}
+	return false
+}
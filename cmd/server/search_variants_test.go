@@ -0,0 +1,91 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/internal/variants"
+	"github.com/moov-io/watchman/pkg/ofac"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubstituteVariants(t *testing.T) {
+	dict := variants.New()
+	dict.Add("bill", "william")
+
+	query, substituted := substituteVariants("william smith", "bill smith", dict)
+	require.Equal(t, "william smith", query)
+	require.Equal(t, []variantToken{{Query: "bill", Name: "william"}}, substituted)
+	require.InDelta(t, 1.0, jaroWinkler("william smith", query), 0.001)
+
+	// Without the dictionary variants score as any other word
+	query, substituted = substituteVariants("william smith", "bill smith", nil)
+	require.Equal(t, "bill smith", query)
+	require.Empty(t, substituted)
+	require.Less(t, jaroWinkler("william smith", query), 0.9)
+
+	// Exact matches are kept
+	query, substituted = substituteVariants("william bill", "bill william", dict)
+	require.Equal(t, "bill william", query)
+	require.Empty(t, substituted)
+}
+
+func TestSubstituteVariants__Scorers(t *testing.T) {
+	// Every scorer scores variants as exact matches
+	for _, sc := range scorers {
+		score := scoreName(sc, "aleksandr petrov", nil, "alexander petrov", nil)
+		require.InDelta(t, 1.0, score, 0.001, sc.name())
+	}
+}
+
+func TestSearcher_TopSDNs_Variants(t *testing.T) {
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.explainMatches = true
+	s.SDNs = precomputeSDNs([]*ofac.SDN{
+		{EntityID: "1", SDNName: "HASSAN, Muhammad", SDNType: "individual"},
+		{EntityID: "2", SDNName: "MAHMOUD, Hassan", SDNType: "individual"},
+	}, nil, noLogPipeliner)
+
+	sdns := s.TopSDNs(1, 0.00, "Mohamed Hasan")
+	require.Len(t, sdns, 1)
+	require.Equal(t, "1", sdns[0].EntityID)
+	require.InDelta(t, 1.0, sdns[0].match, 0.001)
+
+	exp := sdns[0].explanation
+	require.NotNil(t, exp)
+	require.ElementsMatch(t, []variantToken{
+		{Query: "mohamed", Name: "muhammad"},
+		{Query: "hasan", Name: "hassan"},
+	}, exp.Variants)
+
+	bs, err := json.Marshal(sdns[0])
+	require.NoError(t, err)
+	require.Contains(t, string(bs), `"variants":[`)
+}
+
+func TestSearcher_TopSDNs_VariantsFile(t *testing.T) {
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.SDNs = precomputeSDNs([]*ofac.SDN{
+		{EntityID: "1", SDNName: "PETROV, Ivan Ivanovich", SDNType: "individual"},
+	}, nil, noLogPipeliner)
+
+	sdns := s.TopSDNs(1, 0.00, "Ivan Ivanovitch Petrov")
+	require.Len(t, sdns, 1)
+	require.Less(t, sdns[0].match, 1.0)
+
+	dict := nameVariants
+	t.Cleanup(func() { nameVariants = dict })
+
+	nameVariants = variants.Default()
+	nameVariants.Add("ivanovich", "ivanovitch")
+
+	sdns = s.TopSDNs(1, 0.00, "Ivan Ivanovitch Petrov")
+	require.Len(t, sdns, 1)
+	require.InDelta(t, 1.0, sdns[0].match, 0.001)
+}
@@ -0,0 +1,16 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"github.com/moov-io/watchman/pkg/watchlist"
+)
+
+// TopWatchlistEntities searches every internal list by name and alias
+func (s *searcher) TopWatchlistEntities(limit int, minMatch float64, name string) []*Result[watchlist.Entity] {
+	return topEntities[watchlist.Entity](s, limit, minMatch, name, func() []*listData {
+		return s.sourceLists(watchlist.SourceName)
+	})
+}
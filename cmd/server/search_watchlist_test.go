@@ -0,0 +1,51 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/watchlist"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearcher_TopWatchlistEntities(t *testing.T) {
+	lists, err := watchlist.ReadConfig(filepath.Join("..", "..", "test", "testdata", "watchlists.json"))
+	require.NoError(t, err)
+
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.addSource(watchlist.Source{Lists: lists})
+	require.True(t, s.reloadSource(context.Background(), watchlist.SourceName))
+	require.Len(t, s.sourceEntities(watchlist.SourceName), 5)
+
+	found := s.TopWatchlistEntities(1, 0.00, "Jonathan Marlow")
+	require.Len(t, found, 1)
+	require.Equal(t, "C-1001", found[0].Data.ID)
+
+	// Aliases are searched and results carry their list's name
+	found = s.TopWatchlistEntities(1, 0.00, "OLT General Trading")
+	require.Len(t, found, 1)
+	require.Equal(t, "exited-customers", found[0].Data.ListName)
+	require.InDelta(t, 1.0, found[0].match, 0.001)
+
+	bs, err := json.Marshal(found[0])
+	require.NoError(t, err)
+	require.Contains(t, string(bs), `"listName":"exited-customers"`)
+
+	// Rows are screened against internal lists and each hit has the internal list's name
+	resp := buildFullSearchResponse(s, 10, 0.90, "OLT General Trading", "")
+	require.Len(t, resp.Entities, 1)
+	require.Equal(t, "exited-customers", resp.Entities[0].Data.SourceList)
+	require.Empty(t, resp.SDNs)
+
+	bs, err = json.Marshal(resp)
+	require.NoError(t, err)
+	require.Contains(t, string(bs), `"sourceList":"exited-customers"`)
+}
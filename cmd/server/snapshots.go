@@ -0,0 +1,289 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/ofac"
+)
+
+const (
+	// snapshotLayout is how each snapshot directory is named, in UTC
+	snapshotLayout = "20060102T150405Z"
+)
+
+// snapshotArchive keeps a copy of the list files from each refresh so searches can be run
+// against the data which was current at an earlier date.
+//
+// Each snapshot is a sub-directory of dir named after the time it was refreshed.
+type snapshotArchive struct {
+	dir string
+}
+
+func newSnapshotArchive(dir string) (*snapshotArchive, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("snapshot archive: %v", err)
+	}
+	return &snapshotArchive{dir: dir}, nil
+}
+
+// snapshot is one archived set of list files
+type snapshot struct {
+	Name        string
+	Dir         string
+	RefreshedAt time.Time
+}
+
+// list returns every snapshot in the archive ordered from oldest to newest.
+func (a *snapshotArchive) list() ([]snapshot, error) {
+	entries, err := os.ReadDir(a.dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshots: %v", err)
+	}
+	var out []snapshot
+	for i := range entries {
+		if !entries[i].IsDir() {
+			continue
+		}
+		when, err := time.Parse(snapshotLayout, entries[i].Name())
+		if err != nil {
+			continue // not a snapshot
+		}
+		out = append(out, snapshot{
+			Name:        entries[i].Name(),
+			Dir:         filepath.Join(a.dir, entries[i].Name()),
+			RefreshedAt: when,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].RefreshedAt.Before(out[j].RefreshedAt)
+	})
+	return out, nil
+}
+
+// save copies files into a new snapshot for refreshedAt. Nothing is written when the files
+// are identical to the latest snapshot, in which case the returned directory is empty.
+func (a *snapshotArchive) save(files []string, refreshedAt time.Time) (string, error) {
+	if a == nil || len(files) == 0 {
+		return "", nil
+	}
+	snapshots, err := a.list()
+	if err != nil {
+		return "", err
+	}
+	if len(snapshots) > 0 {
+		latest := snapshots[len(snapshots)-1]
+		if same, err := sameFiles(latest.Dir, files); err == nil && same {
+			return "", nil
+		}
+	}
+
+	dir := filepath.Join(a.dir, refreshedAt.UTC().Format(snapshotLayout))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("creating snapshot: %v", err)
+	}
+	for i := range files {
+		if err := copyFile(files[i], filepath.Join(dir, filepath.Base(files[i]))); err != nil {
+			os.RemoveAll(dir)
+			return "", fmt.Errorf("copying %s into snapshot: %v", filepath.Base(files[i]), err)
+		}
+	}
+	return dir, nil
+}
+
+// find returns the snapshot which was current at asOf, which is the latest one refreshed at or before it.
+func (a *snapshotArchive) find(asOf time.Time) (*snapshot, error) {
+	if a == nil {
+		return nil, errors.New("no snapshot directory configured")
+	}
+	snapshots, err := a.list()
+	if err != nil {
+		return nil, err
+	}
+	var found *snapshot
+	for i := range snapshots {
+		if snapshots[i].RefreshedAt.After(asOf) {
+			break
+		}
+		found = &snapshots[i]
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no snapshot from on or before %v", asOf.Format(time.RFC3339))
+	}
+	return found, nil
+}
+
+// searcherAsOf loads the snapshot current at asOf into a new searcher. The returned searcher is
+// never refreshed and labels its search results with the snapshot used.
+//
+// Every source is read from the files archived for it. Sources without files in the snapshot, such
+// as those archived before they were added, are listed in the searcher's missingSources instead.
+func (a *snapshotArchive) searcherAsOf(logger log.Logger, pipe *pipeliner, workers int, asOf time.Time) (*searcher, error) {
+	snap, err := a.find(asOf)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(snap.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot %s: %v", snap.Name, err)
+	}
+	var files []string
+	for i := range entries {
+		files = append(files, filepath.Join(snap.Dir, entries[i].Name()))
+	}
+
+	s := newSearcher(logger, pipe, workers)
+	s.sourceData = make(map[string]*sourceData)
+	for _, src := range s.sources {
+		if len(src.Filenames()) == 0 {
+			continue // local files, such as the internal watchlists, aren't archived
+		}
+		found := snapshotFiles(files, src.Filenames())
+		if len(found) == 0 {
+			s.missingSources = append(s.missingSources, src.Name())
+			continue
+		}
+
+		if src.Name() == ofac.SourceName {
+			results, _, err := ofac.ReadFiles(found)
+			if err != nil {
+				return nil, fmt.Errorf("snapshot %s: %v", snap.Name, err)
+			}
+			s.SDNs, s.Addresses, s.Alts = enabledOFACLists(
+				precomputeSDNs(results.SDNs, results.Addresses, pipe),
+				precomputeAddresses(results.Addresses),
+				precomputeAlts(results.AlternateIdentities, pipe),
+			)
+			s.sdnIndex, s.altIndex = indexSDNs(s.SDNs), indexAlts(s.Alts)
+			s.sdnAlts = groupSDNAlts(s.SDNs, s.Alts)
+			continue
+		}
+
+		lists, err := src.Read(found)
+		if err != nil {
+			return nil, fmt.Errorf("snapshot %s: %s: %v", snap.Name, src.Name(), err)
+		}
+		fingerprint, _ := filesFingerprint(found)
+		s.sourceData[src.Name()] = &sourceData{
+			fingerprint: fingerprint,
+			files:       found,
+			lists:       precomputeLists(lists, pipe),
+		}
+	}
+	s.lastRefreshedAt = snap.RefreshedAt
+	s.snapshot = snap.Name
+	return s, nil
+}
+
+// snapshotFiles returns the paths out of files whose basename is one of filenames
+func snapshotFiles(files []string, filenames []string) []string {
+	var out []string
+	for i := range files {
+		for j := range filenames {
+			if filepath.Base(files[i]) == filenames[j] {
+				out = append(out, files[i])
+			}
+		}
+	}
+	return out
+}
+
+// archivedFiles returns the OFAC files along with the files of every other source which are
+// downloaded. Local files, such as the internal watchlists, aren't archived.
+func (s *searcher) archivedFiles(ofacFiles []string, sources map[string]*sourceData) []string {
+	s.RLock()
+	defer s.RUnlock()
+
+	out := append([]string(nil), ofacFiles...)
+	for _, src := range s.sources {
+		if data := sources[src.Name()]; data != nil && len(src.Filenames()) > 0 {
+			out = append(out, data.files...)
+		}
+	}
+	return out
+}
+
+// parseAsOf reads a date (2006-01-02) or RFC 3339 timestamp. Dates cover the entire day in UTC.
+func parseAsOf(v string) (time.Time, error) {
+	v = strings.TrimSpace(v)
+	if when, err := time.Parse("2006-01-02", v); err == nil {
+		return when.Add(24*time.Hour - time.Second), nil
+	}
+	when, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unable to parse %q as a date or RFC 3339 timestamp", v)
+	}
+	return when, nil
+}
+
+func sameFiles(dir string, files []string) (bool, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false, err
+	}
+	if len(entries) != len(files) {
+		return false, nil
+	}
+	for i := range files {
+		a, err := fileChecksum(files[i])
+		if err != nil {
+			return false, err
+		}
+		b, err := fileChecksum(filepath.Join(dir, filepath.Base(files[i])))
+		if err != nil {
+			return false, nil
+		}
+		if !bytes.Equal(a, b) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func fileChecksum(path string) ([]byte, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, fd); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,149 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/csl"
+	"github.com/moov-io/watchman/pkg/dpl"
+	"github.com/moov-io/watchman/pkg/eu"
+	"github.com/moov-io/watchman/pkg/ofac"
+	"github.com/moov-io/watchman/pkg/uk"
+	"github.com/moov-io/watchman/pkg/un"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshots__parseAsOf(t *testing.T) {
+	when, err := parseAsOf("2026-03-01")
+	require.NoError(t, err)
+	require.Equal(t, "2026-03-01T23:59:59Z", when.Format(time.RFC3339))
+
+	when, err = parseAsOf("2026-03-01T10:00:00Z")
+	require.NoError(t, err)
+	require.Equal(t, 10, when.Hour())
+
+	_, err = parseAsOf("March 1st")
+	require.Error(t, err)
+}
+
+func TestSnapshots(t *testing.T) {
+	archive, err := newSnapshotArchive(t.TempDir())
+	require.NoError(t, err)
+
+	files := []string{
+		filepath.Join("..", "..", "test", "testdata", "sdn.csv"),
+		filepath.Join("..", "..", "test", "testdata", "add.csv"),
+	}
+	first := time.Date(2026, time.February, 1, 12, 0, 0, 0, time.UTC)
+
+	dir, err := archive.save(files, first)
+	require.NoError(t, err)
+	require.Equal(t, "20260201T120000Z", filepath.Base(dir))
+
+	// identical files are not archived twice
+	dir, err = archive.save(files, first.Add(24*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, "", dir)
+
+	// changed files create a new snapshot
+	second := time.Date(2026, time.March, 15, 12, 0, 0, 0, time.UTC)
+	dir, err = archive.save(files[:1], second)
+	require.NoError(t, err)
+	require.NotEmpty(t, dir)
+
+	snapshots, err := archive.list()
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+
+	_, err = archive.find(first.Add(-time.Hour))
+	require.Error(t, err)
+
+	snap, err := archive.find(time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, "20260201T120000Z", snap.Name)
+
+	snap, err = archive.find(time.Now())
+	require.NoError(t, err)
+	require.Equal(t, "20260315T120000Z", snap.Name)
+
+	// search against the older snapshot
+	s, err := archive.searcherAsOf(log.NewNopLogger(), noLogPipeliner, 1, time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, "20260201T120000Z", s.snapshot)
+	require.Greater(t, len(s.SDNs), 0)
+	require.Greater(t, len(s.Addresses), 0)
+
+	resp := buildFullSearchResponse(s, 10, 0.95, "Nicolas Maduro", "")
+	require.Equal(t, "20260201T120000Z", resp.Snapshot)
+	require.Equal(t, first, resp.RefreshedAt)
+
+	// the snapshot only holds OFAC files, so the other lists are reported as not screened
+	require.Subset(t, resp.MissingLists, []string{"CSL", dpl.ListName, "EU", "UK", "UN"})
+	require.NotContains(t, resp.MissingLists, ofac.SourceName)
+}
+
+func TestSnapshots__everySource(t *testing.T) {
+	archive, err := newSnapshotArchive(t.TempDir())
+	require.NoError(t, err)
+
+	dir := filepath.Join("..", "..", "test", "testdata")
+	s := newSearcher(log.NewNopLogger(), noLogPipeliner, 1)
+	s.snapshots = archive
+
+	_, err = s.refreshData(context.Background(), dir)
+	require.NoError(t, err)
+
+	// the files of every source are archived, and unchanged files aren't archived again
+	_, err = s.refreshData(context.Background(), dir)
+	require.NoError(t, err)
+	snapshots, err := archive.list()
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	for _, filename := range []string{"sdn.csv", csl.Filename, dpl.Filename, eu.Filename, uk.Filename, un.Filename} {
+		require.FileExists(t, filepath.Join(snapshots[0].Dir, filename))
+	}
+
+	// each source is read from the snapshot
+	found, err := archive.searcherAsOf(log.NewNopLogger(), noLogPipeliner, 1, time.Now())
+	require.NoError(t, err)
+	require.Empty(t, found.missingSources)
+	require.Len(t, found.SDNs, len(s.SDNs))
+	for name, data := range s.sourceData {
+		require.NotNil(t, found.sourceData[name], name)
+		require.Len(t, found.sourceData[name].lists, len(data.lists), name)
+	}
+
+	dps := found.TopDPs(1, 0.0, "Adrian Manuel Hernandez")
+	require.Len(t, dps, 1)
+	require.Equal(t, "PHONEIX", dps[0].Data.City)
+
+	resp := buildFullSearchResponse(found, 10, 0.90, "Rosoboronexport", "")
+	require.Equal(t, snapshots[0].Name, resp.Snapshot)
+	require.Empty(t, resp.MissingLists)
+	var lists []string
+	for i := range resp.Entities {
+		lists = append(lists, resp.Entities[i].Data.SourceList)
+	}
+	require.Contains(t, lists, "UK")
+}
+
+func TestSnapshots__noArchive(t *testing.T) {
+	archive, err := newSnapshotArchive("")
+	require.NoError(t, err)
+	require.Nil(t, archive)
+
+	dir, err := archive.save([]string{"sdn.csv"}, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, "", dir)
+
+	_, err = archive.find(time.Now())
+	require.Error(t, err)
+}
@@ -67,6 +67,18 @@ var (
 			"add__bis_entities__to_download_stats",
 			"alter table download_stats add column bis_entities default 0;",
 		),
+		execsql(
+			"create_sdn_snapshot",
+			`create table if not exists sdn_snapshot(entity_id primary key, hash);`,
+		),
+		execsql(
+			"create_screened_inputs",
+			`create table if not exists screened_inputs(input_id primary key, name, email, hash, matches, screened_at datetime);`,
+		),
+		execsql(
+			"create_download_list_stats",
+			`create table if not exists download_list_stats(downloaded_at datetime, list, records);`,
+		),
 	)
 )
 
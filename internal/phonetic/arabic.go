@@ -0,0 +1,55 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package phonetic
+
+import (
+	"strings"
+)
+
+// arabicSpellings rewrites the letters romanized differently between English, French and
+// scholarly transliterations of Arabic names into one spelling (e.g. "Djamel" and "Jamal",
+// "Othman" and "Osman", "Khaled" and "Kaled").
+var arabicSpellings = strings.NewReplacer(
+	"DJ", "J",
+	"DH", "D",
+	"TH", "S",
+	"KH", "K",
+	"GH", "G",
+	"Q", "K",
+	"OU", "U",
+	"OO", "U",
+	"EE", "I",
+	"CH", "SH",
+)
+
+// Arabic returns the Double Metaphone encoding of word after rewriting the spellings which
+// differ between transliterations of Arabic names.
+func Arabic(word string) (string, string) {
+	return DoubleMetaphone(arabicSpellings.Replace(normalize(word)))
+}
+
+// Keys returns every distinct phonetic key of word, from both Double Metaphone and its
+// Arabic-aware variant. Words without letters from A to Z have no keys.
+func Keys(word string) []string {
+	primary, alternate := DoubleMetaphone(word)
+	arabicPrimary, arabicAlternate := Arabic(word)
+
+	var out []string
+	for _, key := range []string{primary, alternate, arabicPrimary, arabicAlternate} {
+		if key != "" && !contains(out, key) {
+			out = append(out, key)
+		}
+	}
+	return out
+}
+
+func contains(keys []string, key string) bool {
+	for i := range keys {
+		if keys[i] == key {
+			return true
+		}
+	}
+	return false
+}
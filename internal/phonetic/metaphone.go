@@ -0,0 +1,327 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+// Package phonetic encodes names by how they sound, so spellings such as "Kaddafi", "Qadhafi"
+// and "Gaddafi" share a key.
+package phonetic
+
+import (
+	"strings"
+)
+
+// maxLength is how many sounds are kept of each key, as in the original Double Metaphone
+const maxLength = 4
+
+// DoubleMetaphone returns the primary and alternate encoding of word. The alternate encoding
+// differs when a spelling is pronounced differently by origin (e.g. the "CH" of "Michael" and "Chavez").
+// Anything other than the letters A to Z is ignored, so words in other scripts have no key.
+func DoubleMetaphone(word string) (string, string) {
+	e := &encoder{word: normalize(word)}
+	if e.word == "" {
+		return "", ""
+	}
+	e.encode()
+
+	primary, alternate := e.primary.String(), e.alternate.String()
+	if len(primary) > maxLength {
+		primary = primary[:maxLength]
+	}
+	if len(alternate) > maxLength {
+		alternate = alternate[:maxLength]
+	}
+	return primary, alternate
+}
+
+// normalize uppercases word and removes everything other than the letters A to Z
+func normalize(word string) string {
+	var buf strings.Builder
+	for _, r := range strings.ToUpper(word) {
+		if r >= 'A' && r <= 'Z' {
+			buf.WriteRune(r)
+		}
+	}
+	return buf.String()
+}
+
+type encoder struct {
+	word string
+	pos  int
+
+	primary, alternate strings.Builder
+}
+
+func (e *encoder) add(primary, alternate string) {
+	e.primary.WriteString(primary)
+	e.alternate.WriteString(alternate)
+}
+
+// at returns the letter at offset from the current position, or zero outside of the word
+func (e *encoder) at(offset int) byte {
+	i := e.pos + offset
+	if i < 0 || i >= len(e.word) {
+		return 0
+	}
+	return e.word[i]
+}
+
+// next reports if the letters from the current position start with any of prefixes
+func (e *encoder) next(prefixes ...string) bool {
+	for i := range prefixes {
+		if strings.HasPrefix(e.word[e.pos:], prefixes[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func isVowel(c byte) bool {
+	switch c {
+	case 'A', 'E', 'I', 'O', 'U', 'Y':
+		return true
+	}
+	return false
+}
+
+func (e *encoder) encode() {
+	// Silent first letters
+	if e.next("GN", "KN", "PN", "WR", "PS") {
+		e.pos++
+	}
+	if e.at(0) == 'X' {
+		e.add("S", "S")
+		e.pos++
+	}
+
+	for e.pos < len(e.word) {
+		c := e.at(0)
+		switch {
+		case isVowel(c):
+			// Only vowels starting a word are encoded
+			if e.pos == 0 {
+				e.add("A", "A")
+			}
+			e.pos++
+
+		case c == 'B':
+			e.add("P", "P")
+			e.skip('B')
+
+		case c == 'C':
+			e.encodeC()
+
+		case c == 'D':
+			switch {
+			case e.next("DG") && (e.at(2) == 'E' || e.at(2) == 'I' || e.at(2) == 'Y'):
+				e.add("J", "J")
+				e.pos += 3
+			case e.next("DT", "DD"):
+				e.add("T", "T")
+				e.pos += 2
+			default:
+				e.add("T", "T")
+				e.pos++
+			}
+
+		case c == 'F':
+			e.add("F", "F")
+			e.skip('F')
+
+		case c == 'G':
+			e.encodeG()
+
+		case c == 'H':
+			// H is only sounded before a vowel, unless it follows a consonant
+			if (e.pos == 0 || isVowel(e.at(-1))) && isVowel(e.at(1)) {
+				e.add("H", "H")
+			}
+			e.pos++
+
+		case c == 'J':
+			if e.pos == 0 && e.next("JOSE") {
+				e.add("H", "H")
+			} else {
+				e.add("J", "H")
+			}
+			e.skip('J')
+
+		case c == 'K':
+			e.add("K", "K")
+			e.skip('K')
+
+		case c == 'L':
+			e.add("L", "L")
+			e.skip('L')
+
+		case c == 'M':
+			e.add("M", "M")
+			e.skip('M')
+
+		case c == 'N':
+			e.add("N", "N")
+			e.skip('N')
+
+		case c == 'P':
+			if e.at(1) == 'H' {
+				e.add("F", "F")
+				e.pos += 2
+			} else {
+				e.add("P", "P")
+				e.skip('P', 'B')
+			}
+
+		case c == 'Q':
+			e.add("K", "K")
+			e.skip('Q')
+
+		case c == 'R':
+			e.add("R", "R")
+			e.skip('R')
+
+		case c == 'S':
+			e.encodeS()
+
+		case c == 'T':
+			switch {
+			case e.next("TIO", "TIA", "TCH"):
+				e.add("X", "X")
+				e.pos += 3
+			case e.next("TH"):
+				e.add("0", "T")
+				e.pos += 2
+			default:
+				e.add("T", "T")
+				e.skip('T', 'D')
+			}
+
+		case c == 'V':
+			e.add("F", "F")
+			e.skip('V')
+
+		case c == 'W':
+			// W is only sounded starting a word, where it's a vowel or a V by origin (e.g. "Wasserman",
+			// "Wladimir")
+			if e.pos == 0 {
+				if isVowel(e.at(1)) {
+					e.add("A", "F")
+				} else {
+					e.add("F", "F")
+				}
+			}
+			e.pos++
+
+		case c == 'X':
+			e.add("KS", "KS")
+			e.skip('X')
+
+		case c == 'Z':
+			if e.at(1) == 'H' {
+				e.add("J", "J")
+				e.pos += 2
+			} else {
+				e.add("S", "S")
+				e.skip('Z')
+			}
+
+		default:
+			e.pos++
+		}
+	}
+}
+
+// skip moves past the current letter and any which repeat its sound
+func (e *encoder) skip(same ...byte) {
+	c := e.at(0)
+	e.pos++
+	for {
+		next := e.at(0)
+		repeated := next == c
+		for i := range same {
+			repeated = repeated || next == same[i]
+		}
+		if !repeated || next == 0 {
+			return
+		}
+		e.pos++
+	}
+}
+
+func (e *encoder) encodeC() {
+	switch {
+	case e.next("CIA"):
+		e.add("X", "X")
+		e.pos += 3
+	case e.next("CH"):
+		// "CH" is a K in words of Greek and Germanic origin (e.g. "Christos", "Chaim")
+		if e.pos == 0 && (e.next("CHR", "CHL") || e.at(2) == 0) {
+			e.add("K", "K")
+		} else {
+			e.add("X", "K")
+		}
+		e.pos += 2
+	case e.next("CZ"):
+		e.add("S", "X")
+		e.pos += 2
+	case e.next("CE", "CI", "CY"):
+		e.add("S", "S")
+		e.pos += 2
+	case e.next("CK", "CQ", "CG"):
+		e.add("K", "K")
+		e.pos += 2
+	case e.next("CC") && (e.at(2) == 'E' || e.at(2) == 'I'):
+		// "Bacci" and "Accident"
+		e.add("KS", "X")
+		e.pos += 3
+	default:
+		e.add("K", "K")
+		e.skip('C', 'K', 'Q')
+	}
+}
+
+func (e *encoder) encodeG() {
+	switch {
+	case e.at(1) == 'H':
+		// A "GH" starting a word or following a consonant is hard (e.g. "Ghazi"), it's
+		// silent or an F after a vowel (e.g. "Hugh", "Laughlin")
+		if e.pos == 0 || !isVowel(e.at(-1)) {
+			e.add("K", "K")
+		} else {
+			e.add("", "F")
+		}
+		e.pos += 2
+	case e.at(1) == 'N':
+		e.add("N", "KN")
+		e.pos += 2
+	case e.at(1) == 'E' || e.at(1) == 'I' || e.at(1) == 'Y':
+		// Soft in most languages (e.g. "Gino", "Georgiy"), hard in Germanic ones (e.g. "Gerhard")
+		e.add("J", "K")
+		e.pos++
+	default:
+		e.add("K", "K")
+		e.skip('G')
+	}
+}
+
+func (e *encoder) encodeS() {
+	switch {
+	case e.next("SCH"):
+		// "Schmidt" and "Schlesinger" in German, "Schiavone" in Italian
+		e.add("X", "SK")
+		e.pos += 3
+	case e.next("SH"):
+		e.add("X", "X")
+		e.pos += 2
+	case e.next("SIO", "SIA"):
+		e.add("S", "X")
+		e.pos += 3
+	case e.next("SZ"):
+		e.add("S", "X")
+		e.pos += 2
+	case e.next("SC") && (e.at(2) == 'E' || e.at(2) == 'I' || e.at(2) == 'Y'):
+		e.add("S", "S")
+		e.pos += 3
+	default:
+		e.add("S", "S")
+		e.skip('S', 'Z')
+	}
+}
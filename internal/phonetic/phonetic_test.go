@@ -0,0 +1,66 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package phonetic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoubleMetaphone(t *testing.T) {
+	cases := []struct {
+		word               string
+		primary, alternate string
+	}{
+		{"Kaddafi", "KTF", "KTF"},
+		{"Qadhafi", "KTF", "KTF"},
+		{"Gaddafi", "KTF", "KTF"},
+		{"Mohammed", "MHMT", "MHMT"},
+		{"Muhammad", "MHMT", "MHMT"},
+		{"Hussein", "HSN", "HSN"},
+		{"Husayn", "HSN", "HSN"},
+		{"Michael", "MXL", "MKL"},
+		{"Christos", "KRST", "KRST"},
+		{"Schmidt", "XMT", "SKMT"},
+		{"Thompson", "0MPS", "TMPS"},
+		{"Knight", "NT", "NFT"},
+		{"Vladimir", "FLTM", "FLTM"},
+		{"Wladimir", "FLTM", "FLTM"},
+		{"Xavier", "SFR", "SFR"},
+		{"", "", ""},
+		{"Путин", "", ""},
+	}
+	for _, tc := range cases {
+		primary, alternate := DoubleMetaphone(tc.word)
+		require.Equal(t, tc.primary, primary, tc.word)
+		require.Equal(t, tc.alternate, alternate, tc.word)
+	}
+}
+
+func TestArabic(t *testing.T) {
+	same := [][]string{
+		{"Djamel", "Jamal"},
+		{"Othman", "Osman", "Uthman"},
+		{"Khaled", "Khalid", "Kaled"},
+		{"Cherif", "Sharif"},
+		{"Youssef", "Yusuf"},
+	}
+	for _, words := range same {
+		key, _ := Arabic(words[0])
+		for _, word := range words[1:] {
+			other, _ := Arabic(word)
+			require.Equal(t, key, other, "%s and %s", words[0], word)
+		}
+	}
+}
+
+func TestKeys(t *testing.T) {
+	require.Equal(t, []string{"KTF"}, Keys("qadhafi"))
+	require.Equal(t, []string{"TJML", "THML", "JML", "HML"}, Keys("Djamel"))
+	require.Contains(t, Keys("Othman"), "ASMN")
+	require.Empty(t, Keys("Путин"))
+	require.Empty(t, Keys("123"))
+}
@@ -0,0 +1,163 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package transliterate
+
+import (
+	"strings"
+)
+
+// arabicConvention romanizes Arabic script. Short vowels are rarely written, so unless the
+// text is vowelled one is inserted between consonants.
+type arabicConvention struct {
+	// letters are the romanizations which differ from arabicLetters
+	letters map[rune]string
+
+	// long vowels written with waw and ya
+	waw, ya string
+
+	// first is the vowel inserted after the first consonant, other is inserted after the rest
+	first, other string
+}
+
+// arabicConventions are ALA-LC without its diacritics, and the French based spelling used across
+// North Africa and the Levant (e.g. "Mohamed Abdelkader" rather than "Mahamad Abdalqadar").
+var arabicConventions = []func([]rune) string{
+	arabicConvention{
+		waw:   "u",
+		ya:    "i",
+		first: "a",
+		other: "a",
+	}.romanize,
+	arabicConvention{
+		letters: map[rune]string{'ج': "dj", 'ش': "ch", 'ق': "k", 'ث': "t", 'ذ': "d"},
+		waw:     "ou",
+		ya:      "i",
+		first:   "o",
+		other:   "e",
+	}.romanize,
+}
+
+// arabicLetters are the consonants of Arabic, along with the letters Persian and Urdu add
+var arabicLetters = map[rune]string{
+	'ب': "b", 'ت': "t", 'ث': "th", 'ج': "j", 'ح': "h", 'خ': "kh", 'د': "d", 'ذ': "dh", 'ر': "r",
+	'ز': "z", 'س': "s", 'ش': "sh", 'ص': "s", 'ض': "d", 'ط': "t", 'ظ': "z", 'ع': "", 'غ': "gh",
+	'ف': "f", 'ق': "q", 'ك': "k", 'ل': "l", 'م': "m", 'ن': "n", 'ه': "h", 'ء': "", 'ؤ': "",
+	'ئ': "",
+
+	// Persian and Urdu
+	'پ': "p", 'چ': "ch", 'ژ': "zh", 'گ': "g", 'ک': "k", 'ٹ': "t", 'ڈ': "d", 'ڑ': "r", 'ھ': "h",
+}
+
+const (
+	fatha  = 'َ'
+	damma  = 'ُ'
+	kasra  = 'ِ'
+	shadda = 'ّ'
+	sukun  = 'ْ'
+)
+
+// arabicSound is a romanized letter
+type arabicSound struct {
+	latin string
+	vowel bool
+
+	// doubled is set on the second of a consonant marked with shadda
+	doubled bool
+}
+
+func (c arabicConvention) romanize(run []rune) string {
+	var buf strings.Builder
+
+	// The article is written joined to the name (e.g. "الزواهري" for "al-Zawahiri")
+	if len(run) > 3 && run[0] == 'ا' && run[1] == 'ل' {
+		buf.WriteString("al-")
+		run = run[2:]
+	}
+
+	vowelled := false
+	for _, r := range run {
+		if r == fatha || r == damma || r == kasra {
+			vowelled = true
+		}
+	}
+
+	var sounds []arabicSound
+	lastVowel := func() bool {
+		return len(sounds) > 0 && sounds[len(sounds)-1].vowel
+	}
+	for i, r := range run {
+		next := rune(0)
+		if i+1 < len(run) {
+			next = run[i+1]
+		}
+		switch r {
+		case fatha:
+			sounds = append(sounds, arabicSound{latin: "a", vowel: true})
+		case damma:
+			sounds = append(sounds, arabicSound{latin: "u", vowel: true})
+		case kasra:
+			sounds = append(sounds, arabicSound{latin: "i", vowel: true})
+		case shadda:
+			// Consonants marked with shadda are doubled, the mark may follow a short vowel
+			n := len(sounds)
+			if n > 1 && i > 0 && (run[i-1] == fatha || run[i-1] == damma || run[i-1] == kasra) {
+				if !sounds[n-2].vowel {
+					doubled := arabicSound{latin: sounds[n-2].latin, doubled: true}
+					sounds = append(sounds[:n-1], doubled, sounds[n-1])
+				}
+			} else if n > 0 && !sounds[n-1].vowel {
+				sounds = append(sounds, arabicSound{latin: sounds[n-1].latin, doubled: true})
+			}
+		case 'ا', 'آ', 'ى', 'أ':
+			if !lastVowel() {
+				sounds = append(sounds, arabicSound{latin: "a", vowel: true})
+			}
+		case 'إ':
+			sounds = append(sounds, arabicSound{latin: "i", vowel: true})
+		case 'ة':
+			sounds = append(sounds, arabicSound{latin: "a", vowel: true})
+		case 'و':
+			if i == 0 || lastVowel() || next == 'ا' {
+				sounds = append(sounds, arabicSound{latin: "w"})
+			} else {
+				sounds = append(sounds, arabicSound{latin: c.waw, vowel: true})
+			}
+		case 'ي', 'ی':
+			if i == 0 || lastVowel() || next == 'ا' {
+				sounds = append(sounds, arabicSound{latin: "y"})
+			} else {
+				sounds = append(sounds, arabicSound{latin: c.ya, vowel: true})
+			}
+		default:
+			latin, ok := c.letters[r]
+			if !ok {
+				latin, ok = arabicLetters[r]
+			}
+			if ok {
+				sounds = append(sounds, arabicSound{latin: latin})
+			} else if r != sukun && r != 'ـ' && (r < 'ً' || r > 'ٟ') {
+				// Keep anything which isn't a letter, but drop tatweel and other marks
+				sounds = append(sounds, arabicSound{latin: string(r), vowel: true})
+			}
+		}
+	}
+
+	consonants := 0
+	for i := range sounds {
+		buf.WriteString(sounds[i].latin)
+		if sounds[i].vowel {
+			continue
+		}
+		consonants++
+		if !vowelled && i+1 < len(sounds) && !sounds[i+1].vowel && !sounds[i+1].doubled {
+			if consonants == 1 {
+				buf.WriteString(c.first)
+			} else {
+				buf.WriteString(c.other)
+			}
+		}
+	}
+	return buf.String()
+}
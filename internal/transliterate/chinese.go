@@ -0,0 +1,103 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package transliterate
+
+import (
+	"strings"
+)
+
+// chineseConventions are Hanyu Pinyin, which ALA-LC follows, with each syllable written
+// apart (e.g. "Xi Jin Ping") and with the given name written as one word (e.g. "Xi Jinping").
+// Characters with more than one reading use their second reading in the latter.
+var chineseConventions = []func([]rune) string{
+	func(run []rune) string {
+		return strings.Join(pinyin(run, 0), " ")
+	},
+	func(run []rune) string {
+		syllables := pinyin(run, 1)
+		if len(syllables) < 2 || len(syllables) > 3 {
+			return strings.Join(syllables, " ")
+		}
+		// Names are a one syllable surname followed by a given name of one or two syllables
+		return syllables[0] + " " + strings.Join(syllables[1:], "")
+	},
+}
+
+// pinyin returns the syllable of each character in run, using its reading at index when it has
+// more than one. Characters without a known reading are skipped.
+func pinyin(run []rune, index int) []string {
+	var out []string
+	for _, r := range run {
+		readings := strings.Split(hanzi[r], "/")
+		if readings[0] == "" {
+			continue
+		}
+		if index < len(readings) {
+			out = append(out, readings[index])
+		} else {
+			out = append(out, readings[len(readings)-1])
+		}
+	}
+	return out
+}
+
+// hanzi are the Pinyin readings, without tones, of common characters in Chinese surnames,
+// given names and company names in simplified and traditional script. Readings are separated
+// by a slash.
+var hanzi = map[rune]string{
+	// Surnames
+	'王': "wang", '李': "li", '张': "zhang", '張': "zhang", '刘': "liu", '劉': "liu", '陈': "chen",
+	'陳': "chen", '杨': "yang", '楊': "yang", '黄': "huang", '黃': "huang", '赵': "zhao", '趙': "zhao",
+	'吴': "wu", '吳': "wu", '周': "zhou", '徐': "xu", '孙': "sun", '孫': "sun", '马': "ma", '馬': "ma",
+	'朱': "zhu", '胡': "hu", '郭': "guo", '何': "he", '高': "gao", '林': "lin", '罗': "luo", '羅': "luo",
+	'郑': "zheng", '鄭': "zheng", '梁': "liang", '谢': "xie", '謝': "xie", '宋': "song", '唐': "tang",
+	'许': "xu", '許': "xu", '韩': "han", '韓': "han", '冯': "feng", '馮': "feng", '邓': "deng",
+	'鄧': "deng", '曹': "cao", '彭': "peng", '曾': "zeng", '肖': "xiao", '蕭': "xiao", '田': "tian",
+	'董': "dong", '袁': "yuan", '潘': "pan", '于': "yu", '蒋': "jiang", '蔣': "jiang", '蔡': "cai",
+	'余': "yu", '杜': "du", '叶': "ye", '葉': "ye", '程': "cheng", '苏': "su", '蘇': "su", '魏': "wei",
+	'吕': "lu", '呂': "lu", '丁': "ding", '任': "ren", '沈': "shen", '姚': "yao", '卢': "lu", '盧': "lu",
+	'姜': "jiang", '崔': "cui", '钟': "zhong", '鍾': "zhong", '谭': "tan", '譚': "tan", '陆': "lu",
+	'陸': "lu", '汪': "wang", '范': "fan", '金': "jin", '石': "shi", '廖': "liao", '贾': "jia",
+	'賈': "jia", '夏': "xia", '韦': "wei", '韋': "wei", '付': "fu", '方': "fang", '白': "bai",
+	'邹': "zou", '鄒': "zou", '孟': "meng", '熊': "xiong", '秦': "qin", '邱': "qiu", '江': "jiang",
+	'尹': "yin", '薛': "xue", '闫': "yan", '段': "duan", '雷': "lei", '侯': "hou", '龙': "long",
+	'龍': "long", '史': "shi", '陶': "tao", '黎': "li", '贺': "he", '賀': "he", '顾': "gu", '顧': "gu",
+	'毛': "mao", '郝': "hao", '龚': "gong", '龔': "gong", '邵': "shao", '万': "wan", '萬': "wan",
+	'钱': "qian", '錢': "qian", '严': "yan", '嚴': "yan", '武': "wu", '戴': "dai", '莫': "mo",
+	'孔': "kong", '向': "xiang", '汤': "tang", '湯': "tang", '习': "xi", '習': "xi", '温': "wen",
+	'溫': "wen", '薄': "bo", '令': "ling", '郁': "yu",
+
+	// Given names
+	'伟': "wei", '偉': "wei", '芳': "fang", '娜': "na", '敏': "min", '静': "jing", '靜': "jing",
+	'丽': "li", '麗': "li", '强': "qiang", '強': "qiang", '磊': "lei", '军': "jun", '軍': "jun",
+	'洋': "yang", '勇': "yong", '艳': "yan", '艷': "yan", '杰': "jie", '傑': "jie", '娟': "juan",
+	'涛': "tao", '濤': "tao", '明': "ming", '超': "chao", '秀': "xiu", '霞': "xia", '平': "ping",
+	'刚': "gang", '剛': "gang", '桂': "gui", '英': "ying", '华': "hua", '華': "hua", '建': "jian",
+	'文': "wen", '玉': "yu", '兰': "lan", '蘭': "lan", '红': "hong", '紅': "hong", '小': "xiao",
+	'海': "hai", '东': "dong", '東': "dong", '永': "yong", '生': "sheng", '成': "cheng", '志': "zhi",
+	'春': "chun", '德': "de", '新': "xin", '荣': "rong", '榮': "rong", '波': "bo", '辉': "hui",
+	'輝': "hui", '鹏': "peng", '鵬': "peng", '飞': "fei", '飛': "fei", '宇': "yu", '俊': "jun",
+	'斌': "bin", '光': "guang", '天': "tian", '安': "an", '云': "yun", '雲': "yun", '峰': "feng",
+	'民': "min", '正': "zheng", '学': "xue", '學': "xue", '忠': "zhong", '福': "fu", '庆': "qing",
+	'慶': "qing", '宏': "hong", '山': "shan", '清': "qing", '晓': "xiao", '曉': "xiao", '丹': "dan",
+	'燕': "yan", '萍': "ping", '琳': "lin", '雪': "xue", '梅': "mei", '慧': "hui", '婷': "ting",
+	'佳': "jia", '嘉': "jia", '思': "si", '一': "yi", '泽': "ze", '澤': "ze", '近': "jin", '克': "ke",
+	'立': "li", '家': "jia", '宝': "bao", '寶': "bao", '锦': "jin", '錦': "jin", '岐': "qi",
+	'熙': "xi", '来': "lai", '來': "lai", '计': "ji", '計': "ji", '划': "hua", '劃': "hua", '恩': "en",
+	'日': "ri", '泰': "tai", '亮': "liang", '良': "liang",
+
+	// Places and company names
+	'中': "zhong", '国': "guo", '國': "guo", '北': "bei", '京': "jing", '上': "shang", '南': "nan",
+	'西': "xi", '香': "xiang", '港': "gang", '深': "shen", '圳': "zhen", '广': "guang", '廣': "guang",
+	'州': "zhou", '有': "you", '限': "xian", '公': "gong", '司': "si", '集': "ji", '团': "tuan",
+	'團': "tuan", '股': "gu", '份': "fen", '科': "ke", '技': "ji", '贸': "mao", '貿': "mao", '易': "yi",
+	'电': "dian", '電': "dian", '子': "zi", '航': "hang", '空': "kong", '工': "gong", '业': "ye",
+	'業': "ye", '发': "fa", '發': "fa", '展': "zhan", '银': "yin", '銀': "yin", '行': "hang/xing",
+	'油': "you", '化': "hua", '船': "chuan", '舶': "bo", '运': "yun", '運': "yun",
+	'输': "shu", '輸': "shu", '能': "neng", '源': "yuan", '投': "tou", '资': "zi", '資': "zi",
+	'通': "tong", '信': "xin", '网': "wang", '網': "wang", '络': "luo", '絡': "luo", '器': "qi",
+	'材': "cai", '料': "liao", '研': "yan", '究': "jiu", '所': "suo", '院': "yuan", '大': "da",
+	'长': "chang/zhang", '長': "chang/zhang", '重': "zhong/chong",
+}
@@ -0,0 +1,64 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package transliterate
+
+import (
+	"strings"
+	"unicode"
+)
+
+// cyrillicConventions are BGN/PCGN, which is used by most English language sanctions lists,
+// ALA-LC and ISO 9 without its diacritics.
+var cyrillicConventions = []func([]rune) string{
+	cyrillic(map[rune]string{
+		'ё': "yo", 'ж': "zh", 'й': "y", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+		'ю': "yu", 'я': "ya", 'є': "ye", 'ї': "yi",
+	}, true),
+	cyrillic(map[rune]string{
+		'ё': "e", 'ж': "zh", 'й': "i", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+		'ю': "iu", 'я': "ia", 'є': "ie", 'ї': "i",
+	}, false),
+	cyrillic(map[rune]string{
+		'ё': "e", 'ж': "z", 'й': "j", 'х': "h", 'ц': "c", 'ч': "c", 'ш': "s", 'щ': "s",
+		'ю': "u", 'я': "a", 'є': "e", 'ї': "i",
+	}, false),
+}
+
+// cyrillicLetters are romanized the same under every convention
+var cyrillicLetters = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'з': "z", 'и': "i", 'к': "k",
+	'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e",
+
+	// Ukrainian and Belarusian
+	'і': "i", 'ґ': "g", 'ў': "u",
+
+	// Serbian and Macedonian
+	'ђ': "dj", 'ј': "j", 'љ': "lj", 'њ': "nj", 'ћ': "c", 'џ': "dz", 'ѓ': "gj", 'ќ': "kj", 'ѕ': "dz",
+}
+
+// cyrillic returns a convention which romanizes the letters of table, along with cyrillicLetters.
+// When iotated is set "е" is "ye" starting a word or following a vowel (e.g. "Yevgeniy", "Sergeyev").
+func cyrillic(table map[rune]string, iotated bool) func([]rune) string {
+	return func(run []rune) string {
+		var buf strings.Builder
+		for i, r := range run {
+			lower := unicode.ToLower(r)
+			latin, ok := table[lower]
+			if !ok {
+				latin, ok = cyrillicLetters[lower]
+			}
+			if !ok {
+				buf.WriteRune(r)
+				continue
+			}
+			if iotated && lower == 'е' && (i == 0 || strings.ContainsRune("аеёиоуыэюяъьєії", unicode.ToLower(run[i-1]))) {
+				latin = "ye"
+			}
+			buf.WriteString(withCase(latin, unicode.IsUpper(r)))
+		}
+		return buf.String()
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package transliterate
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// greekConventions are ELOT 743, which is used on Greek passports, ALA-LC and the phonetic
+// spelling common in informal writing (e.g. "Kiriakos" rather than "Kyriakos").
+var greekConventions = []func([]rune) string{
+	greek(map[string]string{
+		"ου": "ou", "αυ": "av", "ευ": "ev", "ηυ": "iv", "γγ": "ng", "γξ": "nx", "γχ": "nch",
+	}, map[rune]string{
+		'η': "i", 'υ': "y",
+	}),
+	greek(map[string]string{
+		"ου": "ou", "αυ": "au", "ευ": "eu", "ηυ": "eu", "γγ": "ng", "γκ": "nk", "γξ": "nx", "γχ": "nch",
+	}, map[rune]string{
+		'η': "e", 'υ': "y",
+	}),
+	greek(map[string]string{
+		"ου": "ou", "αυ": "af", "ευ": "ef", "ηυ": "if", "οι": "i", "ει": "i", "αι": "e", "γγ": "ng",
+		"γκ": "g", "μπ": "b", "ντ": "d",
+	}, map[rune]string{
+		'η': "i", 'υ': "i", 'χ': "h",
+	}),
+}
+
+// greekLetters are romanized the same under every convention
+var greekLetters = map[rune]string{
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'θ': "th", 'ι': "i", 'κ': "k",
+	'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s", 'ς': "s",
+	'τ': "t", 'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
+
+// greek returns a convention which romanizes the pairs of letters in digraphs and the letters of
+// table, along with greekLetters. Accents and breathings are removed first.
+func greek(digraphs map[string]string, table map[rune]string) func([]rune) string {
+	return func(run []rune) string {
+		run = stripMarks(run)
+
+		var buf strings.Builder
+		for i := 0; i < len(run); i++ {
+			upper := unicode.IsUpper(run[i])
+			if i+1 < len(run) {
+				pair := strings.ToLower(string(run[i : i+2]))
+				if latin, ok := digraphs[pair]; ok {
+					buf.WriteString(withCase(latin, upper))
+					i++
+					continue
+				}
+			}
+			lower := unicode.ToLower(run[i])
+			latin, ok := table[lower]
+			if !ok {
+				latin, ok = greekLetters[lower]
+			}
+			if !ok {
+				buf.WriteRune(run[i])
+				continue
+			}
+			buf.WriteString(withCase(latin, upper))
+		}
+		return buf.String()
+	}
+}
+
+// stripMarks removes accents and other combining marks (e.g. "ά" into "α")
+func stripMarks(run []rune) []rune {
+	var out []rune
+	for _, r := range norm.NFD.String(string(run)) {
+		if !unicode.Is(unicode.Mn, r) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
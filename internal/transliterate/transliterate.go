@@ -0,0 +1,113 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+// Package transliterate romanizes names written in Cyrillic, Greek, Arabic and Chinese script so
+// they can be compared with names written in Latin script.
+package transliterate
+
+import (
+	"strings"
+	"unicode"
+)
+
+// script romanizes one writing system under each of its conventions
+type script struct {
+	table *unicode.RangeTable
+
+	// conventions romanize a run of text written in the script, the first is the most common
+	conventions []func(run []rune) string
+}
+
+var scripts = []script{
+	{table: unicode.Cyrillic, conventions: cyrillicConventions},
+	{table: unicode.Greek, conventions: greekConventions},
+	{table: unicode.Arabic, conventions: arabicConventions},
+	{table: unicode.Han, conventions: chineseConventions},
+}
+
+// run is consecutive text written in one script, script is nil for text which is left as is
+type run struct {
+	script *script
+	text   []rune
+}
+
+// Romanize returns each distinct romanization of s, one for every convention of the scripts it's
+// written in (e.g. "Юрий" is "Yuriy" under BGN/PCGN, "Iurii" under ALA-LC and "Urij" under ISO 9).
+// Text which isn't written in a supported script is kept as is, so s is returned as the only
+// result when it's written in Latin script.
+func Romanize(s string) []string {
+	runs := split(s)
+
+	variants := 1
+	for i := range runs {
+		if runs[i].script != nil && len(runs[i].script.conventions) > variants {
+			variants = len(runs[i].script.conventions)
+		}
+	}
+
+	var out []string
+	for v := 0; v < variants; v++ {
+		var buf strings.Builder
+		for i := range runs {
+			if runs[i].script == nil {
+				buf.WriteString(string(runs[i].text))
+				continue
+			}
+			conventions := runs[i].script.conventions
+			if v < len(conventions) {
+				buf.WriteString(conventions[v](runs[i].text))
+			} else {
+				buf.WriteString(conventions[len(conventions)-1](runs[i].text))
+			}
+		}
+		if romanized := buf.String(); !contains(out, romanized) {
+			out = append(out, romanized)
+		}
+	}
+	return out
+}
+
+// split divides s into runs of each script
+func split(s string) []run {
+	var out []run
+	for _, r := range s {
+		sc := scriptOf(r)
+		// Combining marks (e.g. Arabic short vowels) belong to the letter before them
+		if n := len(out); n > 0 && (out[n-1].script == sc || unicode.Is(unicode.Mn, r)) {
+			out[n-1].text = append(out[n-1].text, r)
+			continue
+		}
+		out = append(out, run{script: sc, text: []rune{r}})
+	}
+	return out
+}
+
+func scriptOf(r rune) *script {
+	if r < unicode.MaxLatin1 {
+		return nil
+	}
+	for i := range scripts {
+		if unicode.Is(scripts[i].table, r) {
+			return &scripts[i]
+		}
+	}
+	return nil
+}
+
+func contains(xs []string, x string) bool {
+	for i := range xs {
+		if xs[i] == x {
+			return true
+		}
+	}
+	return false
+}
+
+// withCase capitalizes latin when upper is set, as romanizing "Щ" gives "Shch" rather than "SHCH"
+func withCase(latin string, upper bool) string {
+	if !upper || latin == "" {
+		return latin
+	}
+	return strings.ToUpper(latin[:1]) + latin[1:]
+}
@@ -0,0 +1,59 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package transliterate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRomanize(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected []string
+	}{
+		// Latin script is left alone
+		{"John Smith", []string{"John Smith"}},
+		{"José García", []string{"José García"}},
+		{"", []string{""}},
+
+		// Cyrillic
+		{"Владимир Путин", []string{"Vladimir Putin"}},
+		{"Юрий", []string{"Yuriy", "Iurii", "Urij"}},
+		{"Сергей Шойгу", []string{"Sergey Shoygu", "Sergei Shoigu", "Sergej Sojgu"}},
+		{"Олександр Зінченко", []string{"Oleksandr Zinchenko", "Oleksandr Zincenko"}},
+
+		// Greek
+		{"Νίκος Παππάς", []string{"Nikos Pappas"}},
+		{"Γεώργιος Ευαγγέλου", []string{"Georgios Evangelou", "Georgios Euangelou", "Georgios Efangelou"}},
+
+		// Arabic
+		{"أسامة بن لادن", []string{"asama ban ladan", "asama bon laden"}},
+		{"الزواهري", []string{"al-zawahari", "al-zowaheri"}},
+		{"عبد القادر", []string{"abad al-qadar", "obed al-kader"}},
+
+		// Chinese
+		{"习近平", []string{"xi jin ping", "xi jinping"}},
+		{"習近平", []string{"xi jin ping", "xi jinping"}},
+		{"中国银行", []string{"zhong guo yin hang", "zhong guo yin xing"}},
+
+		// Mixed scripts
+		{"Газпром Export LLC", []string{"Gazprom Export LLC"}},
+	}
+	for _, tc := range cases {
+		require.Equal(t, tc.expected, Romanize(tc.input), tc.input)
+	}
+}
+
+func TestRomanize__Vowelled(t *testing.T) {
+	// Short vowels and shadda are used when written
+	require.Equal(t, "muhammad", Romanize("مُحَمَّد")[0])
+}
+
+func TestRomanize__UnknownHan(t *testing.T) {
+	// Characters without a reading are dropped rather than kept in Han script
+	require.Equal(t, []string{"wang"}, Romanize("王龘"))
+}
@@ -0,0 +1,131 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package variants
+
+// defaultGroups are names which are equivalent to each other, one group per line. Each name is
+// a single word as names are compared word by word.
+var defaultGroups = []string{
+	// Arabic
+	"mohammed,muhammad,mohamed,mohammad,mohamad,muhammed,mohamud,mohammet,mehmet,mehmed,muhamad,mahomed",
+	"ahmed,ahmad,ahmet,ahmat",
+	"mahmoud,mahmud,mahmood,mehmood",
+	"hussein,husein,hussain,husain,husayn,hossein,huseyin",
+	"hassan,hasan,hasson",
+	"hasna,hasnaa",
+	"osama,usama,oussama,usamah",
+	"omar,umar,omer",
+	"othman,osman,uthman,usman,othmane",
+	"abdullah,abdallah,abdulla,abdellah",
+	"abdulrahman,abdelrahman,abdurrahman,abderrahmane",
+	"abdelkader,abdulkadir,abdulqadir,abdelqader",
+	"youssef,yousef,yusuf,yousif,youssouf,yusef,joseph,josef,giuseppe,jose",
+	"ibrahim,ebrahim,ibrahima,abraham",
+	"ismail,ismael,ismaeel,esmail",
+	"suleiman,sulaiman,sulayman,soliman,suleyman,solomon",
+	"khaled,khalid,khaleed",
+	"walid,waleed,oualid",
+	"said,saeed,saied,sayed,sayyid,seyed",
+	"saleh,salih,salah",
+	"jamal,djamel,gamal,jamel,cemal",
+	"jamil,djamil,gamil,jameel,cemil",
+	"hamid,hamed,hameed",
+	"rashid,rachid,rasheed,reshid",
+	"karim,kareem,kerim",
+	"mustafa,mustapha,moustafa,mostafa",
+	"yasser,yasir,yaser",
+	"ali,aly",
+	"fatima,fatimah,fatma,fatema",
+	"aisha,aicha,ayesha,aysha,ayse",
+	"qaddafi,gaddafi,kaddafi,qadhafi,gadhafi,kadhafi",
+	"zawahiri,zawahri",
+	"ladin,laden",
+	"bin,ben,ibn",
+
+	// Slavic
+	"aleksandr,alexander,alexandr,aleksander,alexandre,oleksandr,aleksandar,alessandro,alejandro",
+	"aleksey,alexey,alexei,aleksei,oleksiy,oleksii,alexis",
+	"sergey,sergei,serhiy,serhii,sergiy,siarhei",
+	"dmitry,dmitri,dmitriy,dmytro,dimitri,dimitry",
+	"yuri,yuriy,yury,iurii,jurij,yurii",
+	"mikhail,michael,mykhailo,mihail,mikhael,michal,miguel,michel,michele",
+	"nikolai,nikolay,mykola,nicolas,nicholas,nikola,nicola,nikolaos",
+	"vladimir,wladimir,volodymyr,uladzimir",
+	"evgeny,evgeniy,yevgeny,yevgeniy,yevhen,eugene,evgenii",
+	"andrey,andrei,andriy,andrii,andrew,andreas,andres,andre",
+	"pavel,paul,pavlo,paolo,pablo,pawel",
+	"petr,pyotr,petro,peter,pietro,pedro,pierre,piotr",
+	"ivan,john,johann,juan,jan,giovanni,jean,ioann",
+	"igor,ihor",
+	"oleg,oleh",
+	"konstantin,kostiantyn,constantine,konstantinos",
+	"viktor,victor,wiktor",
+	"vasily,vasiliy,vasyl,vassily,vasili",
+	"grigory,grigoriy,hryhoriy,gregory,grigori",
+	"yakov,jacob,yaakov,jakub,jakob,iakov,yakiv,jacques",
+	"ilya,ilia,illia,elijah,elias",
+	"anatoly,anatoliy,anatolii",
+	"arkady,arkadiy,arkadii",
+	"gennady,gennadiy,hennadiy,gennadii",
+	"yelena,elena,olena,helen,helena,helene",
+	"ekaterina,yekaterina,kateryna,catherine,katherine,katharina,caterina,kate,katie,kathy",
+	"natalia,natalya,nataliya,natalie",
+	"tatiana,tatyana,tetiana,tatjana",
+	"olga,olha",
+	"maria,mariya,marie,mary,marija",
+	"anna,hanna,ann,anne",
+
+	// English nicknames
+	"william,bill,billy,will,willy,liam,guillermo,guillaume,wilhelm",
+	"robert,bob,bobby,rob,robbie",
+	"richard,rick,ricky,dick,rich",
+	"james,jim,jimmy,jamie",
+	"thomas,tom,tommy",
+	"charles,charlie,chuck,carlos,karl,carl",
+	"edward,ed,eddie,ted,teddy,eduardo",
+	"elizabeth,liz,beth,betty,eliza,lisa,isabel,isabella",
+	"margaret,maggie,peggy,meg,marge",
+	"jennifer,jen,jenny",
+	"daniel,dan,danny",
+	"david,dave,davy",
+	"joshua,josh",
+	"matthew,matt,mateo,matteo",
+	"christopher,chris",
+	"anthony,tony,antonio,anton,antoine",
+	"benjamin,benny",
+	"samuel,sam,sammy",
+	"steven,stephen,steve,stefan,esteban,stepan",
+	"timothy,tim",
+	"patrick,pat,paddy",
+	"francis,frank,francisco,francesco,franz,frantisek",
+	"henry,harry,hank,enrique,heinrich,henri",
+	"lawrence,larry,laurence",
+	"susan,sue,susie",
+	"deborah,debbie,debra",
+	"patricia,patty,trish",
+	"alfred,alf",
+	"frederick,freddie,fritz",
+	"gerald,gerry,jerry",
+	"donald,don,donnie",
+	"ronald,ron,ronnie",
+	"kenneth,ken,kenny",
+	"raymond,ray,ramon",
+	"leonard,leo,len",
+	"nathan,nate,nathaniel",
+	"zachary,zach,zack",
+	"gabriel,gabe,gabriele",
+	"manuel,manny,emmanuel,immanuel",
+	"luis,louis,lewis,ludwig,luigi",
+
+	// Chinese surnames written under Wade-Giles, Cantonese and Pinyin
+	"zhang,chang,cheung",
+	"chen,chan,chin",
+	"huang,hwang,wong",
+	"zhou,chou,chow",
+	"zhao,chao,chiu",
+	"xu,hsu",
+	"liu,lau,lew",
+	"li,lee",
+	"wu,woo,ng",
+}
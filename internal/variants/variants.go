@@ -0,0 +1,182 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+// Package variants holds groups of names which compliance treats as the same name, such as
+// spellings of one name (e.g. "Mohammed" and "Muhammad") and nicknames (e.g. "Bill" and "William").
+package variants
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Dictionary maps each name to the group of names it's equivalent to. Names are compared
+// without regard to case.
+type Dictionary struct {
+	mu sync.RWMutex
+
+	// groups holds the id of the group each name belongs to, and members the names of each group
+	groups  map[string]int
+	members map[int][]string
+	next    int
+}
+
+// New returns an empty Dictionary
+func New() *Dictionary {
+	return &Dictionary{
+		groups:  make(map[string]int),
+		members: make(map[int][]string),
+	}
+}
+
+// Default returns a Dictionary of common variants of given names across English, Arabic,
+// Slavic and other naming traditions.
+func Default() *Dictionary {
+	d := New()
+	for i := range defaultGroups {
+		d.Add(strings.Split(defaultGroups[i], ",")...)
+	}
+	return d
+}
+
+// Add makes each of names equivalent to the others. Groups which already hold one of names are
+// merged, so adding "Bill, Billy" after "William, Bill" makes all three equivalent.
+func (d *Dictionary) Add(names ...string) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	group := -1
+	for i := range names {
+		names[i] = normalize(names[i])
+		if id, ok := d.groups[names[i]]; ok && group < 0 {
+			group = id
+		}
+	}
+	if group < 0 {
+		group = d.next
+		d.next++
+	}
+	for i := range names {
+		if names[i] == "" {
+			continue
+		}
+		id, ok := d.groups[names[i]]
+		switch {
+		case !ok:
+			d.groups[names[i]] = group
+			d.members[group] = append(d.members[group], names[i])
+
+		case id != group:
+			// Merge the group this name was in
+			for _, name := range d.members[id] {
+				d.groups[name] = group
+			}
+			d.members[group] = append(d.members[group], d.members[id]...)
+			delete(d.members, id)
+		}
+	}
+}
+
+// Equivalent returns true when a and b are different names of the same group
+func (d *Dictionary) Equivalent(a, b string) bool {
+	if d == nil {
+		return false
+	}
+	a, b = normalize(a), normalize(b)
+	if a == b {
+		return false
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	ga, ok := d.groups[a]
+	if !ok {
+		return false
+	}
+	gb, ok := d.groups[b]
+	return ok && ga == gb
+}
+
+// Variants returns the other names of the group name belongs to
+func (d *Dictionary) Variants(name string) []string {
+	if d == nil {
+		return nil
+	}
+	name = normalize(name)
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	group, ok := d.groups[name]
+	if !ok {
+		return nil
+	}
+	out := make([]string, 0, len(d.members[group])-1)
+	for _, member := range d.members[group] {
+		if member != name {
+			out = append(out, member)
+		}
+	}
+	return out
+}
+
+// Len returns how many names are held
+func (d *Dictionary) Len() int {
+	if d == nil {
+		return 0
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return len(d.groups)
+}
+
+// Read adds a group from each line of r. Names on a line are separated by commas, blank lines
+// and lines starting with '#' are skipped.
+//
+//	# Nicknames
+//	William, Bill, Billy, Will
+//	Robert, Bob, Rob
+func (d *Dictionary) Read(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		names := strings.Split(text, ",")
+		if len(names) < 2 {
+			return fmt.Errorf("line %d: a group needs two or more names", line)
+		}
+		d.Add(names...)
+	}
+	return scanner.Err()
+}
+
+// ReadFile adds the groups of the file at path, see Read for its format
+func (d *Dictionary) ReadFile(path string) error {
+	fd, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening name variants: %v", err)
+	}
+	defer fd.Close()
+
+	if err := d.Read(fd); err != nil {
+		return fmt.Errorf("reading %s: %v", path, err)
+	}
+	return nil
+}
+
+func normalize(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
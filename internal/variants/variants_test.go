@@ -0,0 +1,114 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package variants
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefault(t *testing.T) {
+	d := Default()
+	require.Greater(t, d.Len(), 100)
+
+	cases := []struct {
+		a, b     string
+		expected bool
+	}{
+		{"Mohammed", "muhammad", true},
+		{"mohamad", "MOHAMED", true},
+		{"aleksandr", "alexander", true},
+		{"bill", "william", true},
+		{"guillermo", "bill", true},
+		{"yuriy", "iurii", true},
+		{"bill", "robert", false},
+		{"john", "john", false}, // the same name isn't a variant
+		{"john", "smith", false},
+		{"", "", false},
+	}
+	for _, tc := range cases {
+		require.Equal(t, tc.expected, d.Equivalent(tc.a, tc.b), "%s / %s", tc.a, tc.b)
+	}
+}
+
+func TestDefault__Words(t *testing.T) {
+	for i := range defaultGroups {
+		for _, name := range strings.Split(defaultGroups[i], ",") {
+			require.Equal(t, normalize(name), name)
+			require.NotContains(t, name, " ", "names are compared word by word")
+		}
+	}
+}
+
+func TestDictionary__Merge(t *testing.T) {
+	d := New()
+	d.Add("Robert", "Bob")
+	d.Add("Rob", "Robbie")
+	require.False(t, d.Equivalent("bob", "rob"))
+
+	d.Add("robbie", "bob")
+	require.True(t, d.Equivalent("robert", "rob"))
+	require.True(t, d.Equivalent("bob", "robbie"))
+	require.Equal(t, 4, d.Len())
+
+	require.ElementsMatch(t, []string{"robert", "rob", "robbie"}, d.Variants("Bob"))
+	require.Empty(t, d.Variants("john"))
+}
+
+func TestDictionary__Variants(t *testing.T) {
+	d := Default()
+	require.Contains(t, d.Variants("mohammed"), "muhammad")
+	require.NotContains(t, d.Variants("mohammed"), "mohammed")
+
+	// Adding a name twice doesn't repeat it
+	d.Add("william", "bill")
+	seen := make(map[string]bool)
+	for _, name := range d.Variants("bill") {
+		require.NotEqual(t, "bill", name)
+		require.False(t, seen[name], name)
+		seen[name] = true
+	}
+	require.True(t, seen["william"])
+}
+
+func TestDictionary__Nil(t *testing.T) {
+	var d *Dictionary
+	d.Add("a", "b")
+	require.False(t, d.Equivalent("a", "b"))
+	require.Empty(t, d.Variants("a"))
+	require.Equal(t, 0, d.Len())
+}
+
+func TestDictionary__Read(t *testing.T) {
+	d := New()
+	err := d.Read(strings.NewReader(`
+# Nicknames
+Margaret, Maggie, Peggy
+
+Theodore,Ted
+`))
+	require.NoError(t, err)
+	require.True(t, d.Equivalent("peggy", "margaret"))
+	require.True(t, d.Equivalent("ted", "theodore"))
+
+	err = d.Read(strings.NewReader("William\n"))
+	require.ErrorContains(t, err, "line 1")
+}
+
+func TestDictionary__ReadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "variants.txt")
+	require.NoError(t, os.WriteFile(path, []byte("Bill, Guillaume\nIvanovich, Ivanovitch\n"), 0600))
+
+	d := Default()
+	require.NoError(t, d.ReadFile(path))
+	require.True(t, d.Equivalent("ivanovich", "ivanovitch"))
+	require.True(t, d.Equivalent("william", "guillaume"))
+
+	require.Error(t, d.ReadFile(filepath.Join(t.TempDir(), "missing.txt")))
+}
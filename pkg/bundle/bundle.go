@@ -0,0 +1,241 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+// Package bundle packages list files for hosts without network access. A bundle is a
+// directory holding the files along with a manifest of their SHA-256 checksums and an
+// Ed25519 signature over the manifest.
+package bundle
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	ManifestFilename  = "manifest.json"
+	SignatureFilename = "manifest.json.sig"
+)
+
+// Manifest lists every file in a bundle
+type Manifest struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Files     []File    `json:"files"`
+}
+
+// File is one list file in a bundle
+type File struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Create copies files into dir and writes a manifest of them signed with key.
+func Create(dir string, files []string, key ed25519.PrivateKey) (*Manifest, error) {
+	if len(files) == 0 {
+		return nil, errors.New("bundle: no files")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("bundle: %v", err)
+	}
+
+	manifest := &Manifest{
+		CreatedAt: time.Now().UTC(),
+	}
+	for i := range files {
+		name := filepath.Base(files[i])
+		if name == ManifestFilename || name == SignatureFilename {
+			continue
+		}
+		size, checksum, err := copyFile(files[i], filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("bundle: copying %s: %v", name, err)
+		}
+		manifest.Files = append(manifest.Files, File{
+			Name:   name,
+			Size:   size,
+			SHA256: checksum,
+		})
+	}
+	sort.Slice(manifest.Files, func(i, j int) bool {
+		return manifest.Files[i].Name < manifest.Files[j].Name
+	})
+
+	bs, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("bundle: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ManifestFilename), bs, 0644); err != nil {
+		return nil, fmt.Errorf("bundle: writing manifest: %v", err)
+	}
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(key, bs))
+	if err := os.WriteFile(filepath.Join(dir, SignatureFilename), []byte(signature+"\n"), 0644); err != nil {
+		return nil, fmt.Errorf("bundle: writing signature: %v", err)
+	}
+	return manifest, nil
+}
+
+// Verify checks the manifest in dir was signed by key and that every file it lists is present
+// with the expected size and checksum. The paths of those files are returned, files in dir which
+// aren't listed in the manifest are never returned.
+func Verify(dir string, key ed25519.PublicKey) ([]string, error) {
+	bs, err := os.ReadFile(filepath.Join(dir, ManifestFilename))
+	if err != nil {
+		return nil, fmt.Errorf("bundle: reading manifest: %v", err)
+	}
+	sig, err := os.ReadFile(filepath.Join(dir, SignatureFilename))
+	if err != nil {
+		return nil, fmt.Errorf("bundle: reading signature: %v", err)
+	}
+	signature, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(sig)))
+	if err != nil {
+		return nil, fmt.Errorf("bundle: decoding signature: %v", err)
+	}
+	if !ed25519.Verify(key, bs, signature) {
+		return nil, errors.New("bundle: manifest signature is invalid")
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(bs, &manifest); err != nil {
+		return nil, fmt.Errorf("bundle: reading manifest: %v", err)
+	}
+	if len(manifest.Files) == 0 {
+		return nil, errors.New("bundle: manifest lists no files")
+	}
+
+	var out []string
+	for _, file := range manifest.Files {
+		if file.Name != filepath.Base(file.Name) {
+			return nil, fmt.Errorf("bundle: invalid filename %q", file.Name)
+		}
+		path := filepath.Join(dir, file.Name)
+		size, checksum, err := fileChecksum(path)
+		if err != nil {
+			return nil, fmt.Errorf("bundle: %s: %v", file.Name, err)
+		}
+		if size != file.Size || checksum != file.SHA256 {
+			return nil, fmt.Errorf("bundle: %s: checksum mismatch", file.Name)
+		}
+		out = append(out, path)
+	}
+	return out, nil
+}
+
+// GenerateKey creates an Ed25519 key pair and writes them PEM encoded to privatePath and publicPath.
+func GenerateKey(privatePath, publicPath string) error {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+	privBytes, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return err
+	}
+	pubBytes, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privBytes}), 0600); err != nil {
+		return err
+	}
+	return os.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubBytes}), 0644)
+}
+
+// ReadPrivateKey reads a PEM encoded (PKCS #8) Ed25519 private key, such as those created
+// with GenerateKey or `openssl genpkey -algorithm ed25519`.
+func ReadPrivateKey(path string) (ed25519.PrivateKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing private key: %v", err)
+	}
+	priv, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("unexpected %T private key, expected Ed25519", key)
+	}
+	return priv, nil
+}
+
+// ReadPublicKey reads a PEM encoded (PKIX) Ed25519 public key.
+func ReadPublicKey(path string) (ed25519.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing public key: %v", err)
+	}
+	pub, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unexpected %T public key, expected Ed25519", key)
+	}
+	return pub, nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(bs)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", path)
+	}
+	return block, nil
+}
+
+func fileChecksum(path string) (int64, string, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return 0, "", err
+	}
+	defer fd.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(h, fd)
+	if err != nil {
+		return 0, "", err
+	}
+	return n, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func copyFile(src, dst string) (int64, string, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return 0, "", err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return 0, "", err
+	}
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(out, h), in)
+	if err != nil {
+		out.Close()
+		return 0, "", err
+	}
+	if err := out.Close(); err != nil {
+		return 0, "", err
+	}
+	return n, hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package bundle
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testKeys(t *testing.T) (ed25519.PublicKey, ed25519.PrivateKey) {
+	t.Helper()
+
+	dir := t.TempDir()
+	priv, pub := filepath.Join(dir, "bundle.key"), filepath.Join(dir, "bundle.pub")
+	require.NoError(t, GenerateKey(priv, pub))
+
+	privateKey, err := ReadPrivateKey(priv)
+	require.NoError(t, err)
+	publicKey, err := ReadPublicKey(pub)
+	require.NoError(t, err)
+
+	return publicKey, privateKey
+}
+
+func testBundle(t *testing.T, key ed25519.PrivateKey) string {
+	t.Helper()
+
+	src := filepath.Join("..", "..", "test", "testdata")
+	dir := t.TempDir()
+	manifest, err := Create(dir, []string{
+		filepath.Join(src, "sdn.csv"),
+		filepath.Join(src, "add.csv"),
+	}, key)
+	require.NoError(t, err)
+	require.Len(t, manifest.Files, 2)
+	require.Equal(t, "add.csv", manifest.Files[0].Name)
+
+	return dir
+}
+
+func TestBundle(t *testing.T) {
+	pub, priv := testKeys(t)
+	dir := testBundle(t, priv)
+
+	files, err := Verify(dir, pub)
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(dir, "add.csv"), filepath.Join(dir, "sdn.csv")}, files)
+
+	// files outside the manifest are never returned
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "alt.csv"), []byte("a,b\n"), 0600))
+	files, err = Verify(dir, pub)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+}
+
+func TestBundle__tampered(t *testing.T) {
+	pub, priv := testKeys(t)
+
+	t.Run("file", func(t *testing.T) {
+		dir := testBundle(t, priv)
+		fd, err := os.OpenFile(filepath.Join(dir, "sdn.csv"), os.O_APPEND|os.O_WRONLY, 0600)
+		require.NoError(t, err)
+		fd.WriteString("1,\"EVIL\"\n")
+		require.NoError(t, fd.Close())
+
+		_, err = Verify(dir, pub)
+		require.ErrorContains(t, err, "sdn.csv: checksum mismatch")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		dir := testBundle(t, priv)
+		require.NoError(t, os.Remove(filepath.Join(dir, "add.csv")))
+
+		_, err := Verify(dir, pub)
+		require.ErrorContains(t, err, "add.csv")
+	})
+
+	t.Run("manifest", func(t *testing.T) {
+		dir := testBundle(t, priv)
+		path := filepath.Join(dir, ManifestFilename)
+		bs, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, append(bs, ' '), 0600))
+
+		_, err = Verify(dir, pub)
+		require.ErrorContains(t, err, "signature is invalid")
+	})
+
+	t.Run("other key", func(t *testing.T) {
+		dir := testBundle(t, priv)
+		other, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		_, err = Verify(dir, other)
+		require.ErrorContains(t, err, "signature is invalid")
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		dir := testBundle(t, priv)
+		require.NoError(t, os.Remove(filepath.Join(dir, SignatureFilename)))
+
+		_, err := Verify(dir, pub)
+		require.ErrorContains(t, err, "reading signature")
+	})
+}
+
+func TestReadKey__invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(path, []byte("not a key"), 0600))
+
+	_, err := ReadPublicKey(path)
+	require.ErrorContains(t, err, "no PEM data")
+	_, err = ReadPrivateKey(path)
+	require.ErrorContains(t, err, "no PEM data")
+}
@@ -6,15 +6,18 @@ package csl
 
 // CSL contains each record from the Consolidate Screening List, broken down by the record's original source
 type CSL struct {
-	ELs  []*EL  // Entity List – Bureau of Industry and Security
-	MEUs []*MEU // Military End User List
-	SSIs []*SSI // Sectoral Sanctions Identifications List (SSI) - Treasury Department
+	ELs    []*EL    // Entity List – Bureau of Industry and Security
+	MEUs   []*MEU   // Military End User List
+	SSIs   []*SSI   // Sectoral Sanctions Identifications List (SSI) - Treasury Department
+	UVLs   []*UVL   // Unverified List – Bureau of Industry and Security
+	ISNs   []*ISN   // Nonproliferation Sanctions (ISN) - State Department
+	FSEs   []*FSE   // Foreign Sanctions Evaders (FSE) - Treasury Department
+	PLCs   []*PLC   // Palestinian Legislative Council List (PLC) - Treasury Department
+	CAPs   []*CAP   // CAPTA (formerly Foreign Financial Institutions Subject to Part 561 - Treasury Department)
+	NSMBSs []*NSMBS // Non-SDN Menu-Based Sanctions List (NS-MBS List) - Treasury Department
+	CMICs  []*CMIC  // Non-SDN Chinese Military-Industrial Complex Companies List (CMIC) - Treasury Department
+	DTCs   []*DTC   // ITAR Debarred (DTC) - State Department
 
-	// []*UL (Unverified List – Bureau of Industry and Security)
-	// []*PSE (Foreign Sanctions Evaders (FSE) - Treasury Department)
-	// []*ISN (Nonproliferation Sanctions (ISN) - State Department)
-	// []*PLC (Palestinian Legislative Council List (PLC) - Treasury Department)
-	// []*CAPTA (CAPTA (formerly Foreign Financial Institutions Subject to Part 561 - Treasury Department))
 	// []*ADL (AECA Debarred List - State Department)
 }
 
@@ -55,33 +58,86 @@ const (
 type EL struct {
 	// ID is the unique identifier for the entity
 	ID string `json:"id"`
+	// Type is the entity type (e.g. individual, entity)
+	Type string `json:"type"`
+	// Programs is the list of sanctions program for which the entity is flagged
+	Programs []string `json:"programs"`
 	// Name is the primary name of the entity
 	Name string `json:"name"`
+	// Title is the individual's title or position
+	Title string `json:"title"`
 	// AlternateNames is a list of aliases associated with the entity
 	AlternateNames []string `json:"alternateNames"`
 	// Addresses is a list of known addresses associated with the entity
 	Addresses []string `json:"addresses"`
 	// StartDate is the effective date
 	StartDate string `json:"startDate"`
+	// EndDate is the date the listing expires, if ever
+	EndDate string `json:"endDate"`
+	// StandardOrder denotes whether the entity was added by a "standard" order
+	StandardOrder string `json:"standardOrder"`
 	// LicenseRequirement specifies the license requirements that it imposes on each listed person
 	LicenseRequirement string `json:"licenseRequirement"`
 	// LicensePolicy is the policy with which BIS reviews the requirements set forth in License Requirements
 	LicensePolicy string `json:"licensePolicy"`
 	// FRNotice identifies the notice in the Federal Register
 	FRNotice string `json:"FRNotice"`
+	// Remarks is used to provide additional details for the entity
+	Remarks []string `json:"remarks"`
+	// Citizenships is a list of countries the individual is a citizen of
+	Citizenships []string `json:"citizenships"`
+	// DatesOfBirth is a list of known dates of birth of the individual
+	DatesOfBirth []string `json:"datesOfBirth"`
+	// Nationalities is a list of the individual's nationalities
+	Nationalities []string `json:"nationalities"`
+	// PlacesOfBirth is a list of known places of birth of the individual
+	PlacesOfBirth []string `json:"placesOfBirth"`
+	// IDs is a list of the forms of identification on file for the entity
+	IDs []string `json:"ids"`
 	// SourceListURL is a link to the official SSI list
 	SourceListURL string `json:"sourceListURL"`
 	// SourceInfoURL is a link to information about the list
 	SourceInfoURL string `json:"sourceInfoURL"`
 }
 
+// MEU is the Military End User (MEU) List - Bureau of Industry and Security
 type MEU struct {
-	EntityID  string `json:"entityID"`
-	Name      string `json:"name"`
-	Addresses string `json:"addresses"`
-	FRNotice  string `json:"FRNotice"`
-	StartDate string `json:"startDate"`
-	EndDate   string `json:"endDate"`
+	EntityID string `json:"entityID"`
+	// Type is the entity type (e.g. individual, entity)
+	Type string `json:"type"`
+	// Programs is the list of sanctions program for which the entity is flagged
+	Programs []string `json:"programs"`
+	Name     string   `json:"name"`
+	// Title is the individual's title or position
+	Title string `json:"title"`
+	// AlternateNames is a list of aliases associated with the entity
+	AlternateNames []string `json:"alternateNames"`
+	Addresses      string   `json:"addresses"`
+	FRNotice       string   `json:"FRNotice"`
+	StartDate      string   `json:"startDate"`
+	EndDate        string   `json:"endDate"`
+	// StandardOrder denotes whether the entity was added by a "standard" order
+	StandardOrder string `json:"standardOrder"`
+	// LicenseRequirement specifies the license requirements that it imposes on each listed person
+	LicenseRequirement string `json:"licenseRequirement"`
+	// LicensePolicy is the policy with which BIS reviews the requirements set forth in License Requirements
+	LicensePolicy string `json:"licensePolicy"`
+	// Remarks is used to provide additional details for the entity
+	Remarks []string `json:"remarks"`
+	// Citizenships is a list of countries the individual is a citizen of
+	Citizenships []string `json:"citizenships"`
+	// DatesOfBirth is a list of known dates of birth of the individual
+	DatesOfBirth []string `json:"datesOfBirth"`
+	// Nationalities is a list of the individual's nationalities
+	Nationalities []string `json:"nationalities"`
+	// PlacesOfBirth is a list of known places of birth of the individual
+	PlacesOfBirth []string `json:"placesOfBirth"`
+	// IDs is a list of the forms of identification on file for the entity
+	IDs []string `json:"ids"`
+	// SourceListURL is a link to the official MEU list
+	SourceListURL string `json:"sourceListURL"`
+	// SourceInfoURL is a link to information about the list
+	SourceInfoURL string `json:"sourceInfoURL"`
 }
 
 // SSI is the Sectoral Sanctions Identifications List - Treasury Department
@@ -94,16 +150,238 @@ type SSI struct {
 	Programs []string `json:"programs"`
 	// Name is the entity's name (e.g. given name for individual, company name, etc.)
 	Name string `json:"name"`
+	// Title is the individual's title or position
+	Title string `json:"title"`
 	// Addresses is a list of known addresses associated with the entity
 	Addresses []string `json:"addresses"`
+	// FRNotice identifies the notice in the Federal Register
+	FRNotice string `json:"FRNotice"`
+	// StartDate is the effective date
+	StartDate string `json:"startDate"`
+	// EndDate is the date the listing expires, if ever
+	EndDate string `json:"endDate"`
 	// Remarks is used to provide additional details for the entity
 	Remarks []string `json:"remarks"`
 	// AlternateNames is a list of aliases associated with the entity
 	AlternateNames []string `json:"alternateNames"`
+	// Citizenships is a list of countries the individual is a citizen of
+	Citizenships []string `json:"citizenships"`
+	// DatesOfBirth is a list of known dates of birth of the individual
+	DatesOfBirth []string `json:"datesOfBirth"`
+	// Nationalities is a list of the individual's nationalities
+	Nationalities []string `json:"nationalities"`
+	// PlacesOfBirth is a list of known places of birth of the individual
+	PlacesOfBirth []string `json:"placesOfBirth"`
 	// IDsOnRecord is a list of the forms of identification on file for the entity
 	IDsOnRecord []string `json:"ids"`
+	// CallSign, VesselType, GrossTonnage, GrossRegisteredTonnage, VesselFlag and VesselOwner
+	// describe vessels
+	CallSign               string `json:"callSign"`
+	VesselType             string `json:"vesselType"`
+	GrossTonnage           string `json:"grossTonnage"`
+	GrossRegisteredTonnage string `json:"grossRegisteredTonnage"`
+	VesselFlag             string `json:"vesselFlag"`
+	VesselOwner            string `json:"vesselOwner"`
 	// SourceListURL is a link to the official SSI list
 	SourceListURL string `json:"sourceListURL"`
 	// SourceInfoURL is a link to information about the list
 	SourceInfoURL string `json:"sourceInfoURL"`
 }
+
+// UVL is the Unverified List - Bureau of Industry and Security
+type UVL struct {
+	// EntityID is the unique identifier for the entity
+	EntityID string `json:"entityID"`
+	// Name is the primary name of the entity
+	Name string `json:"name"`
+	// AlternateNames is a list of aliases associated with the entity
+	AlternateNames []string `json:"alternateNames"`
+	// Addresses is a list of known addresses associated with the entity
+	Addresses []string `json:"addresses"`
+	// SourceListURL is a link to the official UVL list
+	SourceListURL string `json:"sourceListURL"`
+	// SourceInfoURL is a link to information about the list
+	SourceInfoURL string `json:"sourceInfoURL"`
+}
+
+// ISN is the Nonproliferation Sanctions list - State Department
+type ISN struct {
+	// EntityID is the unique identifier for the entity
+	EntityID string `json:"entityID"`
+	// Programs is the list of sanctions program for which the entity is flagged
+	Programs []string `json:"programs"`
+	// Name is the primary name of the entity
+	Name string `json:"name"`
+	// AlternateNames is a list of aliases associated with the entity
+	AlternateNames []string `json:"alternateNames"`
+	// FRNotice identifies the notice in the Federal Register
+	FRNotice string `json:"FRNotice"`
+	// StartDate is the effective date
+	StartDate string `json:"startDate"`
+	// Remarks is used to provide additional details for the entity
+	Remarks []string `json:"remarks"`
+	// SourceListURL is a link to the official ISN list
+	SourceListURL string `json:"sourceListURL"`
+	// SourceInfoURL is a link to information about the list
+	SourceInfoURL string `json:"sourceInfoURL"`
+}
+
+// FSE is the Foreign Sanctions Evaders list - Treasury Department
+type FSE struct {
+	// EntityID is the unique identifier for the entity
+	EntityID string `json:"entityID"`
+	// EntityNumber (ent_num) is the unique listing identifier
+	EntityNumber string `json:"entityNumber"`
+	// Type is the entity type (e.g. individual, entity)
+	Type string `json:"type"`
+	// Programs is the list of sanctions program for which the entity is flagged
+	Programs []string `json:"programs"`
+	// Name is the primary name of the entity
+	Name string `json:"name"`
+	// AlternateNames is a list of aliases associated with the entity
+	AlternateNames []string `json:"alternateNames"`
+	// Addresses is a list of known addresses associated with the entity
+	Addresses []string `json:"addresses"`
+	// Citizenships is a list of countries the individual is a citizen of
+	Citizenships []string `json:"citizenships"`
+	// DatesOfBirth is a list of known dates of birth of the individual
+	DatesOfBirth []string `json:"datesOfBirth"`
+	// Nationalities is a list of the individual's nationalities
+	Nationalities []string `json:"nationalities"`
+	// IDs is a list of the forms of identification on file for the entity
+	IDs []string `json:"ids"`
+	// SourceListURL is a link to the official FSE list
+	SourceListURL string `json:"sourceListURL"`
+	// SourceInfoURL is a link to information about the list
+	SourceInfoURL string `json:"sourceInfoURL"`
+}
+
+// PLC is the Palestinian Legislative Council List - Treasury Department
+type PLC struct {
+	// EntityID is the unique identifier for the entity
+	EntityID string `json:"entityID"`
+	// EntityNumber (ent_num) is the unique listing identifier
+	EntityNumber string `json:"entityNumber"`
+	// Type is the entity type (e.g. individual, entity)
+	Type string `json:"type"`
+	// Programs is the list of sanctions program for which the entity is flagged
+	Programs []string `json:"programs"`
+	// Name is the primary name of the entity
+	Name string `json:"name"`
+	// AlternateNames is a list of aliases associated with the entity
+	AlternateNames []string `json:"alternateNames"`
+	// Addresses is a list of known addresses associated with the entity
+	Addresses []string `json:"addresses"`
+	// Remarks is used to provide additional details for the entity
+	Remarks []string `json:"remarks"`
+	// DatesOfBirth is a list of known dates of birth of the individual
+	DatesOfBirth []string `json:"datesOfBirth"`
+	// Nationalities is a list of the individual's nationalities
+	Nationalities []string `json:"nationalities"`
+	// PlacesOfBirth is a list of known places of birth of the individual
+	PlacesOfBirth []string `json:"placesOfBirth"`
+	// SourceListURL is a link to the official PLC list
+	SourceListURL string `json:"sourceListURL"`
+	// SourceInfoURL is a link to information about the list
+	SourceInfoURL string `json:"sourceInfoURL"`
+}
+
+// CAP is the CAPTA List (formerly Foreign Financial Institutions Subject to Part 561) - Treasury Department
+type CAP struct {
+	// EntityID is the unique identifier for the entity
+	EntityID string `json:"entityID"`
+	// EntityNumber (ent_num) is the unique listing identifier
+	EntityNumber string `json:"entityNumber"`
+	// Type is the entity type (e.g. individual, entity)
+	Type string `json:"type"`
+	// Programs is the list of sanctions program for which the entity is flagged
+	Programs []string `json:"programs"`
+	// Name is the primary name of the entity
+	Name string `json:"name"`
+	// AlternateNames is a list of aliases associated with the entity
+	AlternateNames []string `json:"alternateNames"`
+	// Addresses is a list of known addresses associated with the entity
+	Addresses []string `json:"addresses"`
+	// Remarks is used to provide additional details for the entity
+	Remarks []string `json:"remarks"`
+	// IDs is a list of the forms of identification on file for the entity
+	IDs []string `json:"ids"`
+	// SourceListURL is a link to the official CAPTA list
+	SourceListURL string `json:"sourceListURL"`
+	// SourceInfoURL is a link to information about the list
+	SourceInfoURL string `json:"sourceInfoURL"`
+}
+
+// NSMBS is the Non-SDN Menu-Based Sanctions List - Treasury Department
+type NSMBS struct {
+	// EntityID is the unique identifier for the entity
+	EntityID string `json:"entityID"`
+	// EntityNumber (ent_num) is the unique listing identifier
+	EntityNumber string `json:"entityNumber"`
+	// Type is the entity type (e.g. individual, entity)
+	Type string `json:"type"`
+	// Programs is the list of sanctions program for which the entity is flagged
+	Programs []string `json:"programs"`
+	// Name is the primary name of the entity
+	Name string `json:"name"`
+	// AlternateNames is a list of aliases associated with the entity
+	AlternateNames []string `json:"alternateNames"`
+	// Addresses is a list of known addresses associated with the entity
+	Addresses []string `json:"addresses"`
+	// Remarks is used to provide additional details for the entity
+	Remarks []string `json:"remarks"`
+	// Citizenships is a list of countries the individual is a citizen of
+	Citizenships []string `json:"citizenships"`
+	// DatesOfBirth is a list of known dates of birth of the individual
+	DatesOfBirth []string `json:"datesOfBirth"`
+	// Nationalities is a list of the individual's nationalities
+	Nationalities []string `json:"nationalities"`
+	// PlacesOfBirth is a list of known places of birth of the individual
+	PlacesOfBirth []string `json:"placesOfBirth"`
+	// IDs is a list of the forms of identification on file for the entity
+	IDs []string `json:"ids"`
+	// SourceInfoURL is a link to information about the list
+	SourceInfoURL string `json:"sourceInfoURL"`
+}
+
+// CMIC is the Non-SDN Chinese Military-Industrial Complex Companies List - Treasury Department
+type CMIC struct {
+	// EntityID is the unique identifier for the entity
+	EntityID string `json:"entityID"`
+	// EntityNumber (ent_num) is the unique listing identifier
+	EntityNumber string `json:"entityNumber"`
+	// Type is the entity type (e.g. individual, entity)
+	Type string `json:"type"`
+	// Programs is the list of sanctions program for which the entity is flagged
+	Programs []string `json:"programs"`
+	// Name is the primary name of the entity
+	Name string `json:"name"`
+	// AlternateNames is a list of aliases associated with the entity
+	AlternateNames []string `json:"alternateNames"`
+	// Addresses is a list of known addresses associated with the entity
+	Addresses []string `json:"addresses"`
+	// Remarks is used to provide additional details for the entity
+	Remarks []string `json:"remarks"`
+	// IDs is a list of the forms of identification on file for the entity
+	IDs []string `json:"ids"`
+	// SourceListURL is a link to the official CMIC list
+	SourceListURL string `json:"sourceListURL"`
+	// SourceInfoURL is a link to information about the list
+	SourceInfoURL string `json:"sourceInfoURL"`
+}
+
+// DTC is the ITAR Debarred list - State Department, Directorate of Defense Trade Controls
+type DTC struct {
+	// EntityID is the unique identifier for the entity
+	EntityID string `json:"entityID"`
+	// Name is the primary name of the entity
+	Name string `json:"name"`
+	// AlternateNames is a list of aliases associated with the entity
+	AlternateNames []string `json:"alternateNames"`
+	// FRNotice identifies the notice in the Federal Register
+	FRNotice string `json:"FRNotice"`
+	// SourceListURL is a link to the official DTC list
+	SourceListURL string `json:"sourceListURL"`
+	// SourceInfoURL is a link to information about the list
+	SourceInfoURL string `json:"sourceInfoURL"`
+}
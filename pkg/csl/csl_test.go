@@ -5,6 +5,7 @@
 package csl
 
 import (
+	"context"
 	"io/ioutil"
 	"testing"
 
@@ -24,7 +25,7 @@ func TestCSL(t *testing.T) {
 	dir, err := ioutil.TempDir("", "csl")
 	require.NoError(t, err)
 
-	file, err := Download(logger, dir)
+	file, err := Download(context.Background(), logger, dir)
 	require.NoError(t, err)
 
 	cslRecords, err := ReadFile(file)
@@ -5,9 +5,12 @@
 package csl
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/moov-io/base/log"
 	"github.com/moov-io/watchman/pkg/download"
@@ -22,8 +25,20 @@ var (
 	}()
 )
 
-func Download(logger log.Logger, initialDir string) (string, error) {
+func Download(ctx context.Context, logger log.Logger, initialDir string) (string, error) {
 	dl := download.New(logger, download.HTTPClient)
+	dl.Validations = map[string]*download.Validation{
+		Filename: {
+			ContentTypes:    download.DefaultContentTypes,
+			Header:          []string{"source", "entity_number", "name", "alt_names"},
+			MinRecords:      1000,
+			MaxRecordChange: download.DefaultMaxRecordChange,
+		},
+	}
+	dl.Limits = map[string]download.Limits{
+		// The consolidated list is large and served slowly
+		Filename: {Timeout: 5 * time.Minute, MaxBytes: 250 * 1024 * 1024},
+	}
 
 	cslURL, err := buildDownloadURL(cslDownloadTemplate)
 	if err != nil {
@@ -31,13 +46,19 @@ func Download(logger log.Logger, initialDir string) (string, error) {
 	}
 
 	cslNameAndSource := make(map[string]string)
-	cslNameAndSource["csl.csv"] = cslURL
+	cslNameAndSource[Filename] = cslURL
 
-	file, err := dl.GetFiles(initialDir, cslNameAndSource)
-	if len(file) == 0 || err != nil {
+	files, err := dl.GetFiles(ctx, initialDir, cslNameAndSource)
+	if len(files) == 0 || err != nil {
 		return "", fmt.Errorf("csl download: %v", err)
 	}
-	return file[0], nil
+	// every file of initialDir is returned, not only the CSL
+	for i := range files {
+		if filepath.Base(files[i]) == Filename {
+			return files[i], nil
+		}
+	}
+	return "", fmt.Errorf("csl download: csl.csv not found")
 }
 
 func buildDownloadURL(urlStr string) (string, error) {
@@ -5,6 +5,7 @@
 package csl
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -19,7 +20,7 @@ func TestDownload(t *testing.T) {
 		return
 	}
 
-	file, err := Download(log.NewNopLogger(), "")
+	file, err := Download(context.Background(), log.NewNopLogger(), "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -47,12 +48,13 @@ func TestDownload_initialDir(t *testing.T) {
 		}
 	}
 
-	// create each file
+	// create each file, add.csv sorts before csl.csv
+	mk(t, "add.csv", "file=add.csv")
 	mk(t, "sdn.csv", "file=sdn.csv")
 	mk(t, "csl.csv", "file=csl.csv")
 	mk(t, "csl.csv", "file=csl.csv")
 
-	file, err := Download(log.NewNopLogger(), dir)
+	file, err := Download(context.Background(), log.NewNopLogger(), dir)
 	if err != nil {
 		t.Fatal(err)
 	}
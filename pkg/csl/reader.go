@@ -56,31 +56,31 @@ func Parse(r io.Reader) (*CSL, error) {
 				report.SSIs = append(report.SSIs, unmarshalSSI(record, i))
 
 			case "Unverified List (UVL) - Bureau of Industry and Security":
-				// TODO(adam): https://github.com/moov-io/watchman/issues/403
+				report.UVLs = append(report.UVLs, unmarshalUVL(record, i))
 
 			case "Nonproliferation Sanctions (ISN) - State Department":
-				// TODO(adam): https://github.com/moov-io/watchman/issues/413
+				report.ISNs = append(report.ISNs, unmarshalISN(record, i))
 
 			case "AECA Debarred List": // TODO: Not found
 				// TODO(adam): https://github.com/moov-io/watchman/issues/414
 
 			case "Foreign Sanctions Evaders (FSE) - Treasury Department":
-				// TODO(adam): https://github.com/moov-io/watchman/issues/415
+				report.FSEs = append(report.FSEs, unmarshalFSE(record, i))
 
 			case "Palestinian Legislative Council List (PLC) - Treasury Department":
-				// TODO(adam): https://github.com/moov-io/watchman/issues/416
+				report.PLCs = append(report.PLCs, unmarshalPLC(record, i))
 
 			case "Capta List (CAP) - Treasury Department":
-				// TODO(adam): https://github.com/moov-io/watchman/issues/417
+				report.CAPs = append(report.CAPs, unmarshalCAP(record, i))
 
 			case "Non-SDN Menu-Based Sanctions List (NS-MBS List) - Treasury Department":
-				// TODO(adam): https://github.com/moov-io/watchman/issues/418
+				report.NSMBSs = append(report.NSMBSs, unmarshalNSMBS(record, i))
 
 			case "Non-SDN Chinese Military-Industrial Complex Companies List (CMIC) - Treasury Department":
-				// TODO(adam): https://github.com/moov-io/watchman/issues/419
+				report.CMICs = append(report.CMICs, unmarshalCMIC(record, i))
 
 			case "ITAR Debarred (DTC) - State Department":
-				// TODO(adam): https://github.com/moov-io/watchman/issues/422
+				report.DTCs = append(report.DTCs, unmarshalDTC(record, i))
 
 			default:
 				// Other lists are:
@@ -99,13 +99,24 @@ func unmarshalEL(row []string, offset int) *EL {
 	}
 	return &EL{
 		ID:                 id,
+		Type:               row[TypeIdx+offset],
+		Programs:           expandProgramsList(row[ProgramsIdx+offset]),
 		Name:               row[NameIdx+offset],
+		Title:              row[TitleIdx+offset],
 		Addresses:          expandField(row[AddressesIdx+offset]),
 		AlternateNames:     expandField(row[AltNamesIdx+offset]),
 		StartDate:          row[StartDateIdx+offset],
+		EndDate:            row[EndDateIdx+offset],
+		StandardOrder:      row[StandardOrderIdx+offset],
 		LicenseRequirement: row[LicenseRequirementIdx+offset],
 		LicensePolicy:      row[LicensePolicyIdx+offset],
 		FRNotice:           row[FRNoticeIdx+offset],
+		Remarks:            expandField(row[RemarksIdx+offset]),
+		Citizenships:       expandField(row[CitizenshipsIdx+offset]),
+		DatesOfBirth:       expandField(row[DatesOfBirthIdx+offset]),
+		Nationalities:      expandField(row[NationalitiesIdx+offset]),
+		PlacesOfBirth:      expandField(row[PlacesOfBirthIdx+offset]),
+		IDs:                expandField(row[IDsIdx+offset]),
 		SourceListURL:      row[SourceListURLIdx+offset],
 		SourceInfoURL:      row[SourceInformationURLIdx+offset],
 	}
@@ -113,27 +124,187 @@ func unmarshalEL(row []string, offset int) *EL {
 
 func unmarshalMEU(record []string, offset int) *MEU {
 	return &MEU{
-		EntityID:  record[0],
-		Name:      record[NameIdx+offset],
-		Addresses: record[AddressesIdx+offset],
-		FRNotice:  record[FRNoticeIdx+offset],
-		StartDate: record[StartDateIdx+offset],
-		EndDate:   record[EndDateIdx+offset],
+		EntityID:           record[0],
+		Type:               record[TypeIdx+offset],
+		Programs:           expandProgramsList(record[ProgramsIdx+offset]),
+		Name:               record[NameIdx+offset],
+		Title:              record[TitleIdx+offset],
+		AlternateNames:     expandField(record[AltNamesIdx+offset]),
+		Addresses:          record[AddressesIdx+offset],
+		FRNotice:           record[FRNoticeIdx+offset],
+		StartDate:          record[StartDateIdx+offset],
+		EndDate:            record[EndDateIdx+offset],
+		StandardOrder:      record[StandardOrderIdx+offset],
+		LicenseRequirement: record[LicenseRequirementIdx+offset],
+		LicensePolicy:      record[LicensePolicyIdx+offset],
+		Remarks:            expandField(record[RemarksIdx+offset]),
+		Citizenships:       expandField(record[CitizenshipsIdx+offset]),
+		DatesOfBirth:       expandField(record[DatesOfBirthIdx+offset]),
+		Nationalities:      expandField(record[NationalitiesIdx+offset]),
+		PlacesOfBirth:      expandField(record[PlacesOfBirthIdx+offset]),
+		IDs:                expandField(record[IDsIdx+offset]),
+		SourceListURL:      record[SourceListURLIdx+offset],
+		SourceInfoURL:      record[SourceInformationURLIdx+offset],
 	}
 }
 
 func unmarshalSSI(record []string, offset int) *SSI {
 	return &SSI{
-		EntityID:       record[EntityNumberIdx+offset],
-		Type:           record[TypeIdx+offset],
-		Programs:       expandProgramsList(record[ProgramsIdx+offset]),
-		Name:           record[NameIdx+offset],
-		Addresses:      expandField(record[AddressesIdx+offset]),
-		Remarks:        expandField(record[RemarksIdx+offset]),
-		AlternateNames: expandField(record[AltNamesIdx+offset]),
-		IDsOnRecord:    expandField(record[IDsIdx+offset]),
-		SourceListURL:  record[SourceListURLIdx+offset],
-		SourceInfoURL:  record[SourceInformationURLIdx+offset],
+		EntityID:               record[EntityNumberIdx+offset],
+		Type:                   record[TypeIdx+offset],
+		Programs:               expandProgramsList(record[ProgramsIdx+offset]),
+		Name:                   record[NameIdx+offset],
+		Title:                  record[TitleIdx+offset],
+		Addresses:              expandField(record[AddressesIdx+offset]),
+		FRNotice:               record[FRNoticeIdx+offset],
+		StartDate:              record[StartDateIdx+offset],
+		EndDate:                record[EndDateIdx+offset],
+		Remarks:                expandField(record[RemarksIdx+offset]),
+		AlternateNames:         expandField(record[AltNamesIdx+offset]),
+		Citizenships:           expandField(record[CitizenshipsIdx+offset]),
+		DatesOfBirth:           expandField(record[DatesOfBirthIdx+offset]),
+		Nationalities:          expandField(record[NationalitiesIdx+offset]),
+		PlacesOfBirth:          expandField(record[PlacesOfBirthIdx+offset]),
+		IDsOnRecord:            expandField(record[IDsIdx+offset]),
+		CallSign:               record[CallSignIdx+offset],
+		VesselType:             record[VesselTypeIdx+offset],
+		GrossTonnage:           record[GrossTonnageIdx+offset],
+		GrossRegisteredTonnage: record[GrossRegisteredTonnageIdx+offset],
+		VesselFlag:             record[VesselFlagIdx+offset],
+		VesselOwner:            record[VesselOwnerIdx+offset],
+		SourceListURL:          record[SourceListURLIdx+offset],
+		SourceInfoURL:          record[SourceInformationURLIdx+offset],
+	}
+}
+
+// rowID returns the unique identifier of the newer CSV format, which is the first column
+func rowID(row []string, offset int) string {
+	if offset == 1 {
+		return row[0]
+	}
+	return ""
+}
+
+func unmarshalUVL(row []string, offset int) *UVL {
+	return &UVL{
+		EntityID:       rowID(row, offset),
+		Name:           row[NameIdx+offset],
+		AlternateNames: expandField(row[AltNamesIdx+offset]),
+		Addresses:      expandField(row[AddressesIdx+offset]),
+		SourceListURL:  row[SourceListURLIdx+offset],
+		SourceInfoURL:  row[SourceInformationURLIdx+offset],
+	}
+}
+
+func unmarshalISN(row []string, offset int) *ISN {
+	return &ISN{
+		EntityID:       rowID(row, offset),
+		Programs:       expandProgramsList(row[ProgramsIdx+offset]),
+		Name:           row[NameIdx+offset],
+		AlternateNames: expandField(row[AltNamesIdx+offset]),
+		FRNotice:       row[FRNoticeIdx+offset],
+		StartDate:      row[StartDateIdx+offset],
+		Remarks:        expandField(row[RemarksIdx+offset]),
+		SourceListURL:  row[SourceListURLIdx+offset],
+		SourceInfoURL:  row[SourceInformationURLIdx+offset],
+	}
+}
+
+func unmarshalFSE(row []string, offset int) *FSE {
+	return &FSE{
+		EntityID:       rowID(row, offset),
+		EntityNumber:   row[EntityNumberIdx+offset],
+		Type:           row[TypeIdx+offset],
+		Programs:       expandProgramsList(row[ProgramsIdx+offset]),
+		Name:           row[NameIdx+offset],
+		AlternateNames: expandField(row[AltNamesIdx+offset]),
+		Addresses:      expandField(row[AddressesIdx+offset]),
+		Citizenships:   expandField(row[CitizenshipsIdx+offset]),
+		DatesOfBirth:   expandField(row[DatesOfBirthIdx+offset]),
+		Nationalities:  expandField(row[NationalitiesIdx+offset]),
+		IDs:            expandField(row[IDsIdx+offset]),
+		SourceListURL:  row[SourceListURLIdx+offset],
+		SourceInfoURL:  row[SourceInformationURLIdx+offset],
+	}
+}
+
+func unmarshalPLC(row []string, offset int) *PLC {
+	return &PLC{
+		EntityID:       rowID(row, offset),
+		EntityNumber:   row[EntityNumberIdx+offset],
+		Type:           row[TypeIdx+offset],
+		Programs:       expandProgramsList(row[ProgramsIdx+offset]),
+		Name:           row[NameIdx+offset],
+		AlternateNames: expandField(row[AltNamesIdx+offset]),
+		Addresses:      expandField(row[AddressesIdx+offset]),
+		Remarks:        expandField(row[RemarksIdx+offset]),
+		DatesOfBirth:   expandField(row[DatesOfBirthIdx+offset]),
+		Nationalities:  expandField(row[NationalitiesIdx+offset]),
+		PlacesOfBirth:  expandField(row[PlacesOfBirthIdx+offset]),
+		SourceListURL:  row[SourceListURLIdx+offset],
+		SourceInfoURL:  row[SourceInformationURLIdx+offset],
+	}
+}
+
+func unmarshalCAP(row []string, offset int) *CAP {
+	return &CAP{
+		EntityID:       rowID(row, offset),
+		EntityNumber:   row[EntityNumberIdx+offset],
+		Type:           row[TypeIdx+offset],
+		Programs:       expandProgramsList(row[ProgramsIdx+offset]),
+		Name:           row[NameIdx+offset],
+		AlternateNames: expandField(row[AltNamesIdx+offset]),
+		Addresses:      expandField(row[AddressesIdx+offset]),
+		Remarks:        expandField(row[RemarksIdx+offset]),
+		IDs:            expandField(row[IDsIdx+offset]),
+		SourceListURL:  row[SourceListURLIdx+offset],
+		SourceInfoURL:  row[SourceInformationURLIdx+offset],
+	}
+}
+
+func unmarshalNSMBS(row []string, offset int) *NSMBS {
+	return &NSMBS{
+		EntityID:       rowID(row, offset),
+		EntityNumber:   row[EntityNumberIdx+offset],
+		Type:           row[TypeIdx+offset],
+		Programs:       expandProgramsList(row[ProgramsIdx+offset]),
+		Name:           row[NameIdx+offset],
+		AlternateNames: expandField(row[AltNamesIdx+offset]),
+		Addresses:      expandField(row[AddressesIdx+offset]),
+		Remarks:        expandField(row[RemarksIdx+offset]),
+		Citizenships:   expandField(row[CitizenshipsIdx+offset]),
+		DatesOfBirth:   expandField(row[DatesOfBirthIdx+offset]),
+		Nationalities:  expandField(row[NationalitiesIdx+offset]),
+		PlacesOfBirth:  expandField(row[PlacesOfBirthIdx+offset]),
+		IDs:            expandField(row[IDsIdx+offset]),
+		SourceInfoURL:  row[SourceInformationURLIdx+offset],
+	}
+}
+
+func unmarshalCMIC(row []string, offset int) *CMIC {
+	return &CMIC{
+		EntityID:       rowID(row, offset),
+		EntityNumber:   row[EntityNumberIdx+offset],
+		Type:           row[TypeIdx+offset],
+		Programs:       expandProgramsList(row[ProgramsIdx+offset]),
+		Name:           row[NameIdx+offset],
+		AlternateNames: expandField(row[AltNamesIdx+offset]),
+		Addresses:      expandField(row[AddressesIdx+offset]),
+		Remarks:        expandField(row[RemarksIdx+offset]),
+		IDs:            expandField(row[IDsIdx+offset]),
+		SourceListURL:  row[SourceListURLIdx+offset],
+		SourceInfoURL:  row[SourceInformationURLIdx+offset],
+	}
+}
+
+func unmarshalDTC(row []string, offset int) *DTC {
+	return &DTC{
+		EntityID:       rowID(row, offset),
+		Name:           row[NameIdx+offset],
+		AlternateNames: expandField(row[AltNamesIdx+offset]),
+		FRNotice:       row[FRNoticeIdx+offset],
+		SourceListURL:  row[SourceListURLIdx+offset],
+		SourceInfoURL:  row[SourceInformationURLIdx+offset],
 	}
 }
 
@@ -27,6 +27,21 @@ func TestRead(t *testing.T) {
 	if len(csl.ELs) != 22 {
 		t.Errorf("len(ELs)=%d", len(csl.ELs))
 	}
+	if len(csl.PLCs) != 13 {
+		t.Errorf("len(PLCs)=%d", len(csl.PLCs))
+	}
+	if len(csl.DTCs) != 10 {
+		t.Errorf("len(DTCs)=%d", len(csl.DTCs))
+	}
+	if len(csl.UVLs) != 4 {
+		t.Errorf("len(UVLs)=%d", len(csl.UVLs))
+	}
+	if len(csl.ISNs) != 3 {
+		t.Errorf("len(ISNs)=%d", len(csl.ISNs))
+	}
+	if len(csl.CAPs) != 1 {
+		t.Errorf("len(CAPs)=%d", len(csl.CAPs))
+	}
 }
 
 func TestRead__Large(t *testing.T) {
@@ -44,6 +59,27 @@ func TestRead__Large(t *testing.T) {
 	require.Len(t, report.ELs, 2001)
 	require.Len(t, report.MEUs, 71)
 	require.Len(t, report.SSIs, 290)
+	require.Len(t, report.UVLs, 201)
+	require.Len(t, report.ISNs, 163)
+	require.Len(t, report.FSEs, 3)
+	require.Len(t, report.PLCs, 78)
+	require.Len(t, report.CAPs, 53)
+	require.Len(t, report.NSMBSs, 17)
+	require.Len(t, report.CMICs, 68)
+	require.Len(t, report.DTCs, 727)
+
+	require.Equal(t, &FSE{
+		EntityID:      "17526",
+		EntityNumber:  "17526",
+		Type:          "Individual",
+		Programs:      []string{"SYRIA", "FSE-SY"},
+		Name:          "BEKTAS, Halis",
+		Citizenships:  []string{"CH"},
+		DatesOfBirth:  []string{"1966-02-13"},
+		IDs:           []string{"CH, X0906223, Passport"},
+		SourceListURL: "https://bit.ly/1QWTIfE",
+		SourceInfoURL: "http://bit.ly/1N1docf",
+	}, report.FSEs[0])
 }
 
 func TestRead_missingRow(t *testing.T) {
@@ -111,24 +147,46 @@ d54346ef81802673c1b1daeb2ca8bd5d13755abd,Military End User (MEU) List - Bureau o
 	require.Len(t, report.MEUs, 3)
 
 	require.Equal(t, &MEU{
-		EntityID:  "26744194bd9b5cbec49db6ee29a4b53c697c7420",
-		Name:      "AECC Aviation Power Co. Ltd.",
-		Addresses: "Xiujia Bay, Weiyong Dt, Xian, 710021, CN",
-		FRNotice:  "85 FR 83799",
-		StartDate: "2020-12-23",
-		EndDate:   "",
+		EntityID:           "26744194bd9b5cbec49db6ee29a4b53c697c7420",
+		Name:               "AECC Aviation Power Co. Ltd.",
+		Addresses:          "Xiujia Bay, Weiyong Dt, Xian, 710021, CN",
+		FRNotice:           "85 FR 83799",
+		StartDate:          "2020-12-23",
+		EndDate:            "",
+		LicenseRequirement: "For any item subject to the EAR listed in supplement no. 2 to part 744.",
+		LicensePolicy:      "The license application procedure and license review policy for entities specified in supplement no. 2 to part 744 is specified in §744.21(d) and (e).",
+		SourceListURL:      "https://bit.ly/2XaGPYw",
+		SourceInfoURL:      "https://bit.ly/2XaGPYw",
 	}, report.MEUs[0])
 
 	require.Equal(t, &MEU{
-		EntityID:  "d54346ef81802673c1b1daeb2ca8bd5d13755abd",
-		Name:      "AECC China Gas Turbine Establishment",
-		Addresses: "No. 1 Hangkong Road, Mianyang, Sichuan, CN",
-		FRNotice:  "85 FR 83799",
-		StartDate: "2020-12-23",
-		EndDate:   "",
+		EntityID:           "d54346ef81802673c1b1daeb2ca8bd5d13755abd",
+		Name:               "AECC China Gas Turbine Establishment",
+		Addresses:          "No. 1 Hangkong Road, Mianyang, Sichuan, CN",
+		FRNotice:           "85 FR 83799",
+		StartDate:          "2020-12-23",
+		EndDate:            "",
+		LicenseRequirement: "For any item subject to the EAR listed in supplement no. 2 to part 744.",
+		LicensePolicy:      "The license application procedure and license review policy for entities specified in supplement no. 2 to part 744 is specified in §744.21(d) and (e).",
+		SourceListURL:      "https://bit.ly/2XaGPYw",
+		SourceInfoURL:      "https://bit.ly/2XaGPYw",
 	}, report.MEUs[2])
 }
 
+func Test_unmarshalMEU_AlternateNames(t *testing.T) {
+	record := make([]string, IDsIdx+2)
+	record[0] = "26744194bd9b5cbec49db6ee29a4b53c697c7420"
+	record[NameIdx+1] = "AECC Aviation Power Co. Ltd."
+	record[TypeIdx+1] = "Entity"
+	record[AltNamesIdx+1] = "Xi'an Aero-Engine; AVIC Aviation Power"
+	record[CitizenshipsIdx+1] = "CN"
+
+	meu := unmarshalMEU(record, 1)
+	require.Equal(t, "Entity", meu.Type)
+	require.Equal(t, []string{"Xi'an Aero-Engine", "AVIC Aviation Power"}, meu.AlternateNames)
+	require.Equal(t, []string{"CN"}, meu.Citizenships)
+}
+
 func Test_unmarshalSSI(t *testing.T) {
 	// row from the live CSL
 	record := []string{"Sectoral Sanctions Identifications List (SSI) - Treasury Department", "17254", "Entity", "UKRAINE-EO13662]; SYRIA", "AK TRANSNEFT OAO",
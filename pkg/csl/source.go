@@ -0,0 +1,229 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl
+
+import (
+	"context"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/source"
+)
+
+// Filename is the name the Consolidated Screening List is saved as
+const Filename = "csl.csv"
+
+func init() {
+	source.Register(Source{})
+}
+
+// Source publishes each sub-list of the Consolidated Screening List
+type Source struct{}
+
+func (Source) Name() string {
+	return "CSL"
+}
+
+func (Source) Filenames() []string {
+	return []string{Filename}
+}
+
+func (Source) Download(ctx context.Context, logger log.Logger, initialDir string) ([]string, error) {
+	file, err := Download(ctx, logger, initialDir)
+	if err != nil {
+		return nil, err
+	}
+	return []string{file}, nil
+}
+
+func (Source) Read(files []string) ([]source.List, error) {
+	file, err := source.File(files, Filename)
+	if err != nil {
+		return nil, err
+	}
+	records, err := ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return []source.List{
+		{Name: "BISEntities", Entities: source.Entities(records.ELs)},
+		{Name: "MilitaryEndUsers", Entities: source.Entities(records.MEUs)},
+		{Name: "SSIs", Entities: source.Entities(records.SSIs)},
+		{Name: "UVLs", Entities: source.Entities(records.UVLs)},
+		{Name: "ISNs", Entities: source.Entities(records.ISNs)},
+		{Name: "FSEs", Entities: source.Entities(records.FSEs)},
+		{Name: "PLCs", Entities: source.Entities(records.PLCs)},
+		{Name: "CAPs", Entities: source.Entities(records.CAPs)},
+		{Name: "NSMBSs", Entities: source.Entities(records.NSMBSs)},
+		{Name: "CMICs", Entities: source.Entities(records.CMICs)},
+		{Name: "DTCs", Entities: source.Entities(records.DTCs)},
+	}, nil
+}
+
+// ToEntity converts the record into an Entity of the BISEntities list
+func (el *EL) ToEntity() *source.Entity {
+	return &source.Entity{
+		Name:           el.Name,
+		AlternateNames: el.AlternateNames,
+		Type:           source.ParseEntityType(el.Type),
+		Addresses:      el.Addresses,
+		IDs:            el.IDs,
+		DatesOfBirth:   el.DatesOfBirth,
+		Programs:       el.Programs,
+		ListedOn:       el.StartDate,
+		SourceList:     "BISEntities",
+		SourceID:       el.ID,
+		SourceData:     el,
+	}
+}
+
+func (meu *MEU) ToEntity() *source.Entity {
+	var addresses []string
+	if meu.Addresses != "" {
+		addresses = []string{meu.Addresses}
+	}
+	return &source.Entity{
+		Name:           meu.Name,
+		AlternateNames: meu.AlternateNames,
+		Type:           source.ParseEntityType(meu.Type),
+		Addresses:      addresses,
+		IDs:            meu.IDs,
+		DatesOfBirth:   meu.DatesOfBirth,
+		Programs:       meu.Programs,
+		ListedOn:       meu.StartDate,
+		SourceList:     "MilitaryEndUsers",
+		SourceID:       meu.EntityID,
+		SourceData:     meu,
+	}
+}
+
+func (ssi *SSI) ToEntity() *source.Entity {
+	return &source.Entity{
+		Name:           ssi.Name,
+		AlternateNames: ssi.AlternateNames,
+		Type:           source.ParseEntityType(ssi.Type),
+		Addresses:      ssi.Addresses,
+		IDs:            ssi.IDsOnRecord,
+		DatesOfBirth:   ssi.DatesOfBirth,
+		Programs:       ssi.Programs,
+		ListedOn:       ssi.StartDate,
+		SourceList:     "SSIs",
+		SourceID:       ssi.EntityID,
+		SourceData:     ssi,
+	}
+}
+
+func (uvl *UVL) ToEntity() *source.Entity {
+	return &source.Entity{
+		Name:           uvl.Name,
+		AlternateNames: uvl.AlternateNames,
+		Addresses:      uvl.Addresses,
+		SourceList:     "UVLs",
+		SourceID:       uvl.EntityID,
+		SourceData:     uvl,
+	}
+}
+
+func (isn *ISN) ToEntity() *source.Entity {
+	return &source.Entity{
+		Name:           isn.Name,
+		AlternateNames: isn.AlternateNames,
+		Programs:       isn.Programs,
+		ListedOn:       isn.StartDate,
+		SourceList:     "ISNs",
+		SourceID:       isn.EntityID,
+		SourceData:     isn,
+	}
+}
+
+func (fse *FSE) ToEntity() *source.Entity {
+	return &source.Entity{
+		Name:           fse.Name,
+		AlternateNames: fse.AlternateNames,
+		Type:           source.ParseEntityType(fse.Type),
+		Addresses:      fse.Addresses,
+		IDs:            fse.IDs,
+		DatesOfBirth:   fse.DatesOfBirth,
+		Programs:       fse.Programs,
+		SourceList:     "FSEs",
+		SourceID:       sourceID(fse.EntityID, fse.EntityNumber),
+		SourceData:     fse,
+	}
+}
+
+func (plc *PLC) ToEntity() *source.Entity {
+	return &source.Entity{
+		Name:           plc.Name,
+		AlternateNames: plc.AlternateNames,
+		Type:           source.ParseEntityType(plc.Type),
+		Addresses:      plc.Addresses,
+		DatesOfBirth:   plc.DatesOfBirth,
+		Programs:       plc.Programs,
+		SourceList:     "PLCs",
+		SourceID:       sourceID(plc.EntityID, plc.EntityNumber),
+		SourceData:     plc,
+	}
+}
+
+func (c *CAP) ToEntity() *source.Entity {
+	return &source.Entity{
+		Name:           c.Name,
+		AlternateNames: c.AlternateNames,
+		Type:           source.ParseEntityType(c.Type),
+		Addresses:      c.Addresses,
+		IDs:            c.IDs,
+		Programs:       c.Programs,
+		SourceList:     "CAPs",
+		SourceID:       sourceID(c.EntityID, c.EntityNumber),
+		SourceData:     c,
+	}
+}
+
+func (nsmbs *NSMBS) ToEntity() *source.Entity {
+	return &source.Entity{
+		Name:           nsmbs.Name,
+		AlternateNames: nsmbs.AlternateNames,
+		Type:           source.ParseEntityType(nsmbs.Type),
+		Addresses:      nsmbs.Addresses,
+		IDs:            nsmbs.IDs,
+		DatesOfBirth:   nsmbs.DatesOfBirth,
+		Programs:       nsmbs.Programs,
+		SourceList:     "NSMBSs",
+		SourceID:       sourceID(nsmbs.EntityID, nsmbs.EntityNumber),
+		SourceData:     nsmbs,
+	}
+}
+
+func (cmic *CMIC) ToEntity() *source.Entity {
+	return &source.Entity{
+		Name:           cmic.Name,
+		AlternateNames: cmic.AlternateNames,
+		Type:           source.ParseEntityType(cmic.Type),
+		Addresses:      cmic.Addresses,
+		IDs:            cmic.IDs,
+		Programs:       cmic.Programs,
+		SourceList:     "CMICs",
+		SourceID:       sourceID(cmic.EntityID, cmic.EntityNumber),
+		SourceData:     cmic,
+	}
+}
+
+func (dtc *DTC) ToEntity() *source.Entity {
+	return &source.Entity{
+		Name:           dtc.Name,
+		AlternateNames: dtc.AlternateNames,
+		SourceList:     "DTCs",
+		SourceID:       dtc.EntityID,
+		SourceData:     dtc,
+	}
+}
+
+// sourceID returns id, which only the newer CSV format has, or the entity number of the listing
+// when the older format was read
+func sourceID(id, entityNumber string) string {
+	if id != "" {
+		return id
+	}
+	return entityNumber
+}
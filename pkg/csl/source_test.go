@@ -0,0 +1,51 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/source"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSource(t *testing.T) {
+	lists, err := Source{}.Read([]string{filepath.Join("..", "..", "test", "testdata", Filename)})
+	require.NoError(t, err)
+	require.Len(t, lists, 11)
+
+	counts := make(map[string]int)
+	for i := range lists {
+		counts[lists[i].Name] = len(lists[i].Entities)
+	}
+	require.Equal(t, 22, counts["BISEntities"])
+	require.Equal(t, 26, counts["SSIs"])
+	require.Equal(t, 10, counts["DTCs"])
+
+	el := lists[0].Entities[0]
+	require.Equal(t, "32 Group China Ltd.", el.Name)
+	require.Equal(t, "BISEntities", el.SourceList)
+	require.Equal(t, "2015-11-12", el.ListedOn)
+	require.Len(t, el.Addresses, 2)
+	require.Equal(t, el.Name, el.SourceData.(*EL).Name)
+
+	ssi := lists[2].Entities[0]
+	require.Equal(t, "SSIs", ssi.SourceList)
+	require.Equal(t, ssi.SourceData.(*SSI).EntityID, ssi.SourceID)
+	require.Equal(t, ssi.SourceData.(*SSI).IDsOnRecord, ssi.IDs)
+
+	// Records of the older CSV format are identified by their entity number
+	var plc *source.Entity
+	for i := range lists {
+		if lists[i].Name == "PLCs" {
+			plc = lists[i].Entities[0]
+		}
+	}
+	require.NotNil(t, plc)
+	require.Empty(t, plc.SourceData.(*PLC).EntityID)
+	require.Equal(t, plc.SourceData.(*PLC).EntityNumber, plc.SourceID)
+}
@@ -0,0 +1,229 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package download
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+var (
+	// DefaultCache is used by every Downloader created with New and is nil (disabled) unless
+	// it's set, such as with CacheFromEnvironment.
+	DefaultCache *Cache
+)
+
+// CacheFromEnvironment returns the Cache in DOWNLOAD_CACHE_DIRECTORY, which is nil when it's unset.
+func CacheFromEnvironment() (*Cache, error) {
+	dir := os.Getenv("DOWNLOAD_CACHE_DIRECTORY")
+	if dir == "" {
+		return nil, nil
+	}
+	cache, err := NewCache(dir)
+	if err != nil {
+		return nil, fmt.Errorf("DOWNLOAD_CACHE_DIRECTORY: %v", err)
+	}
+	return cache, nil
+}
+
+const (
+	validatorsFilename = "validators.json"
+)
+
+// Cache keeps the most recent copy of each downloaded file along with the HTTP validators
+// (ETag and Last-Modified) it was served with so later downloads can be conditional.
+//
+// When a server responds with 304 Not Modified the cached copy is used instead.
+type Cache struct {
+	Dir string
+
+	mu         sync.Mutex
+	validators map[string]Validators
+}
+
+// Validators are the HTTP cache validators for one downloaded file
+type Validators struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	DownloadedAt time.Time `json:"downloadedAt"`
+
+	// Records is how many records the file contained, if it was validated
+	Records int `json:"records,omitempty"`
+}
+
+// NewCache creates (if needed) dir and reads the validators previously stored in it.
+func NewCache(dir string) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating download cache: %v", err)
+	}
+	c := &Cache{
+		Dir:        dir,
+		validators: make(map[string]Validators),
+	}
+
+	bs, err := os.ReadFile(filepath.Join(dir, validatorsFilename))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return c, nil
+		}
+		return nil, fmt.Errorf("reading download cache validators: %v", err)
+	}
+	if err := json.Unmarshal(bs, &c.validators); err != nil {
+		return nil, fmt.Errorf("reading download cache validators: %v", err)
+	}
+	return c, nil
+}
+
+// Validators returns the stored validators for filename if a cached copy exists.
+func (c *Cache) Validators(filename string) (Validators, bool) {
+	if c == nil {
+		return Validators{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, exists := c.validators[filename]
+	if !exists {
+		return v, false
+	}
+	if _, err := os.Stat(filepath.Join(c.Dir, filename)); err != nil {
+		return v, false
+	}
+	return v, true
+}
+
+// setConditionalHeaders adds If-None-Match and If-Modified-Since to req when a cached copy
+// of filename from the same URL exists.
+func (c *Cache) setConditionalHeaders(req *http.Request, filename string) {
+	v, exists := c.Validators(filename)
+	if !exists || v.URL != req.URL.String() {
+		return
+	}
+	if v.ETag != "" {
+		req.Header.Set("If-None-Match", v.ETag)
+	}
+	if v.LastModified != "" {
+		req.Header.Set("If-Modified-Since", v.LastModified)
+	}
+}
+
+// store keeps a copy of path as filename along with the validators from resp.
+func (c *Cache) store(filename, path string, resp *http.Response, records int) error {
+	if err := copyFile(path, filepath.Join(c.Dir, filename)); err != nil {
+		return fmt.Errorf("caching %s: %v", filename, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.validators[filename] = Validators{
+		URL:          resp.Request.URL.String(),
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		DownloadedAt: time.Now(),
+		Records:      records,
+	}
+	return c.writeValidators()
+}
+
+// restore copies the cached filename into dir and returns how many records it contains. The copy is
+// checked with validation like a download is, and is only renamed to filename once it's valid.
+func (c *Cache) restore(filename, dir string, validation *Validation) (int, error) {
+	tmp, err := copyTemp(filepath.Join(c.Dir, filename), filepath.Join(dir, filename))
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp) // no-op after a successful rename
+
+	records := 0
+	if validation != nil {
+		records, err = validation.checkFile(tmp, previousRecords(c, filename))
+		if err != nil {
+			return 0, fmt.Errorf("invalid file: %v", err)
+		}
+	}
+	if err := os.Rename(tmp, filepath.Join(dir, filename)); err != nil {
+		return 0, fmt.Errorf("renaming temp file: %v", err)
+	}
+	return records, nil
+}
+
+// forget removes the cached copy of filename and its validators, so it's downloaded unconditionally.
+func (c *Cache) forget(filename string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.validators, filename)
+	if err := os.Remove(filepath.Join(c.Dir, filename)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return c.writeValidators()
+}
+
+// writeValidators replaces the validators file, callers must hold c.mu
+func (c *Cache) writeValidators() error {
+	bs, err := json.MarshalIndent(c.validators, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(c.Dir, validatorsFilename)
+	fd, err := os.CreateTemp(c.Dir, "."+validatorsFilename+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(fd.Name()) // no-op after a successful rename
+
+	if _, err := fd.Write(bs); err != nil {
+		fd.Close()
+		return err
+	}
+	if err := fd.Close(); err != nil {
+		return err
+	}
+	return os.Rename(fd.Name(), path)
+}
+
+// copyFile copies src to dst through a temporary file, so dst is never partially written.
+func copyFile(src, dst string) error {
+	tmp, err := copyTemp(src, dst)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp) // no-op after a successful rename
+
+	return os.Rename(tmp, dst)
+}
+
+// copyTemp copies src into a temporary file next to dst and returns its path, which the caller
+// renames to dst or removes.
+func copyTemp(src, dst string) (string, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return "", err
+	}
+	defer in.Close()
+
+	out, err := os.CreateTemp(filepath.Dir(dst), "."+filepath.Base(dst)+"-*")
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(out.Name())
+		return "", err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(out.Name())
+		return "", err
+	}
+	return out.Name(), nil
+}
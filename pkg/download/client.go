@@ -5,12 +5,14 @@
 package download
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -20,15 +22,16 @@ import (
 )
 
 var (
-	HTTPClient = &http.Client{
-		Timeout: 15 * time.Second,
-	}
+	// HTTPClient is used for every list download. Programs replace it with a client from
+	// NewHTTPClient(ConfigFromEnvironment()) to apply the DOWNLOAD_* settings, which it ignores.
+	HTTPClient, _ = NewHTTPClient(ClientConfig{})
 )
 
 func New(logger log.Logger, httpClient *http.Client) *Downloader {
 	return &Downloader{
 		HTTP:   httpClient,
 		Logger: logger,
+		Cache:  DefaultCache,
 	}
 }
 
@@ -36,10 +39,23 @@ func New(logger log.Logger, httpClient *http.Client) *Downloader {
 //
 // If HTTP is nil then http.DefaultClient will be used (which has NO timeouts).
 //
+// If Cache is non-nil requests are made conditional on the cached copy of each file and
+// the cached copy is used when the server responds with 304 Not Modified.
+//
+// Validations are checked against each downloaded file (keyed by filename) before it's
+// accepted into the directory. Limits (also keyed by filename) bound each attempt and fall
+// back to DefaultLimits. Failed attempts are retried according to Backoff, or DefaultBackoff
+// when it's empty.
+//
 // See: https://www.treasury.gov/resource-center/sanctions/SDN-List/Pages/sdn_data.aspx
 type Downloader struct {
 	HTTP   *http.Client
 	Logger log.Logger
+	Cache  *Cache
+
+	Validations map[string]*Validation
+	Limits      map[string]Limits
+	Backoff     Backoff
 }
 
 // GetFiles will download all provided files, return their filepaths, and store them in a
@@ -47,8 +63,10 @@ type Downloader struct {
 //
 // initialDir is an optional filepath to look for files in before attempting to download.
 //
+// Downloads are abandoned once ctx is done.
+//
 // Callers are expected to cleanup the temp directory.
-func (dl *Downloader) GetFiles(initialDir string, namesAndSources map[string]string) ([]string, error) {
+func (dl *Downloader) GetFiles(ctx context.Context, initialDir string, namesAndSources map[string]string) ([]string, error) {
 	if dl == nil {
 		return nil, errors.New("nil Downloader")
 	}
@@ -79,6 +97,9 @@ func (dl *Downloader) GetFiles(initialDir string, namesAndSources map[string]str
 	}
 
 	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var failures []string
+
 	wg.Add(len(namesAndSources))
 	for name, source := range namesAndSources {
 		// Check if we have the file locally first
@@ -97,7 +118,13 @@ func (dl *Downloader) GetFiles(initialDir string, namesAndSources map[string]str
 		// Download missing files
 		go func(wg *sync.WaitGroup, filename, downloadURL string) {
 			defer wg.Done()
-			dl.retryDownload(dir, filename, downloadURL)
+			if err := dl.retryDownload(ctx, dir, filename, downloadURL); err != nil {
+				dl.Logger.Error().LogErrorf("problem downloading %s: %v", filename, err)
+
+				mu.Lock()
+				failures = append(failures, fmt.Sprintf("%s: %v", filename, err))
+				mu.Unlock()
+			}
 		}(&wg, name, source)
 	}
 
@@ -112,37 +139,185 @@ func (dl *Downloader) GetFiles(initialDir string, namesAndSources map[string]str
 	for i := range fds {
 		out = append(out, filepath.Join(dir, filepath.Base(fds[i].Name())))
 	}
+	if len(failures) > 0 {
+		sort.Strings(failures)
+		return out, errors.New(strings.Join(failures, "; "))
+	}
 	return out, nil
 }
 
-func (dl *Downloader) retryDownload(dir, filename, downloadURL string) {
-	// Allow a couple retries for various sources (some are flakey)
-	for i := 0; i < 3; i++ {
-		req, err := http.NewRequest("GET", downloadURL, nil)
-		if err != nil {
-			dl.Logger.Error().LogErrorf("error building HTTP request: %v", err)
-			return
+func (dl *Downloader) retryDownload(ctx context.Context, dir, filename, downloadURL string) error {
+	backoff := dl.Backoff
+	if backoff.Attempts <= 0 {
+		backoff = DefaultBackoff
+	}
+
+	var lastErr error
+
+	// Allow retries for various sources (some are flakey)
+	for attempt := 0; attempt < backoff.Attempts; attempt++ {
+		if attempt > 0 {
+			wait := backoff.delay(attempt - 1)
+			var retryable *retryableError
+			if errors.As(lastErr, &retryable) && retryable.after > 0 {
+				wait = retryable.after // the server told us when to come back
+			}
+			downloadRetryDelay.WithLabelValues(filename).Observe(wait.Seconds())
+			if err := sleep(ctx, wait); err != nil {
+				return fmt.Errorf("%v (last error: %v)", err, lastErr)
+			}
 		}
-		req.Header.Set("User-Agent", fmt.Sprintf("moov-io/watchman:%v", watchman.Version))
 
-		resp, err := dl.HTTP.Do(req)
-		if err != nil {
-			time.Sleep(100 * time.Millisecond)
-			continue // retry
+		start := time.Now()
+		err := dl.download(ctx, dir, filename, downloadURL)
+		downloadDuration.WithLabelValues(filename).Observe(time.Since(start).Seconds())
+
+		if err == nil {
+			downloadAttempts.WithLabelValues(filename, "success").Inc()
+			return nil // quit after successful download
 		}
+		lastErr = err
 
-		// Copy resp.Body into a file in our temp dir
-		fd, err := os.Create(filepath.Join(dir, filename))
+		var retryable *retryableError
+		if !errors.As(err, &retryable) || ctx.Err() != nil {
+			downloadAttempts.WithLabelValues(filename, "failure").Inc()
+			return err
+		}
+		downloadAttempts.WithLabelValues(filename, "retry").Inc()
+		dl.Logger.Warn().Logf("attempt %d downloading %s failed: %v", attempt+1, filename, err)
+	}
+	return lastErr
+}
+
+func (dl *Downloader) limits(filename string) Limits {
+	limits, exists := dl.Limits[filename]
+	if !exists {
+		return DefaultLimits
+	}
+	if limits.Timeout <= 0 {
+		limits.Timeout = DefaultLimits.Timeout
+	}
+	if limits.MaxBytes <= 0 {
+		limits.MaxBytes = DefaultLimits.MaxBytes
+	}
+	return limits
+}
+
+// download makes one attempt at downloading filename into dir
+func (dl *Downloader) download(ctx context.Context, dir, filename, downloadURL string) error {
+	limits := dl.limits(filename)
+
+	ctx, cancel := context.WithTimeout(ctx, limits.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", downloadURL, nil)
+	if err != nil {
+		return fmt.Errorf("error building HTTP request: %v", err)
+	}
+	req.Header.Set("User-Agent", fmt.Sprintf("moov-io/watchman:%v", watchman.Version))
+	if dl.Cache != nil {
+		dl.Cache.setConditionalHeaders(req, filename)
+	}
+
+	resp, err := dl.HTTP.Do(req)
+	if err != nil {
+		return &retryableError{err: err}
+	}
+	defer resp.Body.Close()
+
+	// Use our cached copy when the file hasn't changed
+	if resp.StatusCode == http.StatusNotModified && dl.Cache != nil {
+		validation := dl.Validations[filename]
+		records, err := dl.Cache.restore(filename, dir, validation)
 		if err != nil {
-			resp.Body.Close()
-			return
+			// Drop the cached copy so the next attempt downloads the file again
+			if err := dl.Cache.forget(filename); err != nil {
+				dl.Logger.Warn().LogErrorf("problem removing cached %s: %v", filename, err)
+			}
+			return &retryableError{err: fmt.Errorf("problem restoring cached copy: %v", err)}
+		}
+		if validation != nil {
+			acceptedRecords(filename, records)
 		}
+		dl.Logger.Logf("%s not modified, using cached copy", filename)
+		return nil
+	}
 
-		io.Copy(fd, resp.Body) // copy file contents
+	return dl.saveResponse(dir, filename, resp, limits.MaxBytes)
+}
 
-		// close the open files
+// retryableError is a download failure which might succeed on another attempt
+type retryableError struct {
+	err error
+
+	// after is how long the server asked us to wait with Retry-After
+	after time.Duration
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+// saveResponse writes resp.Body into a temporary file in dir, validates it and then renames
+// it to filename. Nothing is left in dir when any step fails.
+func (dl *Downloader) saveResponse(dir, filename string, resp *http.Response, maxBytes int64) error {
+	if resp.StatusCode != http.StatusOK {
+		err := fmt.Errorf("unexpected HTTP status: %s", resp.Status)
+		if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+			return &retryableError{err: err, after: retryAfter(resp, time.Now())}
+		}
+		return err
+	}
+	if resp.ContentLength > maxBytes {
+		return fmt.Errorf("file exceeds limit of %d bytes (%d bytes)", maxBytes, resp.ContentLength)
+	}
+
+	validation := dl.Validations[filename]
+	if err := validation.checkContentType(resp.Header.Get("Content-Type")); err != nil {
+		return err
+	}
+
+	// Copy resp.Body into a temporary file in our dir
+	fd, err := os.CreateTemp(dir, "."+filename+"-*")
+	if err != nil {
+		return fmt.Errorf("creating temp file: %v", err)
+	}
+	tmp := fd.Name()
+	defer os.Remove(tmp) // no-op after a successful rename
+
+	n, err := io.Copy(fd, io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		fd.Close()
+		return &retryableError{err: fmt.Errorf("incomplete transfer: %v", err)}
+	}
+	if n > maxBytes {
 		fd.Close()
-		resp.Body.Close()
-		return // quit after successful download
+		return fmt.Errorf("file exceeds limit of %d bytes", maxBytes)
+	}
+	if err := fd.Close(); err != nil {
+		return fmt.Errorf("writing temp file: %v", err)
+	}
+
+	records := 0
+	if validation != nil {
+		records, err = validation.checkFile(tmp, previousRecords(dl.Cache, filename))
+		if err != nil {
+			return fmt.Errorf("invalid file: %v", err)
+		}
+	}
+
+	path := filepath.Join(dir, filename)
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("renaming temp file: %v", err)
+	}
+	if validation != nil {
+		acceptedRecords(filename, records)
+	}
+
+	if dl.Cache != nil {
+		if err := dl.Cache.store(filename, path, resp, records); err != nil {
+			dl.Logger.Warn().LogErrorf("problem caching %s: %v", filename, err)
+		}
 	}
+	return nil
 }
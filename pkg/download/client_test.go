@@ -0,0 +1,281 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package download
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base/log"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloader__conditional(t *testing.T) {
+	var requests, notModified int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Mon, 02 Mar 2026 10:00:00 GMT")
+		w.Write([]byte("ent_num,SDN_Name\n"))
+	}))
+	defer server.Close()
+
+	cache, err := NewCache(t.TempDir())
+	require.NoError(t, err)
+
+	dl := New(log.NewNopLogger(), server.Client())
+	dl.Cache = cache
+
+	sources := map[string]string{"sdn.csv": server.URL + "/sdn.csv"}
+
+	// first download is unconditional
+	files, err := dl.GetFiles(context.Background(), "", sources)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	defer os.RemoveAll(filepath.Dir(files[0]))
+
+	v, exists := cache.Validators("sdn.csv")
+	require.True(t, exists)
+	require.Equal(t, `"v1"`, v.ETag)
+	require.Equal(t, "Mon, 02 Mar 2026 10:00:00 GMT", v.LastModified)
+
+	// second download is answered with 304 and restored from the cache
+	files, err = dl.GetFiles(context.Background(), "", sources)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	defer os.RemoveAll(filepath.Dir(files[0]))
+
+	bs, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	require.Equal(t, "ent_num,SDN_Name\n", string(bs))
+
+	require.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	require.Equal(t, int32(1), atomic.LoadInt32(&notModified))
+
+	// validators are read back from disk
+	reopened, err := NewCache(cache.Dir)
+	require.NoError(t, err)
+	v, exists = reopened.Validators("sdn.csv")
+	require.True(t, exists)
+	require.Equal(t, server.URL+"/sdn.csv", v.URL)
+}
+
+func TestDownloader__invalidCache(t *testing.T) {
+	var requests, notModified int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte("a,b\nc,d\n"))
+	}))
+	defer server.Close()
+
+	cache, err := NewCache(t.TempDir())
+	require.NoError(t, err)
+
+	dl := New(log.NewNopLogger(), server.Client())
+	dl.Cache = cache
+	dl.Backoff = Backoff{Attempts: 3, Initial: time.Millisecond, Max: 10 * time.Millisecond}
+	dl.Validations = map[string]*Validation{
+		"sdn.csv": {MinRecords: 2},
+	}
+	sources := map[string]string{"sdn.csv": server.URL + "/sdn.csv"}
+
+	_, err = dl.GetFiles(context.Background(), t.TempDir(), sources)
+	require.NoError(t, err)
+
+	// A cached copy which is no longer valid is dropped and downloaded again
+	require.NoError(t, os.WriteFile(filepath.Join(cache.Dir, "sdn.csv"), []byte("a,b\n"), 0600))
+
+	dir := t.TempDir()
+	files, err := dl.GetFiles(context.Background(), dir, sources)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	bs, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	require.Equal(t, "a,b\nc,d\n", string(bs))
+
+	require.Equal(t, int32(3), atomic.LoadInt32(&requests))
+	require.Equal(t, int32(1), atomic.LoadInt32(&notModified))
+
+	// the new download is cached, with no temp files left behind
+	bs, err = os.ReadFile(filepath.Join(cache.Dir, "sdn.csv"))
+	require.NoError(t, err)
+	require.Equal(t, "a,b\nc,d\n", string(bs))
+
+	entries, err := os.ReadDir(cache.Dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2) // sdn.csv and validators.json
+
+	entries, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
+
+func TestCache__nil(t *testing.T) {
+	var cache *Cache
+	_, exists := cache.Validators("sdn.csv")
+	require.False(t, exists)
+}
+
+func TestCacheFromEnvironment(t *testing.T) {
+	t.Setenv("DOWNLOAD_CACHE_DIRECTORY", "")
+	cache, err := CacheFromEnvironment()
+	require.NoError(t, err)
+	require.Nil(t, cache)
+
+	dir := filepath.Join(t.TempDir(), "cache")
+	t.Setenv("DOWNLOAD_CACHE_DIRECTORY", dir)
+	cache, err = CacheFromEnvironment()
+	require.NoError(t, err)
+	require.Equal(t, dir, cache.Dir)
+
+	// A file where the directory should be is reported rather than panicking
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, []byte("a"), 0600))
+	t.Setenv("DOWNLOAD_CACHE_DIRECTORY", file)
+	_, err = CacheFromEnvironment()
+	require.ErrorContains(t, err, "DOWNLOAD_CACHE_DIRECTORY")
+}
+
+func TestDownloader__invalid(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/error.csv":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/missing.csv":
+			w.WriteHeader(http.StatusNotFound)
+		case "/html.csv":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html></html>"))
+		case "/truncated.csv":
+			w.Header().Set("Content-Length", "1000")
+			w.Write([]byte("a,b\n"))
+		case "/short.csv":
+			w.Write([]byte("a,b\n"))
+		case "/sdn.csv":
+			w.Write([]byte("a,b\nc,d\n"))
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	dl := New(log.NewNopLogger(), server.Client())
+	dl.Backoff = Backoff{Attempts: 3, Initial: time.Millisecond, Max: 10 * time.Millisecond}
+	dl.Validations = map[string]*Validation{
+		"short.csv": {MinRecords: 2},
+		"sdn.csv":   {MinRecords: 2},
+	}
+
+	names := []string{"error.csv", "missing.csv", "html.csv", "truncated.csv", "short.csv", "sdn.csv"}
+	sources := make(map[string]string)
+	for _, name := range names {
+		sources[name] = server.URL + "/" + name
+	}
+
+	files, err := dl.GetFiles(context.Background(), dir, sources)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error.csv: unexpected HTTP status: 500")
+	require.Contains(t, err.Error(), "missing.csv: unexpected HTTP status: 404")
+	require.Contains(t, err.Error(), "html.csv: unexpected content type text/html")
+	require.Contains(t, err.Error(), "truncated.csv: incomplete transfer")
+	require.Contains(t, err.Error(), "short.csv: invalid file: found 1 records, expected at least 2")
+
+	// only the valid file was written, with no temp files left behind
+	require.Len(t, files, 1)
+	require.Equal(t, "sdn.csv", filepath.Base(files[0]))
+}
+
+func TestDownloader__retryAfter(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("a,b\n"))
+	}))
+	defer server.Close()
+
+	dl := New(log.NewNopLogger(), server.Client())
+	dl.Backoff = Backoff{Attempts: 3, Initial: time.Millisecond, Max: 10 * time.Millisecond}
+
+	files, err := dl.GetFiles(context.Background(), t.TempDir(), map[string]string{
+		"sdn.csv": server.URL + "/sdn.csv",
+	})
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
+
+func TestDownloader__canceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	dl := New(log.NewNopLogger(), server.Client())
+	dl.Backoff = Backoff{Attempts: 5, Initial: time.Hour, Max: time.Hour}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := dl.GetFiles(ctx, t.TempDir(), map[string]string{
+		"sdn.csv": server.URL + "/sdn.csv",
+	})
+	require.ErrorContains(t, err, "context deadline exceeded")
+	require.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestDownloader__limits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/large.csv":
+			w.Write([]byte(strings.Repeat("a,b\n", 100)))
+		case "/slow.csv":
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+		}
+	}))
+	defer server.Close()
+
+	dl := New(log.NewNopLogger(), server.Client())
+	dl.Backoff = Backoff{Attempts: 1}
+	dl.Limits = map[string]Limits{
+		"large.csv": {MaxBytes: 10},
+		"slow.csv":  {Timeout: 50 * time.Millisecond},
+	}
+
+	files, err := dl.GetFiles(context.Background(), t.TempDir(), map[string]string{
+		"large.csv": server.URL + "/large.csv",
+		"slow.csv":  server.URL + "/slow.csv",
+	})
+	require.Empty(t, files)
+	require.ErrorContains(t, err, "large.csv: file exceeds limit of 10 bytes")
+	require.ErrorContains(t, err, "slow.csv: ")
+	require.ErrorContains(t, err, "deadline exceeded")
+}
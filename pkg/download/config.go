@@ -0,0 +1,147 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package download
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// ClientConfig describes how list files are fetched when egress is restricted, such as through
+// an authenticating proxy which inspects TLS.
+type ClientConfig struct {
+	// ProxyURL is an explicit HTTP(S) proxy, otherwise HTTP_PROXY / HTTPS_PROXY / NO_PROXY are used.
+	// Credentials can be included in the URL or set with ProxyUsername and ProxyPassword.
+	ProxyURL      string
+	ProxyUsername string
+	ProxyPassword string
+
+	// CAFile is a PEM bundle of certificates trusted in addition to the system roots
+	CAFile string
+
+	// CertFile and KeyFile are a PEM encoded client certificate and key presented to servers
+	CertFile string
+	KeyFile  string
+
+	// UserAgentSuffix is appended to the User-Agent of every request
+	UserAgentSuffix string
+}
+
+// ConfigFromEnvironment reads a ClientConfig from DOWNLOAD_PROXY_URL, DOWNLOAD_PROXY_USERNAME,
+// DOWNLOAD_PROXY_PASSWORD, DOWNLOAD_CA_FILE, DOWNLOAD_CLIENT_CERT_FILE, DOWNLOAD_CLIENT_KEY_FILE
+// and DOWNLOAD_USER_AGENT_SUFFIX.
+func ConfigFromEnvironment() ClientConfig {
+	return ClientConfig{
+		ProxyURL:        os.Getenv("DOWNLOAD_PROXY_URL"),
+		ProxyUsername:   os.Getenv("DOWNLOAD_PROXY_USERNAME"),
+		ProxyPassword:   os.Getenv("DOWNLOAD_PROXY_PASSWORD"),
+		CAFile:          os.Getenv("DOWNLOAD_CA_FILE"),
+		CertFile:        os.Getenv("DOWNLOAD_CLIENT_CERT_FILE"),
+		KeyFile:         os.Getenv("DOWNLOAD_CLIENT_KEY_FILE"),
+		UserAgentSuffix: os.Getenv("DOWNLOAD_USER_AGENT_SUFFIX"),
+	}
+}
+
+// NewHTTPClient returns a client for downloading list files configured by cfg. It has no overall
+// timeout, each download attempt is bound by its Limits instead.
+func NewHTTPClient(cfg ClientConfig) (*http.Client, error) {
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           (&net.Dialer{Timeout: 30 * time.Second}).DialContext,
+		TLSHandshakeTimeout:   15 * time.Second,
+		ResponseHeaderTimeout: 30 * time.Second,
+		IdleConnTimeout:       90 * time.Second,
+		MaxIdleConns:          10,
+	}
+
+	if cfg.ProxyURL != "" {
+		proxy, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %v", err)
+		}
+		if proxy.Scheme != "http" && proxy.Scheme != "https" {
+			return nil, fmt.Errorf("unsupported proxy scheme %q", proxy.Scheme)
+		}
+		if cfg.ProxyUsername != "" {
+			proxy.User = url.UserPassword(cfg.ProxyUsername, cfg.ProxyPassword)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	tlsConfig, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	client := &http.Client{
+		Transport: transport,
+	}
+	if suffix := strings.TrimSpace(cfg.UserAgentSuffix); suffix != "" {
+		client.Transport = &userAgentTransport{
+			suffix: suffix,
+			next:   transport,
+		}
+	}
+	return client, nil
+}
+
+func (cfg ClientConfig) tlsConfig() (*tls.Config, error) {
+	conf := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if cfg.CAFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		bs, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA file: %v", err)
+		}
+		if !pool.AppendCertsFromPEM(bs) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", cfg.CAFile)
+		}
+		conf.RootCAs = pool
+	}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		if cfg.CertFile == "" || cfg.KeyFile == "" {
+			return nil, errors.New("both a client certificate and key are required")
+		}
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %v", err)
+		}
+		conf.Certificates = []tls.Certificate{cert}
+	}
+
+	return conf, nil
+}
+
+// userAgentTransport appends suffix to the User-Agent of each request
+type userAgentTransport struct {
+	suffix string
+	next   http.RoundTripper
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	if ua := req.Header.Get("User-Agent"); ua != "" {
+		req.Header.Set("User-Agent", ua+" "+t.suffix)
+	} else {
+		req.Header.Set("User-Agent", t.suffix)
+	}
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,202 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package download
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base/log"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeServerCA writes the certificate of a TLS test server as a CA bundle
+func writeServerCA(t *testing.T, server *httptest.Server) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	bs := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(path, bs, 0600))
+	return path
+}
+
+// writeClientCert creates a self-signed client certificate and returns its certificate and key paths
+func writeClientCert(t *testing.T) (*x509.Certificate, string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "watchman"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certPath, keyPath := filepath.Join(dir, "client.pem"), filepath.Join(dir, "client.key")
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes}), 0600))
+
+	return cert, certPath, keyPath
+}
+
+func downloadFile(t *testing.T, client *http.Client, url string) error {
+	t.Helper()
+
+	dl := New(log.NewNopLogger(), client)
+	dl.Backoff = Backoff{Attempts: 1}
+
+	_, err := dl.GetFiles(context.Background(), t.TempDir(), map[string]string{
+		"sdn.csv": url,
+	})
+	return err
+}
+
+func TestHTTPClient__default(t *testing.T) {
+	// Settings which can fail are only read from the environment by programs
+	t.Setenv("DOWNLOAD_CA_FILE", filepath.Join(t.TempDir(), "missing.pem"))
+	require.NotNil(t, HTTPClient)
+
+	_, err := NewHTTPClient(ConfigFromEnvironment())
+	require.ErrorContains(t, err, "CA file")
+}
+
+func TestHTTPClient__CAFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("a,b\n"))
+	}))
+	defer server.Close()
+
+	// the test server isn't trusted by default
+	client, err := NewHTTPClient(ClientConfig{})
+	require.NoError(t, err)
+	require.ErrorContains(t, downloadFile(t, client, server.URL+"/sdn.csv"), "certificate")
+
+	client, err = NewHTTPClient(ClientConfig{
+		CAFile: writeServerCA(t, server),
+	})
+	require.NoError(t, err)
+	require.NoError(t, downloadFile(t, client, server.URL+"/sdn.csv"))
+}
+
+func TestHTTPClient__clientCertificate(t *testing.T) {
+	cert, certPath, keyPath := writeClientCert(t)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("a,b\n"))
+	}))
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  pool,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	caFile := writeServerCA(t, server)
+
+	client, err := NewHTTPClient(ClientConfig{CAFile: caFile})
+	require.NoError(t, err)
+	require.Error(t, downloadFile(t, client, server.URL+"/sdn.csv"))
+
+	client, err = NewHTTPClient(ClientConfig{
+		CAFile:   caFile,
+		CertFile: certPath,
+		KeyFile:  keyPath,
+	})
+	require.NoError(t, err)
+	require.NoError(t, downloadFile(t, client, server.URL+"/sdn.csv"))
+}
+
+func TestHTTPClient__proxy(t *testing.T) {
+	var userAgent string
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.Write([]byte("a,b\n"))
+	}))
+	defer origin.Close()
+
+	var proxied int
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expected := "Basic " + base64.StdEncoding.EncodeToString([]byte("user:secret"))
+		if r.Header.Get("Proxy-Authorization") != expected {
+			w.WriteHeader(http.StatusProxyAuthRequired)
+			return
+		}
+		proxied++
+
+		req, _ := http.NewRequestWithContext(r.Context(), r.Method, r.URL.String(), nil)
+		req.Header = r.Header.Clone()
+		req.Header.Del("Proxy-Authorization")
+		resp, err := http.DefaultTransport.RoundTrip(req)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		defer resp.Body.Close()
+		w.WriteHeader(resp.StatusCode)
+		io.Copy(w, resp.Body)
+	}))
+	defer proxy.Close()
+
+	// without credentials the proxy refuses
+	client, err := NewHTTPClient(ClientConfig{ProxyURL: proxy.URL})
+	require.NoError(t, err)
+	require.ErrorContains(t, downloadFile(t, client, origin.URL+"/sdn.csv"), "407")
+
+	client, err = NewHTTPClient(ClientConfig{
+		ProxyURL:        proxy.URL,
+		ProxyUsername:   "user",
+		ProxyPassword:   "secret",
+		UserAgentSuffix: "acme-compliance/1.0",
+	})
+	require.NoError(t, err)
+	require.NoError(t, downloadFile(t, client, origin.URL+"/sdn.csv"))
+	require.Equal(t, 1, proxied)
+	require.Contains(t, userAgent, "moov-io/watchman:")
+	require.Contains(t, userAgent, " acme-compliance/1.0")
+}
+
+func TestHTTPClient__invalid(t *testing.T) {
+	_, err := NewHTTPClient(ClientConfig{ProxyURL: "socks5://localhost:1080"})
+	require.ErrorContains(t, err, "unsupported proxy scheme")
+
+	_, err = NewHTTPClient(ClientConfig{CAFile: filepath.Join(t.TempDir(), "missing.pem")})
+	require.ErrorContains(t, err, "reading CA file")
+
+	_, err = NewHTTPClient(ClientConfig{CertFile: "client.pem"})
+	require.ErrorContains(t, err, "both a client certificate and key are required")
+}
@@ -0,0 +1,136 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package download
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	downloadAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "download_attempts_total",
+		Help: "Count of attempts to download each list file by result",
+	}, []string{"filename", "result"})
+
+	downloadDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "download_duration_seconds",
+		Help:    "Duration of each attempt to download a list file",
+		Buckets: []float64{0.1, 0.5, 1, 5, 15, 30, 60, 120, 300, 600},
+	}, []string{"filename"})
+
+	downloadRetryDelay = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "download_retry_delay_seconds",
+		Help:    "Time waited before retrying the download of a list file",
+		Buckets: []float64{0.1, 0.5, 1, 5, 15, 30, 60, 120},
+	}, []string{"filename"})
+)
+
+func init() {
+	prometheus.MustRegister(downloadAttempts)
+	prometheus.MustRegister(downloadDuration)
+	prometheus.MustRegister(downloadRetryDelay)
+}
+
+// Limits bound a single download attempt of a file
+type Limits struct {
+	// Timeout is how long one attempt, including reading the body, can take
+	Timeout time.Duration
+
+	// MaxBytes is the largest file accepted
+	MaxBytes int64
+}
+
+var (
+	// DefaultLimits apply to files without their own Limits
+	DefaultLimits = Limits{
+		Timeout:  time.Minute,
+		MaxBytes: 100 * 1024 * 1024, // 100MB
+	}
+)
+
+// Backoff controls how failed downloads are retried. Delays grow exponentially from
+// Initial up to Max and are randomized (jitter) to spread out retries.
+type Backoff struct {
+	Attempts int
+	Initial  time.Duration
+	Max      time.Duration
+}
+
+var (
+	// DefaultBackoff is used by Downloaders without a Backoff
+	DefaultBackoff = Backoff{
+		Attempts: 5,
+		Initial:  500 * time.Millisecond,
+		Max:      30 * time.Second,
+	}
+
+	// maxRetryAfter caps how long a server's Retry-After can make us wait
+	maxRetryAfter = 5 * time.Minute
+)
+
+// delay returns how long to wait before the next attempt, where attempt starts at zero
+// for the first retry.
+func (b Backoff) delay(attempt int) time.Duration {
+	d := b.Initial
+	for i := 0; i < attempt && d < b.Max; i++ {
+		d *= 2
+	}
+	if d > b.Max {
+		d = b.Max
+	}
+	if d <= 0 {
+		return 0
+	}
+	// full jitter over the upper half of the delay
+	half := int64(d / 2)
+	return time.Duration(half + rand.Int63n(half+1)) //nolint:gosec
+}
+
+// retryAfter reads the Retry-After header as either seconds or an HTTP date.
+func retryAfter(resp *http.Response, now time.Time) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	v := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if v == "" {
+		return 0
+	}
+	var d time.Duration
+	if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+		d = time.Duration(n) * time.Second
+	} else if when, err := http.ParseTime(v); err == nil {
+		d = when.Sub(now)
+	}
+	if d < 0 {
+		return 0
+	}
+	if d > maxRetryAfter {
+		return maxRetryAfter
+	}
+	return d
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
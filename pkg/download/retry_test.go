@@ -0,0 +1,42 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package download
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackoff__delay(t *testing.T) {
+	b := Backoff{Attempts: 5, Initial: 100 * time.Millisecond, Max: time.Second}
+
+	for attempt, max := range []time.Duration{
+		100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond,
+		800 * time.Millisecond, time.Second, time.Second,
+	} {
+		d := b.delay(attempt)
+		require.GreaterOrEqual(t, d, max/2, "attempt %d", attempt)
+		require.LessOrEqual(t, d, max, "attempt %d", attempt)
+	}
+
+	require.Equal(t, time.Duration(0), Backoff{}.delay(3))
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2026, time.March, 2, 10, 0, 0, 0, time.UTC)
+	resp := func(v string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": []string{v}}}
+	}
+
+	require.Equal(t, 30*time.Second, retryAfter(resp("30"), now))
+	require.Equal(t, 2*time.Minute, retryAfter(resp("Mon, 02 Mar 2026 10:02:00 GMT"), now))
+	require.Equal(t, maxRetryAfter, retryAfter(resp("86400"), now))
+	require.Equal(t, time.Duration(0), retryAfter(resp("Mon, 02 Mar 2026 09:00:00 GMT"), now))
+	require.Equal(t, time.Duration(0), retryAfter(resp("soon"), now))
+	require.Equal(t, time.Duration(0), retryAfter(nil, now))
+}
@@ -0,0 +1,229 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package download
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"os"
+	"strings"
+	"sync"
+)
+
+var (
+	// DefaultContentTypes are the media types accepted for delimited list files
+	DefaultContentTypes = []string{
+		"text/csv",
+		"text/plain",
+		"text/tab-separated-values",
+		"application/csv",
+		"application/octet-stream",
+		"application/vnd.ms-excel",
+	}
+
+	// DefaultMaxRecordChange is the largest fraction a file's record count can change by
+	// between downloads before it's rejected.
+	DefaultMaxRecordChange = 0.5
+)
+
+// Validation describes what a downloaded file must look like to be accepted.
+//
+// Files are expected to be delimited by Comma (',' if empty), or XML when Root is set. Each
+// check is skipped when its field is left empty.
+type Validation struct {
+	// ContentTypes are the allowed media types of the response, HTML is never allowed
+	ContentTypes []string
+
+	Comma rune
+
+	// Root is the name of the root element of XML files and Element the name of the
+	// elements counted as records.
+	Root    string
+	Element string
+
+	// Header are column names which must appear in the first row, compared without case
+	Header []string
+
+	// Columns is the number of fields expected in the first row
+	Columns int
+
+	// MinRecords is the fewest rows a plausible file contains
+	MinRecords int
+
+	// MaxRecordChange is the largest fraction (0.5 = 50%) the record count can change by
+	// compared to the previously accepted download of this file.
+	MaxRecordChange float64
+}
+
+var (
+	// recordCounts holds the record counts of files accepted by this process. They are used to
+	// compare downloads when there's no Cache.
+	recordCounts   = make(map[string]int)
+	recordCountsMu sync.Mutex
+)
+
+func previousRecords(cache *Cache, filename string) int {
+	if v, exists := cache.Validators(filename); exists && v.Records > 0 {
+		return v.Records
+	}
+	recordCountsMu.Lock()
+	defer recordCountsMu.Unlock()
+	return recordCounts[filename]
+}
+
+func acceptedRecords(filename string, records int) {
+	recordCountsMu.Lock()
+	defer recordCountsMu.Unlock()
+	recordCounts[filename] = records
+}
+
+// checkContentType returns an error if the Content-Type isn't allowed
+func (v *Validation) checkContentType(contentType string) error {
+	if contentType == "" {
+		return nil // servers don't always send one
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fmt.Errorf("invalid content type %q: %v", contentType, err)
+	}
+	if mediaType == "text/html" {
+		return fmt.Errorf("unexpected content type %s", mediaType)
+	}
+	if v == nil || len(v.ContentTypes) == 0 {
+		return nil
+	}
+	for i := range v.ContentTypes {
+		if strings.EqualFold(v.ContentTypes[i], mediaType) {
+			return nil
+		}
+	}
+	return fmt.Errorf("unexpected content type %s", mediaType)
+}
+
+// checkFile reads the file at path and returns how many records it contains, or an error
+// if the file doesn't look like what we expect.
+func (v *Validation) checkFile(path string, previous int) (int, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer fd.Close()
+
+	if v.Root != "" {
+		return v.checkXML(fd, previous)
+	}
+
+	reader := csv.NewReader(fd)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	if v.Comma != 0 {
+		reader.Comma = v.Comma
+	}
+
+	records := 0
+	for {
+		record, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				continue // malformed rows are skipped by each parser
+			}
+			return records, err
+		}
+		if records == 0 {
+			if err := v.checkFirstRow(record); err != nil {
+				return records, err
+			}
+		}
+		if len(record) > 1 {
+			records++
+		}
+	}
+
+	return records, v.checkRecords(records, previous)
+}
+
+// checkXML counts the Element records of an XML file after checking its root element
+func (v *Validation) checkXML(r io.Reader, previous int) (int, error) {
+	decoder := xml.NewDecoder(r)
+
+	records := 0
+	root := true
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return records, err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if root {
+			if start.Name.Local != v.Root {
+				return records, fmt.Errorf("found root element %s, expected %s", start.Name.Local, v.Root)
+			}
+			root = false
+		}
+		if start.Name.Local == v.Element {
+			records++
+		}
+	}
+	if root {
+		return records, errors.New("no XML elements found")
+	}
+	return records, v.checkRecords(records, previous)
+}
+
+func (v *Validation) checkRecords(records, previous int) error {
+	if records < v.MinRecords {
+		return fmt.Errorf("found %d records, expected at least %d", records, v.MinRecords)
+	}
+	if v.MaxRecordChange > 0 && previous > 0 {
+		change := math.Abs(float64(records-previous)) / float64(previous)
+		if change > v.MaxRecordChange {
+			return fmt.Errorf("record count changed from %d to %d (%.0f%%), exceeding %.0f%%",
+				previous, records, change*100, v.MaxRecordChange*100)
+		}
+	}
+	return nil
+}
+
+func (v *Validation) checkFirstRow(record []string) error {
+	if v.Columns > 0 && len(record) != v.Columns {
+		return fmt.Errorf("found %d columns, expected %d", len(record), v.Columns)
+	}
+	for i := range v.Header {
+		found := false
+		for j := range record {
+			column := strings.TrimSpace(strings.TrimPrefix(record[j], "\uFEFF")) // strip UTF-8 BOM
+			if strings.EqualFold(column, v.Header[i]) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("missing header column %q in %q", v.Header[i], strings.Join(record, string(v.commaOrDefault())))
+		}
+	}
+	return nil
+}
+
+func (v *Validation) commaOrDefault() rune {
+	if v.Comma != 0 {
+		return v.Comma
+	}
+	return ','
+}
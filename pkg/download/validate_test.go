@@ -0,0 +1,90 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package download
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidation__checkContentType(t *testing.T) {
+	var v *Validation
+	require.NoError(t, v.checkContentType(""))
+	require.NoError(t, v.checkContentType("application/json"))
+	require.Error(t, v.checkContentType("text/html; charset=utf-8"))
+
+	v = &Validation{ContentTypes: DefaultContentTypes}
+	require.NoError(t, v.checkContentType("text/csv; charset=utf-8"))
+	require.NoError(t, v.checkContentType("application/octet-stream"))
+	require.Error(t, v.checkContentType("application/json"))
+	require.Error(t, v.checkContentType("text/html"))
+}
+
+func TestValidation__checkFile(t *testing.T) {
+	write := func(t *testing.T, body string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "file.csv")
+		require.NoError(t, os.WriteFile(path, []byte(body), 0600))
+		return path
+	}
+
+	t.Run("ofac", func(t *testing.T) {
+		v := &Validation{Columns: 12, MinRecords: 1000}
+		records, err := v.checkFile(filepath.Join("..", "..", "test", "testdata", "sdn.csv"), 0)
+		require.NoError(t, err)
+		require.Greater(t, records, 7000)
+
+		_, err = v.checkFile(filepath.Join("..", "..", "test", "testdata", "add.csv"), 0)
+		require.ErrorContains(t, err, "found 6 columns, expected 12")
+	})
+
+	t.Run("header", func(t *testing.T) {
+		v := &Validation{Comma: '\t', Header: []string{"Name", "Street_Address"}, MinRecords: 100}
+		records, err := v.checkFile(filepath.Join("..", "..", "test", "testdata", "dpl.txt"), 0)
+		require.NoError(t, err)
+		require.Greater(t, records, 500)
+
+		_, err = v.checkFile(write(t, "<html><body>Service Unavailable</body></html>"), 0)
+		require.ErrorContains(t, err, `missing header column "Name"`)
+	})
+
+	t.Run("xml", func(t *testing.T) {
+		v := &Validation{Root: "Sanctions", Element: "DistinctParty", MinRecords: 3}
+		records, err := v.checkFile(filepath.Join("..", "ofac", "testdata", "sdn_advanced.xml"), 0)
+		require.NoError(t, err)
+		require.Equal(t, 3, records)
+
+		_, err = v.checkFile(write(t, "<html><body>Service Unavailable</body></html>"), 0)
+		require.ErrorContains(t, err, "found root element html, expected Sanctions")
+
+		_, err = v.checkFile(write(t, "<Sanctions><DistinctParty/></Sanctions>"), 0)
+		require.ErrorContains(t, err, "found 1 records, expected at least 3")
+
+		_, err = v.checkFile(write(t, "<Sanctions><DistinctParty>"), 0)
+		require.Error(t, err)
+	})
+
+	t.Run("min records", func(t *testing.T) {
+		v := &Validation{MinRecords: 3}
+		_, err := v.checkFile(write(t, "a,b\nc,d\n"), 0)
+		require.ErrorContains(t, err, "found 2 records, expected at least 3")
+	})
+
+	t.Run("record change", func(t *testing.T) {
+		body := strings.Repeat("a,b\n", 40)
+		v := &Validation{MaxRecordChange: 0.5}
+
+		records, err := v.checkFile(write(t, body), 60)
+		require.NoError(t, err)
+		require.Equal(t, 40, records)
+
+		_, err = v.checkFile(write(t, body), 100)
+		require.ErrorContains(t, err, "record count changed from 100 to 40")
+	})
+}
@@ -5,6 +5,7 @@
 package dpl
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -23,18 +24,27 @@ var (
 )
 
 // Download returns an array of absolute filepaths for files downloaded
-func Download(logger log.Logger, initialDir string) (string, error) {
+func Download(ctx context.Context, logger log.Logger, initialDir string) (string, error) {
 	dl := download.New(logger, download.HTTPClient)
+	dl.Validations = map[string]*download.Validation{
+		Filename: {
+			ContentTypes:    download.DefaultContentTypes,
+			Comma:           '\t',
+			Header:          []string{"Name", "Street_Address", "Effective_Date"},
+			MinRecords:      100,
+			MaxRecordChange: download.DefaultMaxRecordChange,
+		},
+	}
 
 	addrs := make(map[string]string)
-	addrs["dpl.txt"] = fmt.Sprintf(dplDownloadTemplate, "dpl.txt")
+	addrs[Filename] = fmt.Sprintf(dplDownloadTemplate, Filename)
 
-	files, err := dl.GetFiles(initialDir, addrs)
+	files, err := dl.GetFiles(ctx, initialDir, addrs)
 	if len(files) == 0 || err != nil {
 		return "", fmt.Errorf("dpl download: %v", err)
 	}
 	for i := range files {
-		if filepath.Base(files[i]) == "dpl.txt" {
+		if filepath.Base(files[i]) == Filename {
 			return files[i], nil
 		}
 	}
@@ -5,6 +5,7 @@
 package dpl
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -19,7 +20,7 @@ func TestDownloader(t *testing.T) {
 		return
 	}
 
-	file, err := Download(log.NewNopLogger(), "")
+	file, err := Download(context.Background(), log.NewNopLogger(), "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -51,7 +52,7 @@ func TestDownloader__initialDir(t *testing.T) {
 	mk(t, "sdn.csv", "file=sdn.csv")
 	mk(t, "dpl.txt", "file=dpl.txt")
 
-	file, err := Download(log.NewNopLogger(), dir)
+	file, err := Download(context.Background(), log.NewNopLogger(), dir)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,28 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package dpl
+
+import (
+	"github.com/moov-io/watchman/pkg/source"
+)
+
+// ListName is the SourceList of records from the Denied Persons List
+const ListName = "DPL"
+
+// ToEntity converts the Denied Person into a source.Entity. The list doesn't say whether
+// a Denied Person is an individual or a business, or identify them other than by citation.
+func (dp *DPL) ToEntity() *source.Entity {
+	out := &source.Entity{
+		Name:       dp.Name,
+		ListedOn:   dp.EffectiveDate,
+		SourceList: ListName,
+		SourceID:   dp.FRCitation,
+		SourceData: dp,
+	}
+	if addr := source.JoinParts(dp.StreetAddress, dp.City, dp.State, dp.PostalCode, dp.Country); addr != "" {
+		out.Addresses = []string{addr}
+	}
+	return out
+}
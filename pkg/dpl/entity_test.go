@@ -0,0 +1,31 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package dpl
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDPL__ToEntity(t *testing.T) {
+	dp := &DPL{
+		Name:          "AL NASER AIRLINES",
+		StreetAddress: "P.O. Box 28360",
+		City:          "Dubai",
+		Country:       "AE",
+		EffectiveDate: "08/25/2017",
+		FRCitation:    "82 F.R. 40733 9/29/2017",
+	}
+	entity := dp.ToEntity()
+	if entity.Name != dp.Name || entity.ListedOn != "08/25/2017" {
+		t.Errorf("unexpected entity: %#v", entity)
+	}
+	if !reflect.DeepEqual(entity.Addresses, []string{"P.O. Box 28360, Dubai, AE"}) {
+		t.Errorf("Addresses=%v", entity.Addresses)
+	}
+	if entity.SourceList != "DPL" || entity.SourceID != dp.FRCitation || entity.SourceData != dp {
+		t.Errorf("unexpected source: %s %s %v", entity.SourceList, entity.SourceID, entity.SourceData)
+	}
+}
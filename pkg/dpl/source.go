@@ -0,0 +1,52 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package dpl
+
+import (
+	"context"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/source"
+)
+
+// Filename is the name the Denied Persons List is saved as
+const Filename = "dpl.txt"
+
+func init() {
+	source.Register(Source{})
+}
+
+// Source publishes the BIS Denied Persons List
+type Source struct{}
+
+func (Source) Name() string {
+	return ListName
+}
+
+func (Source) Filenames() []string {
+	return []string{Filename}
+}
+
+func (Source) Download(ctx context.Context, logger log.Logger, initialDir string) ([]string, error) {
+	file, err := Download(ctx, logger, initialDir)
+	if err != nil {
+		return nil, err
+	}
+	return []string{file}, nil
+}
+
+func (Source) Read(files []string) ([]source.List, error) {
+	file, err := source.File(files, Filename)
+	if err != nil {
+		return nil, err
+	}
+	persons, err := Read(file)
+	if err != nil {
+		return nil, err
+	}
+	return []source.List{
+		{Name: ListName, Entities: source.Entities(persons)},
+	}, nil
+}
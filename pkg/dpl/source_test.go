@@ -0,0 +1,34 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package dpl
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSource(t *testing.T) {
+	files := []string{
+		filepath.Join("..", "..", "test", "testdata", "sdn.csv"),
+		filepath.Join("..", "..", "test", "testdata", Filename),
+	}
+	lists, err := Source{}.Read(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lists) != 1 || lists[0].Name != ListName {
+		t.Fatalf("unexpected lists: %#v", lists)
+	}
+	if len(lists[0].Entities) == 0 {
+		t.Fatal("no Denied Persons")
+	}
+	if entity := lists[0].Entities[0]; entity.Name == "" || entity.SourceList != ListName {
+		t.Errorf("unexpected entity: %#v", entity)
+	}
+
+	if _, err := (Source{}).Read(files[:1]); err == nil {
+		t.Error("expected error")
+	}
+}
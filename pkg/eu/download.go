@@ -0,0 +1,60 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package eu
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/download"
+)
+
+// Filename is the name the EU FSF XML file is saved as
+const Filename = "eu_fsf.xml"
+
+var (
+	euDownloadURL = func() string {
+		if w := os.Getenv("EU_DOWNLOAD_URL"); w != "" {
+			return w
+		}
+		// The token is public and published alongside the list
+		return "https://webgate.ec.europa.eu/fsd/fsf/public/files/xmlFullSanctionsList_1_1/content?token=dG9rZW4tMjAxNw"
+	}()
+)
+
+// Download returns the filepath of the EU Consolidated Financial Sanctions XML file
+func Download(ctx context.Context, logger log.Logger, initialDir string) (string, error) {
+	dl := download.New(logger, download.HTTPClient)
+	dl.Validations = map[string]*download.Validation{
+		Filename: {
+			ContentTypes:    append([]string{"application/xml", "text/xml"}, download.DefaultContentTypes...),
+			Root:            "export",
+			Element:         "sanctionEntity",
+			MinRecords:      1000,
+			MaxRecordChange: download.DefaultMaxRecordChange,
+		},
+	}
+	dl.Limits = map[string]download.Limits{
+		Filename: {Timeout: 5 * time.Minute, MaxBytes: 250 * 1024 * 1024},
+	}
+
+	addrs := make(map[string]string)
+	addrs[Filename] = euDownloadURL
+
+	files, err := dl.GetFiles(ctx, initialDir, addrs)
+	if len(files) == 0 || err != nil {
+		return "", fmt.Errorf("eu download: %v", err)
+	}
+	for i := range files {
+		if filepath.Base(files[i]) == Filename {
+			return files[i], nil
+		}
+	}
+	return "", fmt.Errorf("eu download: %s not found", Filename)
+}
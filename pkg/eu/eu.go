@@ -0,0 +1,113 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package eu
+
+// Entity is a person or organisation on the EU Consolidated Financial Sanctions (FSF) list
+type Entity struct {
+	// LogicalID is the unique identifier of the entity
+	LogicalID string `json:"logicalID"`
+	// EUReferenceNumber is the entity's reference in the EU's legal acts (e.g. EU.27.28)
+	EUReferenceNumber string `json:"euReferenceNumber"`
+	// UnitedNationID is the UN reference of entities which are also listed by the UN Security Council
+	UnitedNationID string `json:"unitedNationID,omitempty"`
+	// Type is either "person" or "enterprise"
+	Type string `json:"type"`
+
+	// Name is the first strong name of the entity written in Latin script
+	Name string `json:"name"`
+	// AlternateNames are every other name of the entity, including names in their original scripts
+	AlternateNames []string `json:"alternateNames"`
+	// NameAliases are each name of the entity as published
+	NameAliases []NameAlias `json:"nameAliases"`
+
+	BirthDates      []BirthDate      `json:"birthDates,omitempty"`
+	Citizenships    []Citizenship    `json:"citizenships,omitempty"`
+	Identifications []Identification `json:"identifications,omitempty"`
+	Addresses       []Address        `json:"addresses,omitempty"`
+
+	// Regulations are the legal acts which listed or amended the entity
+	Regulations []Regulation `json:"regulations"`
+
+	Remark string `json:"remark,omitempty"`
+}
+
+const (
+	PersonType     = "person"
+	EnterpriseType = "enterprise"
+)
+
+// NameAlias is one name of an Entity
+type NameAlias struct {
+	WholeName  string `json:"wholeName"`
+	FirstName  string `json:"firstName,omitempty"`
+	MiddleName string `json:"middleName,omitempty"`
+	LastName   string `json:"lastName,omitempty"`
+	Title      string `json:"title,omitempty"`
+	Function   string `json:"function,omitempty"`
+	Gender     string `json:"gender,omitempty"`
+	// Language is the ISO 639-1 code of the language the name is written in, it's empty for Latin names
+	Language string `json:"language,omitempty"`
+	// Strong is false for weak aliases which are broad or generic
+	Strong bool `json:"strong"`
+}
+
+// BirthDate is a known date and place of birth. Date is empty when only the year is known.
+type BirthDate struct {
+	Date    string `json:"date,omitempty"`
+	Year    string `json:"year,omitempty"`
+	Circa   bool   `json:"circa"`
+	City    string `json:"city,omitempty"`
+	Place   string `json:"place,omitempty"`
+	Country string `json:"country,omitempty"`
+}
+
+// Citizenship is a country an Entity is a citizen of
+type Citizenship struct {
+	Region  string `json:"region,omitempty"`
+	Country string `json:"country"`
+	// CountryCode is the ISO 3166-1 alpha-2 code of Country
+	CountryCode string `json:"countryCode,omitempty"`
+}
+
+// Identification is a passport, national ID or other document of an Entity
+type Identification struct {
+	// Type is the code of the document kind (e.g. passport, id, regnumber)
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Number      string `json:"number"`
+	// LatinNumber is Number transliterated into Latin script
+	LatinNumber    string `json:"latinNumber,omitempty"`
+	NameOnDocument string `json:"nameOnDocument,omitempty"`
+	IssuedBy       string `json:"issuedBy,omitempty"`
+	Country        string `json:"country,omitempty"`
+	Diplomatic     bool   `json:"diplomatic"`
+	KnownExpired   bool   `json:"knownExpired"`
+	KnownFalse     bool   `json:"knownFalse"`
+	ReportedLost   bool   `json:"reportedLost"`
+	Revoked        bool   `json:"revoked"`
+}
+
+// Address is a known address of an Entity
+type Address struct {
+	Street  string `json:"street,omitempty"`
+	City    string `json:"city,omitempty"`
+	ZipCode string `json:"zipCode,omitempty"`
+	Region  string `json:"region,omitempty"`
+	Place   string `json:"place,omitempty"`
+	Country string `json:"country,omitempty"`
+}
+
+// Regulation is a legal act of the EU which an Entity is listed under
+type Regulation struct {
+	// Type is the kind of act (e.g. regulation, amendment)
+	Type string `json:"type"`
+	// Programme is the sanctions regime (e.g. ZWE, RUS, UKR)
+	Programme string `json:"programme"`
+	// NumberTitle is the act's number (e.g. 2021/138 (OJ L43))
+	NumberTitle        string `json:"numberTitle"`
+	PublicationDate    string `json:"publicationDate"`
+	EntryIntoForceDate string `json:"entryIntoForceDate,omitempty"`
+	PublicationURL     string `json:"publicationURL,omitempty"`
+}
@@ -0,0 +1,255 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package eu
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// ReadFile parses the EU FSF XML file (xmlFullSanctionsList_1_1) at path
+func ReadFile(path string) ([]*Entity, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	return Parse(fd)
+}
+
+// Parse reads each sanctionEntity out of the EU FSF XML. Entities are decoded one at a time
+// as the full file is large.
+func Parse(r io.Reader) ([]*Entity, error) {
+	decoder := xml.NewDecoder(r)
+
+	var out []*Entity
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("eu: %v", err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "sanctionEntity" {
+			continue
+		}
+		var entity xmlEntity
+		if err := decoder.DecodeElement(&entity, &start); err != nil {
+			return nil, fmt.Errorf("eu: sanctionEntity: %v", err)
+		}
+		out = append(out, entity.toEntity())
+	}
+	return out, nil
+}
+
+type xmlEntity struct {
+	LogicalID         string `xml:"logicalId,attr"`
+	EUReferenceNumber string `xml:"euReferenceNumber,attr"`
+	UnitedNationID    string `xml:"unitedNationId,attr"`
+	Remark            string `xml:"remark"`
+
+	SubjectType struct {
+		Code string `xml:"code,attr"`
+	} `xml:"subjectType"`
+
+	Regulations []struct {
+		Type               string `xml:"regulationType,attr"`
+		Programme          string `xml:"programme,attr"`
+		NumberTitle        string `xml:"numberTitle,attr"`
+		PublicationDate    string `xml:"publicationDate,attr"`
+		EntryIntoForceDate string `xml:"entryIntoForceDate,attr"`
+		PublicationURL     string `xml:"publicationUrl"`
+	} `xml:"regulation"`
+
+	NameAliases []struct {
+		WholeName  string `xml:"wholeName,attr"`
+		FirstName  string `xml:"firstName,attr"`
+		MiddleName string `xml:"middleName,attr"`
+		LastName   string `xml:"lastName,attr"`
+		Title      string `xml:"title,attr"`
+		Function   string `xml:"function,attr"`
+		Gender     string `xml:"gender,attr"`
+		Language   string `xml:"nameLanguage,attr"`
+		Strong     string `xml:"strong,attr"`
+	} `xml:"nameAlias"`
+
+	Citizenships []struct {
+		Region      string `xml:"region,attr"`
+		CountryCode string `xml:"countryIso2Code,attr"`
+		Country     string `xml:"countryDescription,attr"`
+	} `xml:"citizenship"`
+
+	BirthDates []struct {
+		Date    string `xml:"birthdate,attr"`
+		Year    string `xml:"year,attr"`
+		Circa   string `xml:"circa,attr"`
+		City    string `xml:"city,attr"`
+		Place   string `xml:"place,attr"`
+		Country string `xml:"countryDescription,attr"`
+	} `xml:"birthdate"`
+
+	Identifications []struct {
+		Type           string `xml:"identificationTypeCode,attr"`
+		Description    string `xml:"identificationTypeDescription,attr"`
+		Number         string `xml:"number,attr"`
+		LatinNumber    string `xml:"latinNumber,attr"`
+		NameOnDocument string `xml:"nameOnDocument,attr"`
+		IssuedBy       string `xml:"issuedBy,attr"`
+		Country        string `xml:"countryDescription,attr"`
+		Diplomatic     string `xml:"diplomatic,attr"`
+		KnownExpired   string `xml:"knownExpired,attr"`
+		KnownFalse     string `xml:"knownFalse,attr"`
+		ReportedLost   string `xml:"reportedLost,attr"`
+		Revoked        string `xml:"revokedByIssuer,attr"`
+	} `xml:"identification"`
+
+	Addresses []struct {
+		Street  string `xml:"street,attr"`
+		City    string `xml:"city,attr"`
+		ZipCode string `xml:"zipCode,attr"`
+		Region  string `xml:"region,attr"`
+		Place   string `xml:"place,attr"`
+		Country string `xml:"countryDescription,attr"`
+	} `xml:"address"`
+}
+
+func (x *xmlEntity) toEntity() *Entity {
+	entity := &Entity{
+		LogicalID:         x.LogicalID,
+		EUReferenceNumber: x.EUReferenceNumber,
+		UnitedNationID:    x.UnitedNationID,
+		Type:              x.SubjectType.Code,
+		Remark:            strings.TrimSpace(x.Remark),
+	}
+
+	for _, reg := range x.Regulations {
+		entity.Regulations = append(entity.Regulations, Regulation{
+			Type:               reg.Type,
+			Programme:          reg.Programme,
+			NumberTitle:        reg.NumberTitle,
+			PublicationDate:    reg.PublicationDate,
+			EntryIntoForceDate: reg.EntryIntoForceDate,
+			PublicationURL:     strings.TrimSpace(reg.PublicationURL),
+		})
+	}
+
+	for _, alias := range x.NameAliases {
+		name := NameAlias{
+			WholeName:  strings.TrimSpace(alias.WholeName),
+			FirstName:  alias.FirstName,
+			MiddleName: alias.MiddleName,
+			LastName:   alias.LastName,
+			Title:      alias.Title,
+			Function:   alias.Function,
+			Gender:     alias.Gender,
+			Language:   alias.Language,
+			Strong:     alias.Strong != "false",
+		}
+		if name.WholeName == "" {
+			name.WholeName = strings.Join(strings.Fields(strings.Join([]string{name.FirstName, name.MiddleName, name.LastName}, " ")), " ")
+		}
+		if name.WholeName == "" {
+			continue
+		}
+		entity.NameAliases = append(entity.NameAliases, name)
+	}
+	entity.Name, entity.AlternateNames = primaryName(entity.NameAliases)
+
+	for _, c := range x.Citizenships {
+		entity.Citizenships = append(entity.Citizenships, Citizenship{
+			Region:      c.Region,
+			Country:     c.Country,
+			CountryCode: c.CountryCode,
+		})
+	}
+	for _, b := range x.BirthDates {
+		entity.BirthDates = append(entity.BirthDates, BirthDate{
+			Date:    b.Date,
+			Year:    b.Year,
+			Circa:   b.Circa == "true",
+			City:    b.City,
+			Place:   b.Place,
+			Country: b.Country,
+		})
+	}
+	for _, id := range x.Identifications {
+		entity.Identifications = append(entity.Identifications, Identification{
+			Type:           id.Type,
+			Description:    id.Description,
+			Number:         id.Number,
+			LatinNumber:    id.LatinNumber,
+			NameOnDocument: id.NameOnDocument,
+			IssuedBy:       id.IssuedBy,
+			Country:        id.Country,
+			Diplomatic:     id.Diplomatic == "true",
+			KnownExpired:   id.KnownExpired == "true",
+			KnownFalse:     id.KnownFalse == "true",
+			ReportedLost:   id.ReportedLost == "true",
+			Revoked:        id.Revoked == "true",
+		})
+	}
+	for _, addr := range x.Addresses {
+		entity.Addresses = append(entity.Addresses, Address{
+			Street:  addr.Street,
+			City:    addr.City,
+			ZipCode: addr.ZipCode,
+			Region:  addr.Region,
+			Place:   addr.Place,
+			Country: addr.Country,
+		})
+	}
+
+	return entity
+}
+
+// primaryName picks the first strong Latin name of aliases and returns every other
+// distinct name as alternates, keeping names in their original scripts.
+func primaryName(aliases []NameAlias) (string, []string) {
+	primary := -1
+	for i := range aliases {
+		if aliases[i].Strong && isLatin(aliases[i].WholeName) {
+			primary = i
+			break
+		}
+	}
+	if primary < 0 && len(aliases) > 0 {
+		primary = 0
+	}
+	if primary < 0 {
+		return "", nil
+	}
+
+	name := aliases[primary].WholeName
+	seen := map[string]bool{strings.ToLower(name): true}
+
+	var alts []string
+	for i := range aliases {
+		key := strings.ToLower(aliases[i].WholeName)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		alts = append(alts, aliases[i].WholeName)
+	}
+	return name, alts
+}
+
+// isLatin returns false if any letter of s is outside of the Latin scripts
+func isLatin(s string) bool {
+	for _, r := range s {
+		if unicode.IsLetter(r) && !unicode.Is(unicode.Latin, r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package eu
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRead(t *testing.T) {
+	entities, err := ReadFile(filepath.Join("..", "..", "test", "testdata", "eu_fsf.xml"))
+	require.NoError(t, err)
+	require.Len(t, entities, 3)
+
+	mugabe := entities[0]
+	require.Equal(t, "13", mugabe.LogicalID)
+	require.Equal(t, "EU.27.28", mugabe.EUReferenceNumber)
+	require.Equal(t, PersonType, mugabe.Type)
+	require.Equal(t, "Robert Gabriel Mugabe", mugabe.Name)
+	require.Empty(t, mugabe.AlternateNames)
+	require.Equal(t, []BirthDate{{Date: "1924-02-21", Year: "1924", City: "Kutama", Country: "ZIMBABWE"}}, mugabe.BirthDates)
+	require.Equal(t, []Citizenship{{Country: "ZIMBABWE", CountryCode: "ZW"}}, mugabe.Citizenships)
+	require.Len(t, mugabe.Identifications, 1)
+	require.Equal(t, "passport", mugabe.Identifications[0].Type)
+	require.Equal(t, "AD001095", mugabe.Identifications[0].Number)
+	require.Len(t, mugabe.Regulations, 1)
+	require.Equal(t, "ZWE", mugabe.Regulations[0].Programme)
+	require.Equal(t, "2022/227 (OJ L40)", mugabe.Regulations[0].NumberTitle)
+	require.True(t, strings.HasPrefix(mugabe.Regulations[0].PublicationURL, "https://eur-lex.europa.eu/"))
+
+	vasilyev := entities[1]
+	require.Equal(t, "Vladimir Abdualiyevich Vasilyev", vasilyev.Name)
+	require.Equal(t, []string{"Владимир Абдуалиевич Васильев", "Vladimir Vasiliev"}, vasilyev.AlternateNames)
+	require.Equal(t, "RU", vasilyev.NameAliases[1].Language)
+	require.False(t, vasilyev.NameAliases[2].Strong)
+	require.Equal(t, "Member of the State Duma.", vasilyev.Remark)
+	require.Len(t, vasilyev.BirthDates, 2)
+	require.True(t, vasilyev.BirthDates[1].Circa)
+	require.Equal(t, "1950", vasilyev.BirthDates[1].Year)
+
+	trust := entities[2]
+	require.Equal(t, EnterpriseType, trust.Type)
+	require.Equal(t, "QDe.004", trust.UnitedNationID)
+	require.Equal(t, "Al-Rashid Trust", trust.Name)
+	require.Equal(t, []string{"Al Rasheed Trust", "الرشيد ترسټ"}, trust.AlternateNames)
+	require.Equal(t, []Address{{Street: "Kitab Ghar, Darul Ifta Wal Irshad, Nazimabad No. 4", City: "Karachi", Country: "PAKISTAN"}}, trust.Addresses)
+}
+
+func TestParse__originalScriptFirst(t *testing.T) {
+	entities, err := Parse(strings.NewReader(`<export><sanctionEntity logicalId="1">
+<subjectType code="person"/>
+<nameAlias wholeName="Иван Иванов" nameLanguage="RU" strong="true"/>
+<nameAlias wholeName="Ivan Ivanov" strong="true"/>
+</sanctionEntity></export>`))
+	require.NoError(t, err)
+	require.Len(t, entities, 1)
+	require.Equal(t, "Ivan Ivanov", entities[0].Name)
+	require.Equal(t, []string{"Иван Иванов"}, entities[0].AlternateNames)
+}
+
+func TestParse__invalid(t *testing.T) {
+	_, err := Parse(strings.NewReader(`<export><sanctionEntity logicalId="1">`))
+	require.Error(t, err)
+}
@@ -0,0 +1,97 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package eu
+
+import (
+	"context"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/source"
+)
+
+func init() {
+	source.Register(Source{})
+}
+
+// Source publishes the EU Consolidated Financial Sanctions list
+type Source struct{}
+
+func (Source) Name() string {
+	return "EU"
+}
+
+func (Source) Filenames() []string {
+	return []string{Filename}
+}
+
+func (Source) Download(ctx context.Context, logger log.Logger, initialDir string) ([]string, error) {
+	file, err := Download(ctx, logger, initialDir)
+	if err != nil {
+		return nil, err
+	}
+	return []string{file}, nil
+}
+
+func (Source) Read(files []string) ([]source.List, error) {
+	file, err := source.File(files, Filename)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return []source.List{
+		{Name: "EU", Entities: source.Entities(entities)},
+	}, nil
+}
+
+// ToEntity converts the entity into a source.Entity of the EU list
+func (e *Entity) ToEntity() *source.Entity {
+	out := &source.Entity{
+		Name:           e.Name,
+		AlternateNames: e.AlternateNames,
+		Type:           source.ParseEntityType(e.Type),
+		SourceList:     "EU",
+		SourceID:       e.LogicalID,
+		SourceData:     e,
+	}
+	for _, addr := range e.Addresses {
+		if a := source.JoinParts(addr.Street, addr.City, addr.ZipCode, addr.Region, addr.Place, addr.Country); a != "" {
+			out.Addresses = append(out.Addresses, a)
+		}
+	}
+	for _, id := range e.Identifications {
+		if id.Number != "" {
+			out.IDs = append(out.IDs, source.JoinParts(id.Number, id.Description, id.Country))
+		}
+	}
+	for _, dob := range e.BirthDates {
+		if dob.Date != "" {
+			out.DatesOfBirth = append(out.DatesOfBirth, dob.Date)
+		} else if dob.Year != "" {
+			out.DatesOfBirth = append(out.DatesOfBirth, dob.Year)
+		}
+	}
+	for _, reg := range e.Regulations {
+		if reg.Programme != "" && !contains(out.Programs, reg.Programme) {
+			out.Programs = append(out.Programs, reg.Programme)
+		}
+		// The earliest act is the one which listed the entity
+		if out.ListedOn == "" || (reg.PublicationDate != "" && reg.PublicationDate < out.ListedOn) {
+			out.ListedOn = reg.PublicationDate
+		}
+	}
+	return out
+}
+
+func contains(xs []string, x string) bool {
+	for i := range xs {
+		if xs[i] == x {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ofac
+
+// EntityDetails are the structured details of a Specially Designated National which are only
+// published in the advanced XML format (SDN_ADVANCED.XML). The CSV files flatten most of these
+// into Remarks.
+type EntityDetails struct {
+	// EntityID matches the SDN's EntityID
+	EntityID string `json:"entityID"`
+
+	// Names are every documented name of the entity, in each script it's published in
+	Names []DocumentedName `json:"names"`
+
+	// Documents are passports, national IDs, registration numbers and so on
+	Documents []IdentityDocument `json:"documents,omitempty"`
+
+	// Features are facts about the entity such as birthdates, places of birth and nationalities
+	Features []Feature `json:"features,omitempty"`
+
+	// Relationships are links from this entity to other entities
+	Relationships []Relationship `json:"relationships,omitempty"`
+}
+
+// DocumentedName is one name of an entity as published, split into its parts
+type DocumentedName struct {
+	// AliasType is how the name is used (e.g. Name, A.K.A., F.K.A., N.K.A.)
+	AliasType string `json:"aliasType"`
+	// Primary is set for the entity's primary name
+	Primary bool `json:"primary"`
+	// LowQuality is set for weak aliases which are broad or generic
+	LowQuality bool `json:"lowQuality"`
+
+	// FullName is each part of the name combined, last name first for individuals
+	FullName string     `json:"fullName"`
+	Parts    []NamePart `json:"parts"`
+
+	// Script is the name of the writing system (e.g. Latin, Arabic) and ScriptCode its ISO 15924 code (e.g. Latn, Arab)
+	Script     string `json:"script"`
+	ScriptCode string `json:"scriptCode"`
+}
+
+// NamePart is one part of a DocumentedName
+type NamePart struct {
+	// Type is what the part is (e.g. Last Name, First Name, Patronymic, Entity Name, Vessel Name)
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// IdentityDocument is an identification or registration document of an entity
+type IdentityDocument struct {
+	// Type is the kind of document (e.g. Passport, National ID No., Registration Number)
+	Type             string `json:"type"`
+	Number           string `json:"number"`
+	IssuingCountry   string `json:"issuingCountry,omitempty"`
+	IssuingAuthority string `json:"issuingAuthority,omitempty"`
+	IssueDate        string `json:"issueDate,omitempty"`
+	ExpirationDate   string `json:"expirationDate,omitempty"`
+	Comment          string `json:"comment,omitempty"`
+}
+
+// Feature is a fact about an entity
+type Feature struct {
+	// Type is what the feature describes (e.g. Birthdate, Place of Birth, Nationality Country, Gender)
+	Type    string `json:"type"`
+	Value   string `json:"value"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// Relationship links an entity to another entity
+type Relationship struct {
+	// Type is how the entities are related (e.g. Associate Of, Owned or Controlled By)
+	Type string `json:"type"`
+	// EntityID is the related entity
+	EntityID string `json:"entityID"`
+	// Former is set when the relationship no longer exists
+	Former bool `json:"former"`
+}
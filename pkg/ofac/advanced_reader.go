@@ -0,0 +1,576 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ofac
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// The types below mirror the parts of the OFAC Advanced Sanctions Data Standard we read.
+// Most values are references (by ID) into ReferenceValueSets.
+//
+// See: https://ofac.treasury.gov/sanctions-list-service
+
+type xmlSanctions struct {
+	ReferenceValueSets   xmlReferenceValueSets    `xml:"ReferenceValueSets"`
+	Locations            []xmlLocation            `xml:"Locations>Location"`
+	IDRegDocuments       []xmlIDRegDocument       `xml:"IDRegDocuments>IDRegDocument"`
+	DistinctParties      []xmlDistinctParty       `xml:"DistinctParties>DistinctParty"`
+	ProfileRelationships []xmlProfileRelationship `xml:"ProfileRelationships>ProfileRelationship"`
+	SanctionsEntries     []xmlSanctionsEntry      `xml:"SanctionsEntries>SanctionsEntry"`
+}
+
+type xmlReferenceValueSets struct {
+	AliasTypes        []xmlReferenceValue `xml:"AliasTypeValues>AliasType"`
+	Countries         []xmlReferenceValue `xml:"CountryValues>Country"`
+	DetailReferences  []xmlReferenceValue `xml:"DetailReferenceValues>DetailReference"`
+	FeatureTypes      []xmlReferenceValue `xml:"FeatureTypeValues>FeatureType"`
+	IDRegDocDateTypes []xmlReferenceValue `xml:"IDRegDocDateTypeValues>IDRegDocDateType"`
+	IDRegDocTypes     []xmlReferenceValue `xml:"IDRegDocTypeValues>IDRegDocType"`
+	LocPartTypes      []xmlReferenceValue `xml:"LocPartTypeValues>LocPartType"`
+	NamePartTypes     []xmlReferenceValue `xml:"NamePartTypeValues>NamePartType"`
+	PartySubTypes     []xmlReferenceValue `xml:"PartySubTypeValues>PartySubType"`
+	PartyTypes        []xmlReferenceValue `xml:"PartyTypeValues>PartyType"`
+	RelationTypes     []xmlReferenceValue `xml:"RelationTypeValues>RelationType"`
+	SanctionsTypes    []xmlReferenceValue `xml:"SanctionsTypeValues>SanctionsType"`
+	Scripts           []xmlReferenceValue `xml:"ScriptValues>Script"`
+}
+
+type xmlReferenceValue struct {
+	ID          string `xml:"ID,attr"`
+	PartyTypeID string `xml:"PartyTypeID,attr"`
+	ScriptCode  string `xml:"ScriptCode,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type xmlLocation struct {
+	ID        string `xml:"ID,attr"`
+	Countries []struct {
+		CountryID string `xml:"CountryID,attr"`
+	} `xml:"LocationCountry"`
+	Parts []struct {
+		TypeID string `xml:"LocPartTypeID,attr"`
+		Values []struct {
+			Primary bool   `xml:"Primary,attr"`
+			Value   string `xml:"Value"`
+		} `xml:"LocationPartValue"`
+	} `xml:"LocationPart"`
+}
+
+type xmlIDRegDocument struct {
+	ID                string `xml:"ID,attr"`
+	TypeID            string `xml:"IDRegDocTypeID,attr"`
+	IdentityID        string `xml:"IdentityID,attr"`
+	IssuedByCountryID string `xml:"IssuedBy-CountryID,attr"`
+	Number            string `xml:"IDRegistrationNo"`
+	IssuingAuthority  string `xml:"IssuingAuthority"`
+	Comment           string `xml:"Comment"`
+	Dates             []struct {
+		TypeID string        `xml:"IDRegDocDateTypeID,attr"`
+		Period xmlDatePeriod `xml:"DatePeriod"`
+	} `xml:"DocumentDate"`
+}
+
+type xmlDistinctParty struct {
+	FixedRef string       `xml:"FixedRef,attr"`
+	Comment  string       `xml:"Comment"`
+	Profiles []xmlProfile `xml:"Profile"`
+}
+
+type xmlProfile struct {
+	ID             string        `xml:"ID,attr"`
+	PartySubTypeID string        `xml:"PartySubTypeID,attr"`
+	Identities     []xmlIdentity `xml:"Identity"`
+	Features       []xmlFeature  `xml:"Feature"`
+}
+
+type xmlIdentity struct {
+	ID             string     `xml:"ID,attr"`
+	Primary        bool       `xml:"Primary,attr"`
+	Aliases        []xmlAlias `xml:"Alias"`
+	NamePartGroups []struct {
+		ID             string `xml:"ID,attr"`
+		NamePartTypeID string `xml:"NamePartTypeID,attr"`
+	} `xml:"NamePartGroups>MasterNamePartGroup>NamePartGroup"`
+}
+
+type xmlAlias struct {
+	AliasTypeID string `xml:"AliasTypeID,attr"`
+	Primary     bool   `xml:"Primary,attr"`
+	LowQuality  bool   `xml:"LowQuality,attr"`
+	Names       []struct {
+		ID    string `xml:"ID,attr"`
+		Parts []struct {
+			GroupID  string `xml:"NamePartGroupID,attr"`
+			ScriptID string `xml:"ScriptID,attr"`
+			Value    string `xml:",chardata"`
+		} `xml:"DocumentedNamePart>NamePartValue"`
+	} `xml:"DocumentedName"`
+}
+
+type xmlFeature struct {
+	ID       string `xml:"ID,attr"`
+	TypeID   string `xml:"FeatureTypeID,attr"`
+	Versions []struct {
+		Comment string          `xml:"Comment"`
+		Periods []xmlDatePeriod `xml:"DatePeriod"`
+		Details []struct {
+			TypeID      string `xml:"DetailTypeID,attr"`
+			ReferenceID string `xml:"DetailReferenceID,attr"`
+			Value       string `xml:",chardata"`
+		} `xml:"VersionDetail"`
+		Locations []struct {
+			LocationID string `xml:"LocationID,attr"`
+		} `xml:"VersionLocation"`
+	} `xml:"FeatureVersion"`
+}
+
+type xmlProfileRelationship struct {
+	FromProfileID  string `xml:"From-ProfileID,attr"`
+	ToProfileID    string `xml:"To-ProfileID,attr"`
+	RelationTypeID string `xml:"RelationTypeID,attr"`
+	Former         bool   `xml:"Former,attr"`
+}
+
+type xmlSanctionsEntry struct {
+	ProfileID string `xml:"ProfileID,attr"`
+	Measures  []struct {
+		TypeID  string `xml:"SanctionsTypeID,attr"`
+		Comment string `xml:"Comment"`
+	} `xml:"SanctionsMeasure"`
+}
+
+type xmlDatePeriod struct {
+	Start xmlDateBoundary `xml:"Start"`
+	End   xmlDateBoundary `xml:"End"`
+}
+
+type xmlDateBoundary struct {
+	From xmlDate `xml:"From"`
+	To   xmlDate `xml:"To"`
+}
+
+type xmlDate struct {
+	Year  int `xml:"Year"`
+	Month int `xml:"Month"`
+	Day   int `xml:"Day"`
+}
+
+func (d xmlDate) String() string {
+	switch {
+	case d.Year == 0:
+		return ""
+	case d.Month == 0:
+		return fmt.Sprintf("%d", d.Year)
+	case d.Day == 0:
+		return time.Date(d.Year, time.Month(d.Month), 1, 0, 0, 0, 0, time.UTC).Format("Jan 2006")
+	}
+	return time.Date(d.Year, time.Month(d.Month), d.Day, 0, 0, 0, 0, time.UTC).Format("02 Jan 2006")
+}
+
+// String formats the period like the CSV remarks do, e.g. "05 May 1962" or "1960 to 1962"
+func (p xmlDatePeriod) String() string {
+	from, to := p.Start.From.String(), p.End.To.String()
+	if to == "" {
+		to = p.Start.To.String()
+	}
+	if from == "" || to == "" || from == to {
+		if from != "" {
+			return from
+		}
+		return to
+	}
+	return from + " to " + to
+}
+
+// advancedReader converts the parsed XML into Results
+type advancedReader struct {
+	doc *xmlSanctions
+
+	aliasTypes        map[string]xmlReferenceValue
+	countries         map[string]xmlReferenceValue
+	detailReferences  map[string]xmlReferenceValue
+	featureTypes      map[string]xmlReferenceValue
+	idRegDocDateTypes map[string]xmlReferenceValue
+	idRegDocTypes     map[string]xmlReferenceValue
+	locPartTypes      map[string]xmlReferenceValue
+	namePartTypes     map[string]xmlReferenceValue
+	partySubTypes     map[string]xmlReferenceValue
+	partyTypes        map[string]xmlReferenceValue
+	relationTypes     map[string]xmlReferenceValue
+	sanctionsTypes    map[string]xmlReferenceValue
+	scripts           map[string]xmlReferenceValue
+
+	locations     map[string]xmlLocation
+	documents     map[string][]xmlIDRegDocument // by IdentityID
+	relationships map[string][]xmlProfileRelationship
+	entries       map[string][]xmlSanctionsEntry
+}
+
+func referenceValues(values []xmlReferenceValue) map[string]xmlReferenceValue {
+	out := make(map[string]xmlReferenceValue, len(values))
+	for i := range values {
+		values[i].Value = strings.TrimSpace(values[i].Value)
+		out[values[i].ID] = values[i]
+	}
+	return out
+}
+
+func advancedXMLFile(path string) (*Results, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	var doc xmlSanctions
+	if err := xml.NewDecoder(fd).Decode(&doc); err != nil {
+		return nil, err
+	}
+	return newAdvancedReader(&doc).results(), nil
+}
+
+func newAdvancedReader(doc *xmlSanctions) *advancedReader {
+	refs := doc.ReferenceValueSets
+	r := &advancedReader{
+		doc:               doc,
+		aliasTypes:        referenceValues(refs.AliasTypes),
+		countries:         referenceValues(refs.Countries),
+		detailReferences:  referenceValues(refs.DetailReferences),
+		featureTypes:      referenceValues(refs.FeatureTypes),
+		idRegDocDateTypes: referenceValues(refs.IDRegDocDateTypes),
+		idRegDocTypes:     referenceValues(refs.IDRegDocTypes),
+		locPartTypes:      referenceValues(refs.LocPartTypes),
+		namePartTypes:     referenceValues(refs.NamePartTypes),
+		partySubTypes:     referenceValues(refs.PartySubTypes),
+		partyTypes:        referenceValues(refs.PartyTypes),
+		relationTypes:     referenceValues(refs.RelationTypes),
+		sanctionsTypes:    referenceValues(refs.SanctionsTypes),
+		scripts:           referenceValues(refs.Scripts),
+
+		locations:     make(map[string]xmlLocation),
+		documents:     make(map[string][]xmlIDRegDocument),
+		relationships: make(map[string][]xmlProfileRelationship),
+		entries:       make(map[string][]xmlSanctionsEntry),
+	}
+	for _, loc := range doc.Locations {
+		r.locations[loc.ID] = loc
+	}
+	for _, d := range doc.IDRegDocuments {
+		r.documents[d.IdentityID] = append(r.documents[d.IdentityID], d)
+	}
+	for _, rel := range doc.ProfileRelationships {
+		r.relationships[rel.FromProfileID] = append(r.relationships[rel.FromProfileID], rel)
+	}
+	for _, entry := range doc.SanctionsEntries {
+		r.entries[entry.ProfileID] = append(r.entries[entry.ProfileID], entry)
+	}
+	return r
+}
+
+func (r *advancedReader) results() *Results {
+	res := &Results{}
+	for _, party := range r.doc.DistinctParties {
+		for _, profile := range party.Profiles {
+			r.readProfile(res, party, profile)
+		}
+	}
+	return res
+}
+
+func (r *advancedReader) readProfile(res *Results, party xmlDistinctParty, profile xmlProfile) {
+	sdnType := r.sdnType(profile.PartySubTypeID)
+	sdn := &SDN{
+		EntityID: profile.ID,
+		SDNType:  sdnType,
+		Programs: r.programs(profile.ID),
+	}
+	details := &EntityDetails{
+		EntityID: profile.ID,
+	}
+
+	var remarks []string
+	for _, identity := range profile.Identities {
+		groups := make(map[string]string)
+		for _, g := range identity.NamePartGroups {
+			groups[g.ID] = r.namePartTypes[g.NamePartTypeID].Value
+		}
+
+		for _, alias := range identity.Aliases {
+			aliasType := r.aliasTypes[alias.AliasTypeID].Value
+			for _, name := range alias.Names {
+				documented := DocumentedName{
+					AliasType:  aliasType,
+					Primary:    alias.Primary && identity.Primary,
+					LowQuality: alias.LowQuality,
+				}
+				for _, part := range name.Parts {
+					documented.Parts = append(documented.Parts, NamePart{
+						Type:  groups[part.GroupID],
+						Value: strings.TrimSpace(part.Value),
+					})
+					if documented.Script == "" {
+						script := r.scripts[part.ScriptID]
+						documented.Script, documented.ScriptCode = script.Value, script.ScriptCode
+					}
+				}
+				documented.FullName = fullName(documented.Parts, sdnType == "individual")
+				details.Names = append(details.Names, documented)
+
+				// The CSV files only contain names in Latin script
+				if documented.ScriptCode != "" && documented.ScriptCode != "Latn" {
+					continue
+				}
+				if documented.Primary && sdn.SDNName == "" {
+					sdn.SDNName = documented.FullName
+					continue
+				}
+				res.AlternateIdentities = append(res.AlternateIdentities, &AlternateIdentity{
+					EntityID:         profile.ID,
+					AlternateID:      name.ID,
+					AlternateType:    alternateType(aliasType),
+					AlternateName:    documented.FullName,
+					AlternateRemarks: lowQualityRemark(alias.LowQuality),
+				})
+			}
+		}
+
+		for _, d := range r.documents[identity.ID] {
+			doc := IdentityDocument{
+				Type:             r.idRegDocTypes[d.TypeID].Value,
+				Number:           strings.TrimSpace(d.Number),
+				IssuingCountry:   r.countries[d.IssuedByCountryID].Value,
+				IssuingAuthority: strings.TrimSpace(d.IssuingAuthority),
+				Comment:          strings.TrimSpace(d.Comment),
+			}
+			for _, date := range d.Dates {
+				switch r.idRegDocDateTypes[date.TypeID].Value {
+				case "Issue Date":
+					doc.IssueDate = date.Period.String()
+				case "Expiration Date":
+					doc.ExpirationDate = date.Period.String()
+				}
+			}
+			details.Documents = append(details.Documents, doc)
+			remarks = append(remarks, documentRemark(doc))
+		}
+	}
+
+	for _, feature := range profile.Features {
+		featureType := r.featureTypes[feature.TypeID].Value
+		for _, version := range feature.Versions {
+			var values []string
+			for _, period := range version.Periods {
+				values = append(values, period.String())
+			}
+			for _, detail := range version.Details {
+				if detail.ReferenceID != "" {
+					values = append(values, r.detailReferences[detail.ReferenceID].Value)
+				} else {
+					values = append(values, strings.TrimSpace(detail.Value))
+				}
+			}
+			for _, loc := range version.Locations {
+				location, exists := r.locations[loc.LocationID]
+				if !exists {
+					continue
+				}
+				if strings.EqualFold(featureType, "Location") {
+					res.Addresses = append(res.Addresses, r.address(profile.ID, location))
+				}
+				values = append(values, r.formatLocation(location))
+			}
+			value := strings.Join(nonEmpty(values), ", ")
+			details.Features = append(details.Features, Feature{
+				Type:    featureType,
+				Value:   value,
+				Comment: strings.TrimSpace(version.Comment),
+			})
+
+			switch strings.ToLower(featureType) {
+			case "location":
+				// read into Addresses
+			case "title":
+				sdn.Title = value
+			case "vessel call sign":
+				sdn.CallSign = value
+			case "vessel type":
+				sdn.VesselType = value
+			case "vessel tonnage":
+				sdn.Tonnage = value
+			case "vessel gross registered tonnage":
+				sdn.GrossRegisteredTonnage = value
+			case "vessel flag":
+				sdn.VesselFlag = value
+			case "vessel owner":
+				sdn.VesselOwner = value
+			default:
+				if value != "" {
+					remarks = append(remarks, featureRemark(featureType)+" "+value)
+				}
+			}
+		}
+	}
+
+	for _, rel := range r.relationships[profile.ID] {
+		details.Relationships = append(details.Relationships, Relationship{
+			Type:     r.relationTypes[rel.RelationTypeID].Value,
+			EntityID: rel.ToProfileID,
+			Former:   rel.Former,
+		})
+	}
+
+	if comment := strings.TrimSpace(party.Comment); comment != "" {
+		remarks = append(remarks, comment)
+	}
+	if len(remarks) > 0 {
+		sdn.Remarks = strings.Join(remarks, "; ") + "."
+	}
+
+	res.SDNs = append(res.SDNs, sdn)
+	res.Details = append(res.Details, details)
+}
+
+// sdnType returns the SDN_Type used in sdn.csv, which is empty for entities
+func (r *advancedReader) sdnType(partySubTypeID string) string {
+	sub := r.partySubTypes[partySubTypeID]
+	value := sub.Value
+	if strings.EqualFold(value, "Unknown") || value == "" {
+		value = r.partyTypes[sub.PartyTypeID].Value
+	}
+	if strings.EqualFold(value, "Entity") {
+		return ""
+	}
+	return strings.ToLower(value)
+}
+
+func (r *advancedReader) programs(profileID string) []string {
+	var out []string
+	for _, entry := range r.entries[profileID] {
+		for _, measure := range entry.Measures {
+			if strings.EqualFold(r.sanctionsTypes[measure.TypeID].Value, "Program") {
+				if program := strings.TrimSpace(measure.Comment); program != "" {
+					out = append(out, program)
+				}
+			}
+		}
+	}
+	return out
+}
+
+func (r *advancedReader) locationParts(loc xmlLocation) map[string]string {
+	parts := make(map[string]string)
+	for _, part := range loc.Parts {
+		for _, v := range part.Values {
+			key := strings.ToUpper(r.locPartTypes[part.TypeID].Value)
+			if _, exists := parts[key]; !exists || v.Primary {
+				parts[key] = strings.TrimSpace(v.Value)
+			}
+		}
+	}
+	return parts
+}
+
+func (r *advancedReader) locationCountry(loc xmlLocation) string {
+	for _, c := range loc.Countries {
+		if country := r.countries[c.CountryID].Value; country != "" {
+			return country
+		}
+	}
+	return ""
+}
+
+func (r *advancedReader) address(entityID string, loc xmlLocation) *Address {
+	parts := r.locationParts(loc)
+
+	city := strings.Join(nonEmpty([]string{parts["CITY"], parts["STATE/PROVINCE"]}), ", ")
+	city = strings.Join(nonEmpty([]string{city, parts["POSTAL CODE"]}), " ")
+
+	return &Address{
+		EntityID:                    entityID,
+		AddressID:                   loc.ID,
+		Address:                     strings.Join(nonEmpty([]string{parts["ADDRESS1"], parts["ADDRESS2"], parts["ADDRESS3"]}), ", "),
+		CityStateProvincePostalCode: city,
+		Country:                     r.locationCountry(loc),
+		AddressRemarks:              parts["REGION"],
+	}
+}
+
+func (r *advancedReader) formatLocation(loc xmlLocation) string {
+	parts := r.locationParts(loc)
+	return strings.Join(nonEmpty([]string{
+		parts["ADDRESS1"], parts["ADDRESS2"], parts["ADDRESS3"],
+		parts["CITY"], parts["STATE/PROVINCE"], parts["POSTAL CODE"], parts["REGION"],
+		r.locationCountry(loc),
+	}), ", ")
+}
+
+// fullName combines name parts how sdn.csv does, as "LAST, First Middle" for individuals
+func fullName(parts []NamePart, individual bool) string {
+	var last, rest []string
+	for _, part := range parts {
+		if individual && strings.EqualFold(part.Type, "Last Name") {
+			last = append(last, part.Value)
+		} else {
+			rest = append(rest, part.Value)
+		}
+	}
+	if len(last) > 0 && len(rest) > 0 {
+		return strings.Join(last, " ") + ", " + strings.Join(rest, " ")
+	}
+	return strings.Join(append(last, rest...), " ")
+}
+
+// alternateType returns the alt_type used in alt.csv (aka, fka, nka)
+func alternateType(aliasType string) string {
+	return strings.ToLower(strings.ReplaceAll(aliasType, ".", ""))
+}
+
+func lowQualityRemark(lowQuality bool) string {
+	if lowQuality {
+		return "weak"
+	}
+	return ""
+}
+
+func documentRemark(doc IdentityDocument) string {
+	remark := strings.TrimSpace(doc.Type + " " + doc.Number)
+	if doc.IssuingCountry != "" {
+		remark += " (" + doc.IssuingCountry + ")"
+	}
+	if doc.ExpirationDate != "" {
+		remark += " expires " + doc.ExpirationDate
+	}
+	return remark
+}
+
+// featureRemark abbreviates feature types like the CSV remarks
+func featureRemark(featureType string) string {
+	switch strings.ToLower(featureType) {
+	case "birthdate":
+		return "DOB"
+	case "place of birth":
+		return "POB"
+	case "nationality country":
+		return "nationality"
+	case "citizenship country":
+		return "citizen"
+	}
+	return featureType
+}
+
+func nonEmpty(in []string) []string {
+	var out []string
+	for i := range in {
+		if s := strings.TrimSpace(in[i]); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,151 @@
+// Copyright 2020 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ofac
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestOFAC__readAdvanced(t *testing.T) {
+	res, err := Read(filepath.Join("testdata", "sdn_advanced.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.SDNs) != 3 || len(res.Details) != 3 {
+		t.Fatalf("found %d SDNs and %d details", len(res.SDNs), len(res.Details))
+	}
+
+	// entity
+	bank := res.SDNs[0]
+	if bank.EntityID != "306" || bank.SDNName != "BANCO NACIONAL DE CUBA" || bank.SDNType != "" || bank.SourceList != SDNList {
+		t.Errorf("unexpected SDN: %#v", bank)
+	}
+	if !reflect.DeepEqual(bank.Programs, []string{"CUBA"}) {
+		t.Errorf("programs: %v", bank.Programs)
+	}
+	if len(res.Addresses) != 1 {
+		t.Fatalf("found %d addresses", len(res.Addresses))
+	}
+	expectedAddress := &Address{
+		EntityID:                    "306",
+		AddressID:                   "2001",
+		Address:                     "Calle 50",
+		CityStateProvincePostalCode: "Panama City",
+		Country:                     "Panama",
+	}
+	if !reflect.DeepEqual(res.Addresses[0], expectedAddress) {
+		t.Errorf("address: %#v", res.Addresses[0])
+	}
+
+	// individual
+	person := res.SDNs[1]
+	if person.SDNName != "AL-HASSAN, Ali" || person.SDNType != "individual" {
+		t.Errorf("unexpected SDN: %#v", person)
+	}
+	if !reflect.DeepEqual(person.Programs, []string{"SDGT", "IRGC"}) {
+		t.Errorf("programs: %v", person.Programs)
+	}
+	expectedRemarks := "Passport D9001234 (Iran) expires 15 Jan 2030; DOB 05 May 1962; POB Tehran, Iran; Gender Male; Linked To: BANCO NACIONAL DE CUBA."
+	if person.Remarks != expectedRemarks {
+		t.Errorf("remarks: %q", person.Remarks)
+	}
+
+	// vessel
+	vessel := res.SDNs[2]
+	if vessel.SDNName != "OCEAN STAR" || vessel.SDNType != "vessel" || vessel.CallSign != "HOXA" ||
+		vessel.VesselType != "Bulk Carrier" || vessel.VesselFlag != "Panama" || vessel.Tonnage != "25,000" {
+		t.Errorf("unexpected SDN: %#v", vessel)
+	}
+
+	// alternate names only include Latin script names
+	expectedAlts := []*AlternateIdentity{
+		{EntityID: "306", AlternateID: "30602", AlternateType: "aka", AlternateName: "NATIONAL BANK OF CUBA"},
+		{EntityID: "410", AlternateID: "41003", AlternateType: "aka", AlternateName: "Abu Ali", AlternateRemarks: "weak"},
+	}
+	if !reflect.DeepEqual(res.AlternateIdentities, expectedAlts) {
+		t.Errorf("alternate identities: %#v", res.AlternateIdentities)
+	}
+}
+
+func TestOFAC__readAdvancedDetails(t *testing.T) {
+	res, err := Read(filepath.Join("testdata", "sdn_advanced.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	details := res.Details[1]
+	if details.EntityID != "410" || len(details.Names) != 3 {
+		t.Fatalf("unexpected details: %#v", details)
+	}
+
+	arabic := details.Names[1]
+	if arabic.Script != "Arabic" || arabic.ScriptCode != "Arab" || !arabic.Primary || arabic.FullName != "الحسن, علي" {
+		t.Errorf("unexpected name: %#v", arabic)
+	}
+	expectedParts := []NamePart{
+		{Type: "Last Name", Value: "الحسن"},
+		{Type: "First Name", Value: "علي"},
+	}
+	if !reflect.DeepEqual(arabic.Parts, expectedParts) {
+		t.Errorf("name parts: %#v", arabic.Parts)
+	}
+
+	expectedDocs := []IdentityDocument{
+		{Type: "Passport", Number: "D9001234", IssuingCountry: "Iran", ExpirationDate: "15 Jan 2030"},
+	}
+	if !reflect.DeepEqual(details.Documents, expectedDocs) {
+		t.Errorf("documents: %#v", details.Documents)
+	}
+
+	expectedFeatures := []Feature{
+		{Type: "Birthdate", Value: "05 May 1962"},
+		{Type: "Place of Birth", Value: "Tehran, Iran"},
+		{Type: "Gender", Value: "Male"},
+	}
+	if !reflect.DeepEqual(details.Features, expectedFeatures) {
+		t.Errorf("features: %#v", details.Features)
+	}
+
+	expectedRelationships := []Relationship{
+		{Type: "Owned or Controlled By", EntityID: "306"},
+	}
+	if !reflect.DeepEqual(res.Details[2].Relationships, expectedRelationships) {
+		t.Errorf("relationships: %#v", res.Details[2].Relationships)
+	}
+}
+
+func TestOFAC__xmlDatePeriod(t *testing.T) {
+	cases := []struct {
+		period   xmlDatePeriod
+		expected string
+	}{
+		{xmlDatePeriod{}, ""},
+		{xmlDatePeriod{Start: xmlDateBoundary{From: xmlDate{Year: 1962}}}, "1962"},
+		{xmlDatePeriod{Start: xmlDateBoundary{From: xmlDate{Year: 1962, Month: 5}}}, "May 1962"},
+		{
+			xmlDatePeriod{
+				Start: xmlDateBoundary{From: xmlDate{Year: 1960}, To: xmlDate{Year: 1960}},
+				End:   xmlDateBoundary{From: xmlDate{Year: 1962}, To: xmlDate{Year: 1962}},
+			},
+			"1960 to 1962",
+		},
+	}
+	for i := range cases {
+		if got := cases[i].period.String(); got != cases[i].expected {
+			t.Errorf("#%d: got %q, expected %q", i, got, cases[i].expected)
+		}
+	}
+}
+
+func TestOFAC__Filenames(t *testing.T) {
+	if names := Filenames(FormatAdvancedXML); !reflect.DeepEqual(names, []string{"sdn_advanced.xml", "cons_advanced.xml"}) {
+		t.Errorf("xml: %v", names)
+	}
+	if names := Filenames(FormatCSV); len(names) != 8 {
+		t.Errorf("csv: %v", names)
+	}
+}
@@ -5,8 +5,11 @@
 package ofac
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/moov-io/base/log"
 	"github.com/moov-io/watchman/pkg/download"
@@ -20,21 +23,160 @@ var (
 		"sdn_comments.csv", // Specially Designated National Comments
 	}
 
+	// consolidatedFilenames are the non-SDN Consolidated Sanctions List files, which share
+	// the layout of the SDN files
+	consolidatedFilenames = []string{
+		"cons_add.csv",      // Address
+		"cons_alt.csv",      // Alternate ID
+		"cons_prim.csv",     // Primary records
+		"cons_comments.csv", // Comments
+	}
+
+	// ofacValidations are checked against each downloaded file before it's accepted
+	ofacValidations = map[string]*download.Validation{
+		"add.csv": {
+			ContentTypes:    download.DefaultContentTypes,
+			Columns:         6,
+			MinRecords:      1000,
+			MaxRecordChange: download.DefaultMaxRecordChange,
+		},
+		"alt.csv": {
+			ContentTypes:    download.DefaultContentTypes,
+			Columns:         5,
+			MinRecords:      1000,
+			MaxRecordChange: download.DefaultMaxRecordChange,
+		},
+		"sdn.csv": {
+			ContentTypes:    download.DefaultContentTypes,
+			Columns:         12,
+			MinRecords:      1000,
+			MaxRecordChange: download.DefaultMaxRecordChange,
+		},
+		"sdn_comments.csv": {
+			ContentTypes: download.DefaultContentTypes,
+			MinRecords:   1,
+		},
+		"cons_add.csv": {
+			ContentTypes:    download.DefaultContentTypes,
+			Columns:         6,
+			MinRecords:      50,
+			MaxRecordChange: download.DefaultMaxRecordChange,
+		},
+		"cons_alt.csv": {
+			ContentTypes:    download.DefaultContentTypes,
+			Columns:         5,
+			MinRecords:      50,
+			MaxRecordChange: download.DefaultMaxRecordChange,
+		},
+		"cons_prim.csv": {
+			ContentTypes:    download.DefaultContentTypes,
+			Columns:         12,
+			MinRecords:      100,
+			MaxRecordChange: download.DefaultMaxRecordChange,
+		},
+		"cons_comments.csv": {
+			ContentTypes: download.DefaultContentTypes,
+		},
+	}
 	ofacURLTemplate = func() string {
 		if v := os.Getenv("OFAC_DOWNLOAD_TEMPLATE"); v != "" {
 			return v
 		}
 		return "https://www.treasury.gov/ofac/downloads/%s"
 	}()
+	consolidatedURLTemplate = func() string {
+		if v := os.Getenv("OFAC_CONSOLIDATED_DOWNLOAD_TEMPLATE"); v != "" {
+			return v
+		}
+		return "https://www.treasury.gov/ofac/downloads/consolidated/%s"
+	}()
+
+	advancedValidation = &download.Validation{
+		ContentTypes:    append([]string{"application/xml", "text/xml"}, download.DefaultContentTypes...),
+		Root:            "Sanctions",
+		Element:         "DistinctParty",
+		MinRecords:      1000,
+		MaxRecordChange: download.DefaultMaxRecordChange,
+	}
+	consolidatedAdvancedValidation = &download.Validation{
+		ContentTypes:    advancedValidation.ContentTypes,
+		Root:            "Sanctions",
+		Element:         "DistinctParty",
+		MinRecords:      100,
+		MaxRecordChange: download.DefaultMaxRecordChange,
+	}
+	advancedURL = func() string {
+		if v := os.Getenv("OFAC_ADVANCED_DOWNLOAD_URL"); v != "" {
+			return v
+		}
+		return "https://www.treasury.gov/ofac/downloads/sanctions/1.0/sdn_advanced.xml"
+	}()
+	consolidatedAdvancedURL = func() string {
+		if v := os.Getenv("OFAC_CONSOLIDATED_ADVANCED_DOWNLOAD_URL"); v != "" {
+			return v
+		}
+		return "https://www.treasury.gov/ofac/downloads/sanctions/1.0/cons_advanced.xml"
+	}()
+)
+
+const (
+	// FormatCSV reads the legacy sdn.csv, add.csv, alt.csv and sdn_comments.csv files along with
+	// the matching cons_*.csv files
+	FormatCSV = "csv"
+
+	// FormatAdvancedXML reads sdn_advanced.xml and cons_advanced.xml, which include EntityDetails
+	FormatAdvancedXML = "xml"
+
+	advancedFilename             = "sdn_advanced.xml"
+	consolidatedAdvancedFilename = "cons_advanced.xml"
+)
+
+var (
+	// DefaultFormat is which files are downloaded and read. It's set with OFAC_FORMAT.
+	DefaultFormat = func() string {
+		if strings.EqualFold(os.Getenv("OFAC_FORMAT"), FormatAdvancedXML) {
+			return FormatAdvancedXML
+		}
+		return FormatCSV
+	}()
 )
 
-func Download(logger log.Logger, initialDir string) ([]string, error) {
+// Filenames returns the OFAC files read in format
+func Filenames(format string) []string {
+	if format == FormatAdvancedXML {
+		return []string{advancedFilename, consolidatedAdvancedFilename}
+	}
+	var out []string
+	out = append(out, ofacFilenames...)
+	return append(out, consolidatedFilenames...)
+}
+
+func Download(ctx context.Context, logger log.Logger, initialDir string) ([]string, error) {
 	dl := download.New(logger, download.HTTPClient)
 
 	addrs := make(map[string]string)
-	for i := range ofacFilenames {
-		addrs[ofacFilenames[i]] = fmt.Sprintf(ofacURLTemplate, ofacFilenames[i])
+	switch DefaultFormat {
+	case FormatAdvancedXML:
+		dl.Validations = map[string]*download.Validation{
+			advancedFilename:             advancedValidation,
+			consolidatedAdvancedFilename: consolidatedAdvancedValidation,
+		}
+		dl.Limits = map[string]download.Limits{
+			// The advanced XML is much larger than the CSV files
+			advancedFilename: {Timeout: 5 * time.Minute, MaxBytes: 500 * 1024 * 1024},
+		}
+		addrs[advancedFilename] = advancedURL
+		addrs[consolidatedAdvancedFilename] = consolidatedAdvancedURL
+
+	default:
+		dl.Validations = ofacValidations
+		for i := range ofacFilenames {
+			addrs[ofacFilenames[i]] = fmt.Sprintf(ofacURLTemplate, ofacFilenames[i])
+		}
+		for i := range consolidatedFilenames {
+			addrs[consolidatedFilenames[i]] = fmt.Sprintf(consolidatedURLTemplate, consolidatedFilenames[i])
+		}
 	}
 
-	return dl.GetFiles(initialDir, addrs)
+	return dl.GetFiles(ctx, initialDir, addrs)
 }
@@ -5,6 +5,7 @@
 package ofac
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -18,13 +19,13 @@ func TestDownloader(t *testing.T) {
 		return
 	}
 
-	files, err := Download(log.NewNopLogger(), "")
+	files, err := Download(context.Background(), log.NewNopLogger(), "")
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer os.RemoveAll(filepath.Dir(files[0]))
 
-	if len(files) != 4 {
+	if len(files) != 8 {
 		t.Errorf("OFAC: found %d files", len(files))
 	}
 	for i := range files {
@@ -32,6 +33,8 @@ func TestDownloader(t *testing.T) {
 		switch name {
 		case "add.csv", "alt.csv", "sdn.csv", "sdn_comments.csv":
 			continue
+		case "cons_add.csv", "cons_alt.csv", "cons_prim.csv", "cons_comments.csv":
+			continue
 		default:
 			t.Errorf("unknown file %s", name)
 		}
@@ -52,17 +55,19 @@ func TestDownloader__initialDir(t *testing.T) {
 		}
 	}
 
-	// create each file
-	mk(t, "sdn.csv", "file=sdn.csv")
+	// create each file, so nothing is downloaded
+	for _, name := range Filenames(DefaultFormat) {
+		mk(t, name, "file="+name)
+	}
 	mk(t, "dpl.txt", "file=dpl.txt")
 
-	files, err := Download(log.NewNopLogger(), dir)
+	files, err := Download(context.Background(), log.NewNopLogger(), dir)
 	if err != nil {
 		t.Fatal(err)
 	}
 	for i := range files {
 		switch filepath.Base(files[i]) {
-		case "sdn.txt":
+		case "sdn.csv":
 			bs, err := ioutil.ReadFile(files[i])
 			if err != nil {
 				t.Fatal(err)
@@ -0,0 +1,48 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ofac
+
+import (
+	"strings"
+
+	"github.com/moov-io/watchman/pkg/source"
+)
+
+// ToEntity converts an SDN, along with its addresses and alternate names, into a source.Entity.
+// Dates of birth are read from the SDN's remarks.
+func ToEntity(sdn *SDN, addresses []*Address, alts []*AlternateIdentity) *source.Entity {
+	out := &source.Entity{
+		Name:       sdn.SDNName,
+		Type:       source.ParseEntityType(sdn.SDNType),
+		Programs:   sdn.Programs,
+		SourceList: sdn.SourceList,
+		SourceID:   sdn.EntityID,
+		SourceData: sdn,
+	}
+	if sdn.SDNType == "" {
+		// OFAC leaves the type of businesses and organizations empty
+		out.Type = source.Business
+	}
+	if out.SourceList == "" {
+		out.SourceList = SDNList
+	}
+	for i := range addresses {
+		if a := source.JoinParts(addresses[i].Address, addresses[i].CityStateProvincePostalCode, addresses[i].Country); a != "" {
+			out.Addresses = append(out.Addresses, a)
+		}
+	}
+	for i := range alts {
+		if alts[i].AlternateName != "" {
+			out.AlternateNames = append(out.AlternateNames, alts[i].AlternateName)
+		}
+	}
+	for _, remark := range strings.Split(sdn.Remarks, ";") {
+		remark = strings.TrimSpace(remark)
+		if strings.HasPrefix(remark, "DOB ") {
+			out.DatesOfBirth = append(out.DatesOfBirth, strings.TrimSpace(strings.TrimPrefix(remark, "DOB ")))
+		}
+	}
+	return out
+}
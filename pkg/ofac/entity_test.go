@@ -0,0 +1,47 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ofac
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/source"
+)
+
+func TestToEntity(t *testing.T) {
+	sdn := &SDN{
+		EntityID: "2681",
+		SDNName:  "HAWATMA, Nayif",
+		SDNType:  "individual",
+		Programs: []string{"SDGT"},
+		Remarks:  "DOB 1933; POB Al Salt, Jordan; Secretary General.",
+	}
+	addresses := []*Address{{EntityID: "2681", Address: "123 Main St", Country: "Jordan"}}
+	alts := []*AlternateIdentity{{EntityID: "2681", AlternateName: "HAWATMEH, Nayif"}}
+
+	entity := ToEntity(sdn, addresses, alts)
+	if entity.Name != "HAWATMA, Nayif" || entity.Type != source.Individual {
+		t.Errorf("unexpected entity: %#v", entity)
+	}
+	if !reflect.DeepEqual(entity.AlternateNames, []string{"HAWATMEH, Nayif"}) {
+		t.Errorf("AlternateNames=%v", entity.AlternateNames)
+	}
+	if !reflect.DeepEqual(entity.Addresses, []string{"123 Main St, Jordan"}) {
+		t.Errorf("Addresses=%v", entity.Addresses)
+	}
+	if !reflect.DeepEqual(entity.DatesOfBirth, []string{"1933"}) {
+		t.Errorf("DatesOfBirth=%v", entity.DatesOfBirth)
+	}
+	if entity.SourceList != SDNList || entity.SourceID != "2681" || entity.SourceData != sdn {
+		t.Errorf("unexpected source: %s %s %v", entity.SourceList, entity.SourceID, entity.SourceData)
+	}
+
+	// OFAC leaves the type of businesses empty
+	entity = ToEntity(&SDN{EntityID: "36", SDNName: "AEROCARIBBEAN AIRLINES", SourceList: ConsolidatedList}, nil, nil)
+	if entity.Type != source.Business || entity.SourceList != ConsolidatedList {
+		t.Errorf("unexpected entity: %#v", entity)
+	}
+}
@@ -4,6 +4,14 @@
 
 package ofac
 
+const (
+	// SDNList is the SourceList of records from the Specially Designated Nationals list
+	SDNList = "SDN"
+
+	// ConsolidatedList is the SourceList of records from the non-SDN Consolidated Sanctions List
+	ConsolidatedList = "Consolidated"
+)
+
 // SDN is a specially Designated National
 type SDN struct {
 	// EntityID (ent_num) is the unique record identifier/unique listing identifier
@@ -30,6 +38,8 @@ type SDN struct {
 	VesselOwner string `json:"vesselOwner"`
 	//  Remarks is remarks on specially designated national
 	Remarks string `json:"remarks"`
+	// SourceList is the OFAC list this record was published on, SDNList or ConsolidatedList
+	SourceList string `json:"sourceList"`
 }
 
 // Address is OFAC SDN Addresses
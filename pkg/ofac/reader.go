@@ -14,22 +14,25 @@ import (
 	"strings"
 )
 
-// Read will consume the file at path and attempt to parse it was a CSV OFAC file.
+// Read will consume the file at path and attempt to parse it was a CSV OFAC file or the
+// advanced XML file (sdn_advanced.xml). Files of the non-SDN Consolidated Sanctions List
+// (cons_prim.csv, cons_add.csv, cons_alt.csv, cons_comments.csv and cons_advanced.xml) are
+// read the same way with their SDNs tagged as ConsolidatedList.
 //
 // For more details on the raw OFAC files see https://moov-io.github.io/watchman/file-structure.html
 func Read(path string) (*Results, error) {
 	switch filepath.Base(path) {
-	case "add.csv":
+	case "add.csv", "cons_add.csv":
 		res, err := csvAddressFile(path)
 		if err != nil {
-			return res, fmt.Errorf("add.csv: %v", err)
+			return res, fmt.Errorf("%s: %v", filepath.Base(path), err)
 		}
 		return res, err
 
-	case "alt.csv":
+	case "alt.csv", "cons_alt.csv":
 		res, err := csvAlternateIdentityFile(path)
 		if err != nil {
-			return res, fmt.Errorf("alt.csv: %v", err)
+			return res, fmt.Errorf("%s: %v", filepath.Base(path), err)
 		}
 		return res, err
 
@@ -38,14 +41,35 @@ func Read(path string) (*Results, error) {
 		if err != nil {
 			return res, fmt.Errorf("sdn.csv: %v", err)
 		}
-		return res, err
+		return res.withSourceList(SDNList), err
+
+	case "cons_prim.csv":
+		res, err := csvSDNFile(path)
+		if err != nil {
+			return res, fmt.Errorf("cons_prim.csv: %v", err)
+		}
+		return res.withSourceList(ConsolidatedList), err
 
-	case "sdn_comments.csv":
+	case "sdn_comments.csv", "cons_comments.csv":
 		res, err := csvSDNCommentsFile(path)
 		if err != nil {
-			return res, fmt.Errorf("sdn_comments.csv: %v", err)
+			return res, fmt.Errorf("%s: %v", filepath.Base(path), err)
 		}
 		return res, err
+
+	case advancedFilename:
+		res, err := advancedXMLFile(path)
+		if err != nil {
+			return res, fmt.Errorf("%s: %v", advancedFilename, err)
+		}
+		return res.withSourceList(SDNList), err
+
+	case consolidatedAdvancedFilename:
+		res, err := advancedXMLFile(path)
+		if err != nil {
+			return res, fmt.Errorf("%s: %v", consolidatedAdvancedFilename, err)
+		}
+		return res.withSourceList(ConsolidatedList), err
 	}
 	return nil, nil
 }
@@ -62,6 +86,17 @@ type Results struct {
 
 	// SDNComments returns an array of OFAC Specially Designated National Comments
 	SDNComments []*SDNComments `json:"sdnComments"`
+
+	// Details returns the structured details of each SDN, which are only read from the advanced XML format
+	Details []*EntityDetails `json:"details,omitempty"`
+}
+
+// withSourceList tags each SDN with the list it was read from
+func (r *Results) withSourceList(list string) *Results {
+	for i := range r.SDNs {
+		r.SDNs[i].SourceList = list
+	}
+	return r
 }
 
 func csvAddressFile(path string) (*Results, error) {
@@ -113,3 +113,46 @@ func TestSDNComments(t *testing.T) {
 		}
 	}
 }
+
+func TestOFAC__readConsolidated(t *testing.T) {
+	res, err := Read(filepath.Join("..", "..", "test", "testdata", "cons_prim.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.SDNs) != 3 {
+		t.Fatalf("found %d records", len(res.SDNs))
+	}
+	for i := range res.SDNs {
+		if res.SDNs[i].SourceList != ConsolidatedList {
+			t.Errorf("%s: unexpected source list %q", res.SDNs[i].EntityID, res.SDNs[i].SourceList)
+		}
+	}
+	if sdn := res.SDNs[0]; sdn.SDNName != "AKB PERESVET" || !reflect.DeepEqual(sdn.Programs, []string{"UKRAINE-EO13662", "RUSSIA-EO14024"}) {
+		t.Errorf("unexpected record: %#v", sdn)
+	}
+
+	res, err = Read(filepath.Join("..", "..", "test", "testdata", "cons_alt.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.AlternateIdentities) != 2 {
+		t.Errorf("found %d alternate identities", len(res.AlternateIdentities))
+	}
+
+	res, err = Read(filepath.Join("..", "..", "test", "testdata", "cons_add.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Addresses) != 1 || res.Addresses[0].Country != "Russia" {
+		t.Errorf("unexpected addresses: %#v", res.Addresses)
+	}
+
+	// SDN records are tagged with their list too
+	res, err = Read(filepath.Join("..", "..", "test", "testdata", "sdn.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.SDNs[0].SourceList != SDNList {
+		t.Errorf("unexpected source list %q", res.SDNs[0].SourceList)
+	}
+}
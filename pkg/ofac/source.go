@@ -0,0 +1,118 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ofac
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/source"
+)
+
+// SourceName is the name of the Source of the SDN and non-SDN Consolidated Sanctions lists
+const SourceName = "OFAC"
+
+func init() {
+	source.Register(Source{})
+}
+
+// Source publishes the SDN list and the non-SDN Consolidated Sanctions List, read in DefaultFormat
+type Source struct{}
+
+func (Source) Name() string {
+	return SourceName
+}
+
+func (Source) Filenames() []string {
+	return Filenames(DefaultFormat)
+}
+
+func (Source) Download(ctx context.Context, logger log.Logger, initialDir string) ([]string, error) {
+	files, err := Download(ctx, logger, initialDir)
+	if err != nil {
+		return nil, fmt.Errorf("download: %v", err)
+	}
+	files = formatFiles(files)
+	if len(files) == 0 {
+		return nil, errors.New("no OFAC Results")
+	}
+	return files, nil
+}
+
+func (Source) Read(files []string) ([]source.List, error) {
+	res, _, err := ReadFiles(files)
+	if err != nil {
+		return nil, err
+	}
+	return Lists(res), nil
+}
+
+// formatFiles returns the files out of files which are read in DefaultFormat
+func formatFiles(files []string) []string {
+	names := make(map[string]bool)
+	for _, name := range Filenames(DefaultFormat) {
+		names[name] = true
+	}
+	var out []string
+	for i := range files {
+		if names[filepath.Base(files[i])] {
+			out = append(out, files[i])
+		}
+	}
+	return out
+}
+
+// ReadFiles parses and merges each file out of files which is read in DefaultFormat. The filepaths
+// which contained OFAC data are returned so they can be archived.
+func ReadFiles(files []string) (*Results, []string, error) {
+	res := &Results{}
+	var used []string
+
+	for _, file := range formatFiles(files) {
+		rr, err := Read(file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("read: %v", err)
+		}
+		if rr != nil {
+			res.Addresses = append(res.Addresses, rr.Addresses...)
+			res.AlternateIdentities = append(res.AlternateIdentities, rr.AlternateIdentities...)
+			res.SDNs = append(res.SDNs, rr.SDNs...)
+			res.SDNComments = append(res.SDNComments, rr.SDNComments...)
+			res.Details = append(res.Details, rr.Details...)
+			used = append(used, file)
+		}
+	}
+	return res, used, nil
+}
+
+// Lists converts every SDN of res, along with its addresses and alternate names, into a
+// source.Entity and groups them into a List for each SourceList (SDNList and ConsolidatedList).
+func Lists(res *Results) []source.List {
+	addresses := make(map[string][]*Address)
+	for _, addr := range res.Addresses {
+		addresses[addr.EntityID] = append(addresses[addr.EntityID], addr)
+	}
+	alts := make(map[string][]*AlternateIdentity)
+	for _, alt := range res.AlternateIdentities {
+		alts[alt.EntityID] = append(alts[alt.EntityID], alt)
+	}
+
+	var out []source.List
+	lists := make(map[string]int)
+	for _, sdn := range res.SDNs {
+		entity := ToEntity(sdn, addresses[sdn.EntityID], alts[sdn.EntityID])
+		i, ok := lists[entity.SourceList]
+		if !ok {
+			i = len(out)
+			lists[entity.SourceList] = i
+			out = append(out, source.List{Name: entity.SourceList})
+		}
+		out[i].Entities = append(out[i].Entities, entity)
+	}
+	return out
+}
@@ -0,0 +1,55 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ofac
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSource(t *testing.T) {
+	var files []string
+	for _, name := range []string{"sdn.csv", "add.csv", "alt.csv", "cons_prim.csv", "dpl.txt", "eu_fsf.xml"} {
+		files = append(files, filepath.Join("..", "..", "test", "testdata", name))
+	}
+
+	// Only files read in DefaultFormat are used
+	res, used, err := ReadFiles(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(used, files[:4]) {
+		t.Errorf("used=%v", used)
+	}
+
+	lists, err := Source{}.Read(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lists) != 2 || lists[0].Name != SDNList || lists[1].Name != ConsolidatedList {
+		t.Fatalf("unexpected lists: %#v", lists)
+	}
+	if n := len(lists[0].Entities) + len(lists[1].Entities); n != len(res.SDNs) {
+		t.Errorf("got %d entities for %d SDNs", n, len(res.SDNs))
+	}
+	if len(lists[1].Entities) != 3 {
+		t.Errorf("got %d Consolidated entities", len(lists[1].Entities))
+	}
+
+	// Addresses and alternate names are kept with their SDN
+	for _, entity := range lists[0].Entities {
+		if entity.SourceID != "306" {
+			continue
+		}
+		if entity.Name != "BANCO NACIONAL DE CUBA" || len(entity.Addresses) == 0 || len(entity.AlternateNames) == 0 {
+			t.Errorf("unexpected entity: %#v", entity)
+		}
+	}
+
+	if lists := Lists(&Results{}); len(lists) != 0 {
+		t.Errorf("unexpected lists: %#v", lists)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"strings"
+)
+
+// Entity is a record of any list in a common shape, so every list can be searched and
+// ranked together. Details only some lists publish are kept on the original record.
+type Entity struct {
+	// Name is the primary name the entity is listed under
+	Name string `json:"name"`
+	// AlternateNames are aliases, former names and names written in other scripts
+	AlternateNames []string `json:"alternateNames"`
+	// Type is the kind of entity, it's empty when the list doesn't say
+	Type EntityType `json:"type"`
+
+	Addresses    []string `json:"addresses,omitempty"`
+	IDs          []string `json:"ids,omitempty"`
+	DatesOfBirth []string `json:"datesOfBirth,omitempty"`
+	// Programs are the sanctions programs or regimes the entity is listed under
+	Programs []string `json:"programs,omitempty"`
+	// ListedOn is the date the entity was added to the list, as published
+	ListedOn string `json:"listedOn,omitempty"`
+
+	// SourceList is the name of the List the entity was read from (e.g. "SSIs")
+	SourceList string `json:"sourceList"`
+	// SourceID is the entity's identifier within SourceList
+	SourceID string `json:"sourceID"`
+	// SourceData is the record as read from the list (e.g. *csl.SSI)
+	SourceData interface{} `json:"sourceData"`
+}
+
+// EntityType is the kind of an Entity
+type EntityType string
+
+const (
+	UnknownType EntityType = ""
+	Individual  EntityType = "individual"
+	Business    EntityType = "business"
+	Vessel      EntityType = "vessel"
+	Aircraft    EntityType = "aircraft"
+)
+
+// ParseEntityType returns the EntityType of each list's own type names
+// (e.g. "Individual", "person", "enterprise", "Ship")
+func ParseEntityType(raw string) EntityType {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "individual", "person":
+		return Individual
+	case "entity", "enterprise", "business", "organization", "company":
+		return Business
+	case "vessel", "ship":
+		return Vessel
+	case "aircraft":
+		return Aircraft
+	}
+	return UnknownType
+}
+
+// JoinParts formats the parts of an address or ID on one line, skipping empty parts
+func JoinParts(parts ...string) string {
+	var out []string
+	for i := range parts {
+		if p := strings.TrimSpace(parts[i]); p != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, ", ")
+}
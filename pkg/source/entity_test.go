@@ -0,0 +1,33 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEntityType(t *testing.T) {
+	cases := map[string]EntityType{
+		"Individual": Individual,
+		"person":     Individual,
+		"Entity":     Business,
+		"enterprise": Business,
+		" Ship ":     Vessel,
+		"vessel":     Vessel,
+		"aircraft":   Aircraft,
+		"":           UnknownType,
+		"other":      UnknownType,
+	}
+	for raw, expected := range cases {
+		require.Equal(t, expected, ParseEntityType(raw), raw)
+	}
+}
+
+func TestJoinParts(t *testing.T) {
+	require.Equal(t, "1 Main St, Springfield, US", JoinParts("1 Main St", " ", "Springfield", "", "US"))
+	require.Equal(t, "", JoinParts("", " "))
+}
@@ -0,0 +1,74 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   []Source
+)
+
+// Register makes src available through Sources. List packages call it from init.
+// It panics if a Source with the same name is already registered.
+func Register(src Source) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if src == nil {
+		panic("source: Register of nil Source")
+	}
+	for i := range registry {
+		if strings.EqualFold(registry[i].Name(), src.Name()) {
+			panic(fmt.Sprintf("source: Register called twice for %s", src.Name()))
+		}
+	}
+	registry = append(registry, src)
+}
+
+// Sources returns every registered Source in the order they were registered
+func Sources() []Source {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	out := make([]Source, len(registry))
+	copy(out, registry)
+	return out
+}
+
+// Disabled is a set of Source and List names which are skipped
+type Disabled map[string]bool
+
+// ParseDisabled reads a comma separated list of Source or List names, such as DISABLED_LISTS.
+// Names are case-insensitive.
+func ParseDisabled(raw string) Disabled {
+	out := make(Disabled)
+	for _, name := range strings.Split(raw, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			out[strings.ToLower(name)] = true
+		}
+	}
+	return out
+}
+
+// Contains returns true if name was disabled
+func (d Disabled) Contains(name string) bool {
+	return d[strings.ToLower(name)]
+}
+
+// Enabled returns the Sources whose names aren't disabled
+func (d Disabled) Enabled(sources []Source) []Source {
+	var out []Source
+	for i := range sources {
+		if !d.Contains(sources[i].Name()) {
+			out = append(out, sources[i])
+		}
+	}
+	return out
+}
@@ -0,0 +1,67 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moov-io/base/log"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testSource struct {
+	name string
+}
+
+func (src testSource) Name() string {
+	return src.name
+}
+
+func (testSource) Filenames() []string {
+	return []string{"test.csv"}
+}
+
+func (testSource) Download(_ context.Context, _ log.Logger, _ string) ([]string, error) {
+	return nil, nil
+}
+
+func (testSource) Read(_ []string) ([]List, error) {
+	return nil, nil
+}
+
+func TestRegister(t *testing.T) {
+	Register(testSource{name: "first"})
+	Register(testSource{name: "second"})
+
+	var names []string
+	for _, src := range Sources() {
+		names = append(names, src.Name())
+	}
+	require.Equal(t, []string{"first", "second"}, names)
+
+	require.Panics(t, func() {
+		Register(testSource{name: "FIRST"})
+	})
+	require.Panics(t, func() {
+		Register(nil)
+	})
+}
+
+func TestDisabled(t *testing.T) {
+	disabled := ParseDisabled(" EU, ssis,,")
+	require.Len(t, disabled, 2)
+	require.True(t, disabled.Contains("eu"))
+	require.True(t, disabled.Contains("SSIs"))
+	require.False(t, disabled.Contains("UN"))
+
+	sources := []Source{testSource{name: "EU"}, testSource{name: "UN"}}
+	enabled := disabled.Enabled(sources)
+	require.Len(t, enabled, 1)
+	require.Equal(t, "UN", enabled[0].Name())
+
+	require.Len(t, ParseDisabled("").Enabled(sources), 2)
+}
@@ -0,0 +1,64 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+// Package source defines how a watchlist is downloaded, parsed and searched. Each list package
+// provides a Source and registers it, so adding a list doesn't require changes to the server.
+package source
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/moov-io/base/log"
+)
+
+// Source is a publisher of one or more watchlists
+type Source interface {
+	// Name identifies the source in configuration, logs and metrics (e.g. "EU")
+	Name() string
+
+	// Filenames are the basenames of the files Download returns, which are used
+	// to find the source's files in a signed bundle.
+	Filenames() []string
+
+	// Download returns the paths of the source's files. Files in initialDir are used
+	// instead of downloading them when present.
+	Download(ctx context.Context, logger log.Logger, initialDir string) ([]string, error)
+
+	// Read parses files into each list published by the source
+	Read(files []string) ([]List, error)
+}
+
+// List is the records of one watchlist. Sources such as the CSL publish several lists in one file.
+type List struct {
+	// Name identifies the list in stats, metrics and configuration (e.g. "SSIs")
+	Name string
+
+	Entities []*Entity
+}
+
+// Record is a list's own record which converts into an Entity
+type Record interface {
+	ToEntity() *Entity
+}
+
+// Entities converts a slice of records into Entities
+func Entities[T Record](items []T) []*Entity {
+	out := make([]*Entity, len(items))
+	for i := range items {
+		out[i] = items[i].ToEntity()
+	}
+	return out
+}
+
+// File returns the path out of files whose basename is filename
+func File(files []string, filename string) (string, error) {
+	for i := range files {
+		if filepath.Base(files[i]) == filename {
+			return files[i], nil
+		}
+	}
+	return "", fmt.Errorf("%s not found", filename)
+}
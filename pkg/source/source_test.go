@@ -0,0 +1,41 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package source
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testRecord struct {
+	name string
+}
+
+func (r *testRecord) ToEntity() *Entity {
+	return &Entity{Name: r.name, SourceList: "test", SourceData: r}
+}
+
+func TestEntities(t *testing.T) {
+	records := []*testRecord{{name: "a"}, {name: "b"}}
+	entities := Entities(records)
+	require.Len(t, entities, 2)
+
+	require.Equal(t, "b", entities[1].Name)
+	require.Empty(t, entities[1].AlternateNames)
+	require.Same(t, records[1], entities[1].SourceData)
+}
+
+func TestFile(t *testing.T) {
+	files := []string{filepath.Join("data", "sdn.csv"), filepath.Join("data", "csl.csv")}
+
+	file, err := File(files, "csl.csv")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join("data", "csl.csv"), file)
+
+	_, err = File(files, "eu_fsf.xml")
+	require.ErrorContains(t, err, "eu_fsf.xml not found")
+}
@@ -0,0 +1,54 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package uk
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/download"
+)
+
+// Filename is the name the OFSI Consolidated List CSV file is saved as
+const Filename = "uk_conlist.csv"
+
+var (
+	ukDownloadURL = func() string {
+		if w := os.Getenv("UK_DOWNLOAD_URL"); w != "" {
+			return w
+		}
+		return "https://ofsistorage.blob.core.windows.net/publishlive/2022format/ConList.csv"
+	}()
+)
+
+// Download returns the filepath of the OFSI Consolidated List CSV file
+func Download(ctx context.Context, logger log.Logger, initialDir string) (string, error) {
+	dl := download.New(logger, download.HTTPClient)
+	dl.Validations = map[string]*download.Validation{
+		Filename: {
+			// The first row is "Last Updated" followed by the header, so columns aren't checked
+			ContentTypes:    download.DefaultContentTypes,
+			MinRecords:      1000,
+			MaxRecordChange: download.DefaultMaxRecordChange,
+		},
+	}
+
+	addrs := make(map[string]string)
+	addrs[Filename] = ukDownloadURL
+
+	files, err := dl.GetFiles(ctx, initialDir, addrs)
+	if len(files) == 0 || err != nil {
+		return "", fmt.Errorf("uk download: %v", err)
+	}
+	for i := range files {
+		if filepath.Base(files[i]) == Filename {
+			return files[i], nil
+		}
+	}
+	return "", fmt.Errorf("uk download: %s not found", Filename)
+}
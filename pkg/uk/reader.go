@@ -0,0 +1,227 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package uk
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ReadFile parses the OFSI Consolidated List CSV file at path
+func ReadFile(path string) ([]*Entity, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	return Parse(fd)
+}
+
+// Parse reads every row of the OFSI Consolidated List CSV and links them by Group ID.
+// Columns are found by name in the header row, which follows a "Last Updated" row.
+func Parse(r io.Reader) ([]*Entity, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	var columns map[string]int
+	groups := make(map[string]*Entity)
+	var out []*Entity
+	for {
+		record, err := reader.Read()
+		if err != nil {
+			// reached the last line
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			// malformed row
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				continue
+			}
+			return nil, err
+		}
+
+		if columns == nil {
+			columns = headerColumns(record)
+			continue
+		}
+
+		row := &row{record: record, columns: columns}
+		groupID := row.get("Group ID")
+		if groupID == "" {
+			continue
+		}
+		entity, exists := groups[groupID]
+		if !exists {
+			entity = &Entity{GroupID: groupID}
+			groups[groupID] = entity
+			out = append(out, entity)
+		}
+		entity.addRow(row)
+	}
+	if columns == nil {
+		return nil, fmt.Errorf("uk: no header row with a %q column", "Group ID")
+	}
+
+	for i := range out {
+		out[i].Name, out[i].AlternateNames = primaryName(out[i].Names)
+	}
+	return out, nil
+}
+
+// headerColumns returns the index of each column by name, or nil when record isn't the header
+func headerColumns(record []string) map[string]int {
+	columns := make(map[string]int)
+	for i := range record {
+		columns[strings.TrimSpace(strings.TrimPrefix(record[i], "\ufeff"))] = i
+	}
+	if _, exists := columns["Group ID"]; !exists {
+		return nil
+	}
+	return columns
+}
+
+type row struct {
+	record  []string
+	columns map[string]int
+}
+
+func (r *row) get(column string) string {
+	idx, exists := r.columns[column]
+	if !exists || idx >= len(r.record) {
+		return ""
+	}
+	return strings.TrimSpace(r.record[idx])
+}
+
+func (e *Entity) addRow(r *row) {
+	name := Name{
+		Title:                  r.get("Title"),
+		NonLatinScript:         r.get("Name Non-Latin Script"),
+		NonLatinScriptType:     r.get("Non-Latin Script Type"),
+		NonLatinScriptLanguage: r.get("Non-Latin Script Language"),
+		AliasType:              r.get("Alias Type"),
+		AliasQuality:           r.get("Alias Quality"),
+	}
+	var parts []string
+	for i := range name.Parts {
+		name.Parts[i] = r.get(fmt.Sprintf("Name %d", i+1))
+		if name.Parts[i] != "" {
+			parts = append(parts, name.Parts[i])
+		}
+	}
+	name.FullName = strings.Join(parts, " ")
+	if name.FullName != "" || name.NonLatinScript != "" {
+		e.Names = append(e.Names, name)
+	}
+
+	// Details of the target are repeated on each of its rows
+	setFirst(&e.GroupType, r.get("Group Type"))
+	setFirst(&e.Regime, r.get("Regime"))
+	setFirst(&e.OtherInformation, r.get("Other Information"))
+	setFirst(&e.ListedOn, r.get("Listed On"))
+	setFirst(&e.DesignatedOn, r.get("UK Sanctions List Date Designated"))
+	setFirst(&e.LastUpdated, r.get("Last Updated"))
+
+	e.DatesOfBirth = appendUnique(e.DatesOfBirth, r.get("DOB"))
+	e.TownsOfBirth = appendUnique(e.TownsOfBirth, r.get("Town of Birth"))
+	e.CountriesOfBirth = appendUnique(e.CountriesOfBirth, r.get("Country of Birth"))
+	e.Nationalities = appendUnique(e.Nationalities, r.get("Nationality"))
+	e.Positions = appendUnique(e.Positions, r.get("Position"))
+
+	if doc := (Document{Number: r.get("Passport Number"), Details: r.get("Passport Details")}); doc != (Document{}) && !containsDocument(e.Passports, doc) {
+		e.Passports = append(e.Passports, doc)
+	}
+	if doc := (Document{Number: r.get("National Identification Number"), Details: r.get("National Identification Details")}); doc != (Document{}) && !containsDocument(e.NationalIDs, doc) {
+		e.NationalIDs = append(e.NationalIDs, doc)
+	}
+
+	addr := Address{
+		PostalCode: r.get("Post/Zip Code"),
+		Country:    r.get("Country"),
+	}
+	for i := 1; i <= 6; i++ {
+		if line := r.get(fmt.Sprintf("Address %d", i)); line != "" {
+			addr.Lines = append(addr.Lines, line)
+		}
+	}
+	if (len(addr.Lines) > 0 || addr.PostalCode != "" || addr.Country != "") && !containsAddress(e.Addresses, addr) {
+		e.Addresses = append(e.Addresses, addr)
+	}
+}
+
+// primaryName returns the full name of the "Primary name" row (or the first row) and every
+// other distinct name, including non-Latin script names, as alternates.
+func primaryName(names []Name) (string, []string) {
+	if len(names) == 0 {
+		return "", nil
+	}
+	primary := 0
+	for i := range names {
+		if strings.EqualFold(names[i].AliasType, PrimaryNameAlias) && names[i].FullName != "" {
+			primary = i
+			break
+		}
+	}
+	name := names[primary].FullName
+	seen := map[string]bool{strings.ToLower(name): true}
+
+	var alts []string
+	add := func(alt string) {
+		if alt == "" || seen[strings.ToLower(alt)] {
+			return
+		}
+		seen[strings.ToLower(alt)] = true
+		alts = append(alts, alt)
+	}
+	for i := range names {
+		add(names[i].FullName)
+		add(names[i].NonLatinScript)
+	}
+	return name, alts
+}
+
+func setFirst(field *string, value string) {
+	if *field == "" {
+		*field = value
+	}
+}
+
+func appendUnique(values []string, value string) []string {
+	if value == "" {
+		return values
+	}
+	for i := range values {
+		if values[i] == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+func containsDocument(docs []Document, doc Document) bool {
+	for i := range docs {
+		if docs[i] == doc {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAddress(addrs []Address, addr Address) bool {
+	for i := range addrs {
+		if addrs[i].PostalCode == addr.PostalCode && addrs[i].Country == addr.Country &&
+			strings.Join(addrs[i].Lines, "\n") == strings.Join(addr.Lines, "\n") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package uk
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRead(t *testing.T) {
+	entities, err := ReadFile(filepath.Join("..", "..", "test", "testdata", "uk_conlist.csv"))
+	require.NoError(t, err)
+	require.Len(t, entities, 2)
+
+	// Rows are linked by their Group ID
+	zawahiri := entities[0]
+	require.Equal(t, "6905", zawahiri.GroupID)
+	require.Equal(t, IndividualType, zawahiri.GroupType)
+	require.Equal(t, "Ayman Muhammed Rabi AL-ZAWAHIRI", zawahiri.Name)
+	require.Equal(t, []string{"أيمن محمد ربيع الظواهري", "Ayman AL-ZAWAHARI", "Abu MOHAMMED"}, zawahiri.AlternateNames)
+	require.Len(t, zawahiri.Names, 3)
+	require.Equal(t, [6]string{"Ayman", "Muhammed", "Rabi", "", "", "AL-ZAWAHIRI"}, zawahiri.Names[0].Parts)
+	require.Equal(t, "Dr", zawahiri.Names[0].Title)
+	require.Equal(t, "Low", zawahiri.Names[2].AliasQuality)
+	require.Equal(t, "ISIL (Da'esh) and Al-Qaida", zawahiri.Regime)
+	require.Equal(t, []string{"19/06/1951", "00/00/1953"}, zawahiri.DatesOfBirth)
+	require.Equal(t, []string{"Giza"}, zawahiri.TownsOfBirth)
+	require.Equal(t, []string{"Egyptian"}, zawahiri.Nationalities)
+	require.Equal(t, []Document{{Number: "1084010", Details: "(Egypt)"}, {Number: "19820215", Details: "(Egypt)"}}, zawahiri.Passports)
+	require.Equal(t, "25/01/2001", zawahiri.ListedOn)
+	require.Equal(t, "31/12/2020", zawahiri.DesignatedOn)
+	require.Empty(t, zawahiri.Addresses)
+
+	roe := entities[1]
+	require.Equal(t, EntityType, roe.GroupType)
+	require.Equal(t, "ROSOBORONEXPORT", roe.Name)
+	require.Equal(t, []string{"РОСОБОРОНЭКСПОРТ", "ROE"}, roe.AlternateNames)
+	require.Equal(t, []Address{{Lines: []string{"27 Stromynka Street", "Moscow"}, PostalCode: "107076", Country: "Russia"}}, roe.Addresses)
+}
+
+func TestParse__noHeader(t *testing.T) {
+	_, err := Parse(strings.NewReader("Last Updated,14/06/2022\n"))
+	require.Error(t, err)
+}
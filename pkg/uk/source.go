@@ -0,0 +1,79 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package uk
+
+import (
+	"context"
+	"strings"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/source"
+)
+
+func init() {
+	source.Register(Source{})
+}
+
+// Source publishes HM Treasury's OFSI Consolidated List
+type Source struct{}
+
+func (Source) Name() string {
+	return "UK"
+}
+
+func (Source) Filenames() []string {
+	return []string{Filename}
+}
+
+func (Source) Download(ctx context.Context, logger log.Logger, initialDir string) ([]string, error) {
+	file, err := Download(ctx, logger, initialDir)
+	if err != nil {
+		return nil, err
+	}
+	return []string{file}, nil
+}
+
+func (Source) Read(files []string) ([]source.List, error) {
+	file, err := source.File(files, Filename)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return []source.List{
+		{Name: "UK", Entities: source.Entities(entities)},
+	}, nil
+}
+
+// ToEntity converts the entity into a source.Entity of the UK list
+func (e *Entity) ToEntity() *source.Entity {
+	out := &source.Entity{
+		Name:           e.Name,
+		AlternateNames: e.AlternateNames,
+		Type:           source.ParseEntityType(e.GroupType),
+		DatesOfBirth:   e.DatesOfBirth,
+		ListedOn:       e.ListedOn,
+		SourceList:     "UK",
+		SourceID:       e.GroupID,
+		SourceData:     e,
+	}
+	if e.Regime != "" {
+		out.Programs = []string{e.Regime}
+	}
+	for _, addr := range e.Addresses {
+		parts := append(append([]string{}, addr.Lines...), addr.PostalCode, addr.Country)
+		if a := source.JoinParts(parts...); a != "" {
+			out.Addresses = append(out.Addresses, a)
+		}
+	}
+	for _, doc := range append(append([]Document{}, e.Passports...), e.NationalIDs...) {
+		if doc.Number != "" {
+			out.IDs = append(out.IDs, source.JoinParts(doc.Number, strings.Trim(doc.Details, "()")))
+		}
+	}
+	return out
+}
@@ -0,0 +1,37 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package uk
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/source"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSource(t *testing.T) {
+	files := []string{
+		filepath.Join("..", "..", "test", "testdata", "sdn.csv"),
+		filepath.Join("..", "..", "test", "testdata", Filename),
+	}
+	lists, err := Source{}.Read(files)
+	require.NoError(t, err)
+	require.Len(t, lists, 1)
+	require.Equal(t, "UK", lists[0].Name)
+	require.Len(t, lists[0].Entities, 2)
+
+	entity := lists[0].Entities[0]
+	require.Equal(t, "Ayman Muhammed Rabi AL-ZAWAHIRI", entity.Name)
+	require.Equal(t, source.Individual, entity.Type)
+	require.Equal(t, []string{"1084010, Egypt", "19820215, Egypt"}, entity.IDs)
+	require.Equal(t, []string{"ISIL (Da'esh) and Al-Qaida"}, entity.Programs)
+	require.Equal(t, "6905", entity.SourceID)
+	require.Equal(t, "6905", entity.SourceData.(*Entity).GroupID)
+
+	_, err = Source{}.Read(files[:1])
+	require.ErrorContains(t, err, "not found")
+}
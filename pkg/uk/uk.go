@@ -0,0 +1,77 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package uk
+
+// Entity is an individual, entity or ship on HM Treasury's OFSI Consolidated List of Financial
+// Sanctions Targets. The list has a row for every name of a target, which are linked by GroupID
+// into one Entity.
+type Entity struct {
+	// GroupID is the unique identifier shared by every row of a target
+	GroupID string `json:"groupID"`
+	// GroupType is Individual, Entity or Ship
+	GroupType string `json:"groupType"`
+
+	// Name is the target's primary name
+	Name string `json:"name"`
+	// AlternateNames are every other name of the target, including those in non-Latin scripts
+	AlternateNames []string `json:"alternateNames"`
+	// Names are each name of the target as published
+	Names []Name `json:"names"`
+
+	Regime           string     `json:"regime"`
+	DatesOfBirth     []string   `json:"datesOfBirth,omitempty"`
+	TownsOfBirth     []string   `json:"townsOfBirth,omitempty"`
+	CountriesOfBirth []string   `json:"countriesOfBirth,omitempty"`
+	Nationalities    []string   `json:"nationalities,omitempty"`
+	Passports        []Document `json:"passports,omitempty"`
+	NationalIDs      []Document `json:"nationalIDs,omitempty"`
+	Positions        []string   `json:"positions,omitempty"`
+	Addresses        []Address  `json:"addresses,omitempty"`
+
+	OtherInformation string `json:"otherInformation,omitempty"`
+	ListedOn         string `json:"listedOn"`
+	DesignatedOn     string `json:"designatedOn,omitempty"`
+	LastUpdated      string `json:"lastUpdated"`
+}
+
+const (
+	IndividualType = "Individual"
+	EntityType     = "Entity"
+	ShipType       = "Ship"
+
+	// PrimaryNameAlias is the AliasType of a target's primary name
+	PrimaryNameAlias = "Primary name"
+)
+
+// Name is one row's name of an Entity
+type Name struct {
+	// Parts are Name 1 through Name 6, where Name 6 is the surname or the name of an entity
+	Parts [6]string `json:"parts"`
+	Title string    `json:"title,omitempty"`
+	// FullName is each part joined in order
+	FullName string `json:"fullName"`
+
+	NonLatinScript         string `json:"nonLatinScript,omitempty"`
+	NonLatinScriptType     string `json:"nonLatinScriptType,omitempty"`
+	NonLatinScriptLanguage string `json:"nonLatinScriptLanguage,omitempty"`
+
+	// AliasType is Primary name, AKA or Primary name variation
+	AliasType string `json:"aliasType"`
+	// AliasQuality is Good or Low for aliases
+	AliasQuality string `json:"aliasQuality,omitempty"`
+}
+
+// Document is a passport or national identification document
+type Document struct {
+	Number  string `json:"number"`
+	Details string `json:"details,omitempty"`
+}
+
+// Address is a known address of an Entity
+type Address struct {
+	Lines      []string `json:"lines"`
+	PostalCode string   `json:"postalCode,omitempty"`
+	Country    string   `json:"country,omitempty"`
+}
@@ -0,0 +1,55 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package un
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/download"
+)
+
+// Filename is the name the UN consolidated XML file is saved as
+const Filename = "un_consolidated.xml"
+
+var (
+	unDownloadURL = func() string {
+		if w := os.Getenv("UN_DOWNLOAD_URL"); w != "" {
+			return w
+		}
+		return "https://scsanctions.un.org/resources/xml/en/consolidated.xml"
+	}()
+)
+
+// Download returns the filepath of the UN Security Council Consolidated List XML file
+func Download(ctx context.Context, logger log.Logger, initialDir string) (string, error) {
+	dl := download.New(logger, download.HTTPClient)
+	dl.Validations = map[string]*download.Validation{
+		Filename: {
+			ContentTypes:    append([]string{"application/xml", "text/xml"}, download.DefaultContentTypes...),
+			Root:            "CONSOLIDATED_LIST",
+			Element:         "INDIVIDUAL",
+			MinRecords:      500,
+			MaxRecordChange: download.DefaultMaxRecordChange,
+		},
+	}
+
+	addrs := make(map[string]string)
+	addrs[Filename] = unDownloadURL
+
+	files, err := dl.GetFiles(ctx, initialDir, addrs)
+	if len(files) == 0 || err != nil {
+		return "", fmt.Errorf("un download: %v", err)
+	}
+	for i := range files {
+		if filepath.Base(files[i]) == Filename {
+			return files[i], nil
+		}
+	}
+	return "", fmt.Errorf("un download: %s not found", Filename)
+}
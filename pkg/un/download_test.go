@@ -0,0 +1,35 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package un
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloader(t *testing.T) {
+	if testing.Short() {
+		return
+	}
+
+	file, err := Download(context.Background(), log.NewNopLogger(), "")
+	require.NoError(t, err)
+	defer os.RemoveAll(filepath.Dir(file))
+
+	require.Equal(t, Filename, filepath.Base(file))
+}
+
+func TestDownloader__initialDir(t *testing.T) {
+	dir := filepath.Join("..", "..", "test", "testdata")
+
+	file, err := Download(context.Background(), log.NewNopLogger(), dir)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, Filename), file)
+}
@@ -0,0 +1,227 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package un
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ReadFile parses the UN Security Council Consolidated List XML file at path
+func ReadFile(path string) ([]*Entity, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	return Parse(fd)
+}
+
+// Parse reads each INDIVIDUAL and ENTITY out of the UN consolidated XML
+func Parse(r io.Reader) ([]*Entity, error) {
+	decoder := xml.NewDecoder(r)
+
+	var out []*Entity
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("un: %v", err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "INDIVIDUAL":
+			var record xmlRecord
+			if err := decoder.DecodeElement(&record, &start); err != nil {
+				return nil, fmt.Errorf("un: INDIVIDUAL: %v", err)
+			}
+			out = append(out, record.toEntity(IndividualType))
+
+		case "ENTITY":
+			var record xmlRecord
+			if err := decoder.DecodeElement(&record, &start); err != nil {
+				return nil, fmt.Errorf("un: ENTITY: %v", err)
+			}
+			out = append(out, record.toEntity(EntityType))
+		}
+	}
+	return out, nil
+}
+
+// xmlRecord is an INDIVIDUAL or ENTITY, entities only use FIRST_NAME and
+// ENTITY_ALIAS / ENTITY_ADDRESS.
+type xmlRecord struct {
+	DataID             string   `xml:"DATAID"`
+	FirstName          string   `xml:"FIRST_NAME"`
+	SecondName         string   `xml:"SECOND_NAME"`
+	ThirdName          string   `xml:"THIRD_NAME"`
+	FourthName         string   `xml:"FOURTH_NAME"`
+	ListType           string   `xml:"UN_LIST_TYPE"`
+	ReferenceNumber    string   `xml:"REFERENCE_NUMBER"`
+	ListedOn           string   `xml:"LISTED_ON"`
+	NameOriginalScript string   `xml:"NAME_ORIGINAL_SCRIPT"`
+	Comments           string   `xml:"COMMENTS1"`
+	Titles             []string `xml:"TITLE>VALUE"`
+	Designations       []string `xml:"DESIGNATION>VALUE"`
+	Nationalities      []string `xml:"NATIONALITY>VALUE"`
+
+	Aliases []xmlAlias `xml:"INDIVIDUAL_ALIAS"`
+	// entities use a different element name for the same fields
+	EntityAliases []xmlAlias `xml:"ENTITY_ALIAS"`
+
+	Addresses       []xmlPlace `xml:"INDIVIDUAL_ADDRESS"`
+	EntityAddresses []xmlPlace `xml:"ENTITY_ADDRESS"`
+
+	DatesOfBirth []struct {
+		Type     string `xml:"TYPE_OF_DATE"`
+		Date     string `xml:"DATE"`
+		Year     string `xml:"YEAR"`
+		FromYear string `xml:"FROM_YEAR"`
+		ToYear   string `xml:"TO_YEAR"`
+		Note     string `xml:"NOTE"`
+	} `xml:"INDIVIDUAL_DATE_OF_BIRTH"`
+
+	PlacesOfBirth []xmlPlace `xml:"INDIVIDUAL_PLACE_OF_BIRTH"`
+
+	Documents []struct {
+		Type           string `xml:"TYPE_OF_DOCUMENT"`
+		Type2          string `xml:"TYPE_OF_DOCUMENT2"`
+		Number         string `xml:"NUMBER"`
+		IssuingCountry string `xml:"ISSUING_COUNTRY"`
+		DateOfIssue    string `xml:"DATE_OF_ISSUE"`
+		CityOfIssue    string `xml:"CITY_OF_ISSUE"`
+		CountryOfIssue string `xml:"COUNTRY_OF_ISSUE"`
+		Note           string `xml:"NOTE"`
+	} `xml:"INDIVIDUAL_DOCUMENT"`
+}
+
+type xmlAlias struct {
+	Quality string `xml:"QUALITY"`
+	Name    string `xml:"ALIAS_NAME"`
+	Note    string `xml:"NOTE"`
+}
+
+type xmlPlace struct {
+	Street        string `xml:"STREET"`
+	City          string `xml:"CITY"`
+	StateProvince string `xml:"STATE_PROVINCE"`
+	ZipCode       string `xml:"ZIP_CODE"`
+	Country       string `xml:"COUNTRY"`
+	Note          string `xml:"NOTE"`
+}
+
+func (x xmlPlace) toPlace() Place {
+	return Place{
+		Street:        strings.TrimSpace(x.Street),
+		City:          strings.TrimSpace(x.City),
+		StateProvince: strings.TrimSpace(x.StateProvince),
+		ZipCode:       strings.TrimSpace(x.ZipCode),
+		Country:       strings.TrimSpace(x.Country),
+		Note:          strings.TrimSpace(x.Note),
+	}
+}
+
+func (x *xmlRecord) toEntity(kind string) *Entity {
+	entity := &Entity{
+		DataID:             strings.TrimSpace(x.DataID),
+		Type:               kind,
+		ReferenceNumber:    strings.TrimSpace(x.ReferenceNumber),
+		ListType:           strings.TrimSpace(x.ListType),
+		SourceList:         SourceList,
+		ListedOn:           strings.TrimSpace(x.ListedOn),
+		Name:               strings.Join(strings.Fields(strings.Join([]string{x.FirstName, x.SecondName, x.ThirdName, x.FourthName}, " ")), " "),
+		NameOriginalScript: strings.TrimSpace(x.NameOriginalScript),
+		Titles:             nonEmpty(x.Titles),
+		Designations:       nonEmpty(x.Designations),
+		Nationalities:      nonEmpty(x.Nationalities),
+		Comments:           strings.TrimSpace(x.Comments),
+	}
+
+	seen := map[string]bool{strings.ToLower(entity.Name): true}
+	addAlternate := func(name string) {
+		if name == "" || seen[strings.ToLower(name)] {
+			return
+		}
+		seen[strings.ToLower(name)] = true
+		entity.AlternateNames = append(entity.AlternateNames, name)
+	}
+	addAlternate(entity.NameOriginalScript)
+
+	for _, alias := range append(x.Aliases, x.EntityAliases...) {
+		name := strings.TrimSpace(alias.Name)
+		if name == "" {
+			continue
+		}
+		entity.Aliases = append(entity.Aliases, Alias{
+			Name:    name,
+			Quality: strings.TrimSpace(alias.Quality),
+			Note:    strings.TrimSpace(alias.Note),
+		})
+		addAlternate(name)
+	}
+
+	for _, dob := range x.DatesOfBirth {
+		d := DateOfBirth{
+			Type:     strings.TrimSpace(dob.Type),
+			Date:     strings.TrimSpace(dob.Date),
+			Year:     strings.TrimSpace(dob.Year),
+			FromYear: strings.TrimSpace(dob.FromYear),
+			ToYear:   strings.TrimSpace(dob.ToYear),
+			Note:     strings.TrimSpace(dob.Note),
+		}
+		if d != (DateOfBirth{Type: d.Type}) {
+			entity.DatesOfBirth = append(entity.DatesOfBirth, d)
+		}
+	}
+	for _, pob := range x.PlacesOfBirth {
+		if place := pob.toPlace(); place != (Place{}) {
+			entity.PlacesOfBirth = append(entity.PlacesOfBirth, place)
+		}
+	}
+	for _, addr := range append(x.Addresses, x.EntityAddresses...) {
+		if place := addr.toPlace(); place != (Place{}) {
+			entity.Addresses = append(entity.Addresses, place)
+		}
+	}
+	for _, doc := range x.Documents {
+		d := Document{
+			Type:           strings.TrimSpace(doc.Type),
+			Number:         strings.TrimSpace(doc.Number),
+			IssuingCountry: strings.TrimSpace(doc.IssuingCountry),
+			DateOfIssue:    strings.TrimSpace(doc.DateOfIssue),
+			CityOfIssue:    strings.TrimSpace(doc.CityOfIssue),
+			CountryOfIssue: strings.TrimSpace(doc.CountryOfIssue),
+			Note:           strings.TrimSpace(doc.Note),
+		}
+		if t := strings.TrimSpace(doc.Type2); t != "" {
+			d.Type = strings.TrimSpace(d.Type + " " + t)
+		}
+		if d.Type != "" || d.Number != "" {
+			entity.Documents = append(entity.Documents, d)
+		}
+	}
+
+	return entity
+}
+
+func nonEmpty(values []string) []string {
+	var out []string
+	for i := range values {
+		if v := strings.TrimSpace(values[i]); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
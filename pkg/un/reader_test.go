@@ -0,0 +1,55 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package un
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRead(t *testing.T) {
+	entities, err := ReadFile(filepath.Join("..", "..", "test", "testdata", "un_consolidated.xml"))
+	require.NoError(t, err)
+	require.Len(t, entities, 3)
+
+	ri := entities[0]
+	require.Equal(t, "6908555", ri.DataID)
+	require.Equal(t, IndividualType, ri.Type)
+	require.Equal(t, "KPi.033", ri.ReferenceNumber)
+	require.Equal(t, "DPRK", ri.ListType)
+	require.Equal(t, SourceList, ri.SourceList)
+	require.Equal(t, "RI WON HO", ri.Name)
+	require.Equal(t, []string{"리원호"}, ri.AlternateNames)
+	require.Empty(t, ri.Aliases)
+	require.Empty(t, ri.Addresses)
+	require.Empty(t, ri.PlacesOfBirth)
+	require.Equal(t, []DateOfBirth{{Type: "EXACT", Date: "1964-07-17"}}, ri.DatesOfBirth)
+	require.Equal(t, []Document{{Type: "Passport", Number: "381310014"}}, ri.Documents)
+	require.Equal(t, []string{"Democratic People's Republic of Korea"}, ri.Nationalities)
+	require.Equal(t, []string{"DPRK Ministry of State Security Official"}, ri.Designations)
+
+	zawahiri := entities[1]
+	require.Equal(t, "AIMAN MUHAMMED RABI AL-ZAWAHIRI", zawahiri.Name)
+	require.Equal(t, []string{"أيمن محمد ربيع الظواهري", "Ayman Al-Zawahari", "Abu Mohammed"}, zawahiri.AlternateNames)
+	require.Equal(t, []Alias{{Name: "Ayman Al-Zawahari", Quality: "Good"}, {Name: "Abu Mohammed", Quality: "Low"}}, zawahiri.Aliases)
+	require.Equal(t, []Place{{City: "Giza", Country: "Egypt"}}, zawahiri.PlacesOfBirth)
+	require.Equal(t, []Place{{Note: "Reportedly in Afghanistan/Pakistan border area"}}, zawahiri.Addresses)
+	require.Equal(t, []string{"Doctor"}, zawahiri.Titles)
+	require.Equal(t, "Egypt", zawahiri.Documents[0].IssuingCountry)
+
+	komid := entities[2]
+	require.Equal(t, EntityType, komid.Type)
+	require.Equal(t, "KOREA MINING DEVELOPMENT TRADING CORPORATION", komid.Name)
+	require.Equal(t, []string{"KOMID"}, komid.AlternateNames)
+	require.Equal(t, []Place{{Street: "Central District", City: "Pyongyang", Country: "Democratic People's Republic of Korea"}}, komid.Addresses)
+}
+
+func TestParse__invalid(t *testing.T) {
+	_, err := Parse(strings.NewReader(`<CONSOLIDATED_LIST><INDIVIDUALS><INDIVIDUAL>`))
+	require.Error(t, err)
+}
@@ -0,0 +1,86 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package un
+
+import (
+	"context"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/source"
+)
+
+func init() {
+	source.Register(Source{})
+}
+
+// Source publishes the UN Security Council Consolidated List
+type Source struct{}
+
+func (Source) Name() string {
+	return "UN"
+}
+
+func (Source) Filenames() []string {
+	return []string{Filename}
+}
+
+func (Source) Download(ctx context.Context, logger log.Logger, initialDir string) ([]string, error) {
+	file, err := Download(ctx, logger, initialDir)
+	if err != nil {
+		return nil, err
+	}
+	return []string{file}, nil
+}
+
+func (Source) Read(files []string) ([]source.List, error) {
+	file, err := source.File(files, Filename)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return []source.List{
+		{Name: "UN", Entities: source.Entities(entities)},
+	}, nil
+}
+
+// ToEntity converts the entity into a source.Entity of the UN list
+func (e *Entity) ToEntity() *source.Entity {
+	out := &source.Entity{
+		Name:           e.Name,
+		AlternateNames: e.AlternateNames,
+		Type:           source.ParseEntityType(e.Type),
+		ListedOn:       e.ListedOn,
+		SourceList:     SourceList,
+		SourceID:       e.DataID,
+		SourceData:     e,
+	}
+	if e.ListType != "" {
+		out.Programs = []string{e.ListType}
+	}
+	for _, addr := range e.Addresses {
+		if a := source.JoinParts(addr.Street, addr.City, addr.StateProvince, addr.ZipCode, addr.Country); a != "" {
+			out.Addresses = append(out.Addresses, a)
+		}
+	}
+	for _, doc := range e.Documents {
+		if doc.Number != "" {
+			out.IDs = append(out.IDs, source.JoinParts(doc.Number, doc.Type, doc.IssuingCountry))
+		}
+	}
+	for _, dob := range e.DatesOfBirth {
+		switch {
+		case dob.Date != "":
+			out.DatesOfBirth = append(out.DatesOfBirth, dob.Date)
+		case dob.Year != "":
+			out.DatesOfBirth = append(out.DatesOfBirth, dob.Year)
+		case dob.FromYear != "" && dob.ToYear != "":
+			out.DatesOfBirth = append(out.DatesOfBirth, dob.FromYear+"-"+dob.ToYear)
+		}
+	}
+	return out
+}
@@ -0,0 +1,38 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package un
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/source"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSource(t *testing.T) {
+	files := []string{
+		filepath.Join("..", "..", "test", "testdata", "sdn.csv"),
+		filepath.Join("..", "..", "test", "testdata", Filename),
+	}
+	lists, err := Source{}.Read(files)
+	require.NoError(t, err)
+	require.Len(t, lists, 1)
+	require.Equal(t, "UN", lists[0].Name)
+	require.Len(t, lists[0].Entities, 3)
+
+	entity := lists[0].Entities[0]
+	require.Equal(t, "RI WON HO", entity.Name)
+	require.Equal(t, source.Individual, entity.Type)
+	require.Equal(t, []string{"381310014, Passport"}, entity.IDs)
+	require.Equal(t, []string{"1964-07-17"}, entity.DatesOfBirth)
+	require.Equal(t, []string{"DPRK"}, entity.Programs)
+	require.Equal(t, "2016-11-30", entity.ListedOn)
+	require.Equal(t, "6908555", entity.SourceID)
+
+	_, err = Source{}.Read(files[:1])
+	require.ErrorContains(t, err, "not found")
+}
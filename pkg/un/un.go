@@ -0,0 +1,87 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package un
+
+// SourceList is set on every Entity to attribute search hits to the UN list
+const SourceList = "UN"
+
+const (
+	IndividualType = "individual"
+	EntityType     = "entity"
+)
+
+// Entity is an individual or entity on the UN Security Council Consolidated List
+type Entity struct {
+	// DataID is the unique identifier of the record
+	DataID string `json:"dataID"`
+	// Type is either "individual" or "entity"
+	Type string `json:"type"`
+	// ReferenceNumber is the permanent reference of the record (e.g. QDi.001, KPe.019)
+	ReferenceNumber string `json:"referenceNumber"`
+	// ListType is the sanctions committee which listed the record (e.g. Al-Qaida, DPRK, Libya)
+	ListType string `json:"listType"`
+	// SourceList is always "UN"
+	SourceList string `json:"sourceList"`
+	ListedOn   string `json:"listedOn"`
+
+	// Name is each part of the name joined in order
+	Name string `json:"name"`
+	// NameOriginalScript is the name in its original script (e.g. Arabic, Korean)
+	NameOriginalScript string `json:"nameOriginalScript,omitempty"`
+	// AlternateNames are the name in its original script and every alias
+	AlternateNames []string `json:"alternateNames"`
+	// Aliases are each alias as published, along with its quality
+	Aliases []Alias `json:"aliases,omitempty"`
+
+	Titles        []string      `json:"titles,omitempty"`
+	Designations  []string      `json:"designations,omitempty"`
+	Nationalities []string      `json:"nationalities,omitempty"`
+	DatesOfBirth  []DateOfBirth `json:"datesOfBirth,omitempty"`
+	PlacesOfBirth []Place       `json:"placesOfBirth,omitempty"`
+	Documents     []Document    `json:"documents,omitempty"`
+	Addresses     []Place       `json:"addresses,omitempty"`
+
+	Comments string `json:"comments,omitempty"`
+}
+
+// Alias is another name of an Entity
+type Alias struct {
+	Name string `json:"name"`
+	// Quality is "Good" or "Low", low quality aliases are broad or generic
+	Quality string `json:"quality"`
+	Note    string `json:"note,omitempty"`
+}
+
+// DateOfBirth is a known date of birth, which is either an exact Date, a Year or a range of years
+type DateOfBirth struct {
+	// Type is how precise the date is (e.g. EXACT, APPROXIMATELY, BETWEEN)
+	Type     string `json:"type,omitempty"`
+	Date     string `json:"date,omitempty"`
+	Year     string `json:"year,omitempty"`
+	FromYear string `json:"fromYear,omitempty"`
+	ToYear   string `json:"toYear,omitempty"`
+	Note     string `json:"note,omitempty"`
+}
+
+// Place is a place of birth or an address
+type Place struct {
+	Street        string `json:"street,omitempty"`
+	City          string `json:"city,omitempty"`
+	StateProvince string `json:"stateProvince,omitempty"`
+	ZipCode       string `json:"zipCode,omitempty"`
+	Country       string `json:"country,omitempty"`
+	Note          string `json:"note,omitempty"`
+}
+
+// Document is a passport, national ID or other document of an individual
+type Document struct {
+	Type           string `json:"type"`
+	Number         string `json:"number"`
+	IssuingCountry string `json:"issuingCountry,omitempty"`
+	DateOfIssue    string `json:"dateOfIssue,omitempty"`
+	CityOfIssue    string `json:"cityOfIssue,omitempty"`
+	CountryOfIssue string `json:"countryOfIssue,omitempty"`
+	Note           string `json:"note,omitempty"`
+}
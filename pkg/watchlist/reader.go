@@ -0,0 +1,151 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package watchlist
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Read parses every entity of list out of its file. Files ending in .json are read as an array
+// of objects, everything else as CSV with a header row.
+func Read(list List) ([]*Entity, error) {
+	if err := list.validate(); err != nil {
+		return nil, err
+	}
+	fd, err := os.Open(list.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	var entities []*Entity
+	if strings.EqualFold(filepath.Ext(list.Path), ".json") {
+		entities, err = ParseJSON(list, fd)
+	} else {
+		entities, err = ParseCSV(list, fd)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("watchlist: %s: %v", list.Name, err)
+	}
+	return entities, nil
+}
+
+// ParseCSV reads each row of r into an Entity. Columns are found by name in the first row.
+func ParseCSV(list List, r io.Reader) ([]*Entity, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("missing header row")
+		}
+		return nil, err
+	}
+	columns := make(map[string]int)
+	for i := range header {
+		columns[strings.TrimSpace(header[i])] = i
+	}
+	if _, exists := columns[list.Columns.Name]; !exists {
+		return nil, fmt.Errorf("missing %q column", list.Columns.Name)
+	}
+
+	var out []*Entity
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		get := func(column string) string {
+			if idx, exists := columns[column]; exists && column != "" && idx < len(record) {
+				return strings.TrimSpace(record[idx])
+			}
+			return ""
+		}
+		if entity := list.entity(line, get); entity != nil {
+			out = append(out, entity)
+		}
+	}
+	return out, nil
+}
+
+// ParseJSON reads each object of the JSON array in r into an Entity. Values can be strings,
+// numbers or arrays of strings.
+func ParseJSON(list List, r io.Reader) ([]*Entity, error) {
+	var records []map[string]interface{}
+	if err := json.NewDecoder(r).Decode(&records); err != nil {
+		return nil, err
+	}
+
+	var out []*Entity
+	for i := range records {
+		record := records[i]
+		get := func(column string) string {
+			if column == "" {
+				return ""
+			}
+			return jsonValue(record[column], list.separator())
+		}
+		if entity := list.entity(i+1, get); entity != nil {
+			out = append(out, entity)
+		}
+	}
+	return out, nil
+}
+
+// entity reads the mapped columns of one record, records without a name are skipped
+func (l List) entity(index int, get func(column string) string) *Entity {
+	name := get(l.Columns.Name)
+	if name == "" {
+		return nil
+	}
+	id := get(l.Columns.ID)
+	if id == "" {
+		id = strconv.Itoa(index)
+	}
+	return &Entity{
+		ListName:       l.Name,
+		ID:             id,
+		Name:           name,
+		AlternateNames: l.split(get(l.Columns.Aliases)),
+		Type:           get(l.Columns.Type),
+		Addresses:      l.split(get(l.Columns.Addresses)),
+		IDs:            l.split(get(l.Columns.IDs)),
+		DatesOfBirth:   l.split(get(l.Columns.DatesOfBirth)),
+	}
+}
+
+// jsonValue flattens v into a string, joining arrays with separator
+func jsonValue(v interface{}, separator string) string {
+	switch val := v.(type) {
+	case string:
+		return strings.TrimSpace(val)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	case []interface{}:
+		var parts []string
+		for i := range val {
+			if s := jsonValue(val[i], separator); s != "" {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, separator)
+	}
+	return ""
+}
@@ -0,0 +1,115 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package watchlist
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/base/log"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadConfig(t *testing.T) {
+	lists, err := ReadConfig(filepath.Join("..", "..", "test", "testdata", "watchlists.json"))
+	require.NoError(t, err)
+	require.Len(t, lists, 2)
+
+	require.Equal(t, "fraudsters", lists[0].Name)
+	require.Equal(t, filepath.Join("..", "..", "test", "testdata", "watchlist_fraud.csv"), lists[0].Path)
+	require.Equal(t, "Full Name", lists[0].Columns.Name)
+	require.Equal(t, "exited-customers", lists[1].Name)
+}
+
+func TestRead__CSV(t *testing.T) {
+	lists, err := ReadConfig(filepath.Join("..", "..", "test", "testdata", "watchlists.json"))
+	require.NoError(t, err)
+
+	entities, err := Read(lists[0])
+	require.NoError(t, err)
+	require.Len(t, entities, 3)
+
+	require.Equal(t, &Entity{
+		ListName:       "fraudsters",
+		ID:             "C-1001",
+		Name:           "Jonathan Quincy Marlow",
+		AlternateNames: []string{"Johnny Marlow", "J. Q. Marlow"},
+		Type:           "individual",
+		Addresses:      []string{"12 Harbour Road, Leith, Edinburgh, GB"},
+		IDs:            []string{"GB-PASS 534112907"},
+		DatesOfBirth:   []string{"1979-03-14"},
+	}, entities[0])
+
+	require.Nil(t, entities[2].AlternateNames)
+	require.Equal(t, []string{"NL-ID IX4471023", "NL-PASS NW8C7R2K9"}, entities[2].IDs)
+}
+
+func TestRead__JSON(t *testing.T) {
+	lists, err := ReadConfig(filepath.Join("..", "..", "test", "testdata", "watchlists.json"))
+	require.NoError(t, err)
+
+	entities, err := Read(lists[1])
+	require.NoError(t, err)
+	require.Len(t, entities, 2)
+
+	require.Equal(t, "exited-customers", entities[0].ListName)
+	require.Equal(t, "88213", entities[0].ID)
+	require.Equal(t, "Orchid Lane Trading FZE", entities[0].Name)
+	require.Equal(t, []string{"Orchid Lane", "OLT General Trading"}, entities[0].AlternateNames)
+	require.Equal(t, "business", entities[0].Type)
+
+	require.Equal(t, "Marisol Echeverría Pardo", entities[1].Name)
+	require.Nil(t, entities[1].AlternateNames)
+	require.Equal(t, []string{"1985-06-30"}, entities[1].DatesOfBirth)
+}
+
+func TestParseCSV(t *testing.T) {
+	list := List{Name: "blocked", Columns: Columns{Name: "name", Aliases: "aka"}, Separator: "|"}
+
+	entities, err := ParseCSV(list, strings.NewReader("name,aka\nJane Doe,Janie|J Doe\n"))
+	require.NoError(t, err)
+	require.Len(t, entities, 1)
+	require.Equal(t, "1", entities[0].ID)
+	require.Equal(t, []string{"Janie", "J Doe"}, entities[0].AlternateNames)
+
+	_, err = ParseCSV(list, strings.NewReader("full name,aka\nJane Doe,\n"))
+	require.ErrorContains(t, err, `missing "name" column`)
+
+	_, err = ParseCSV(list, strings.NewReader(""))
+	require.ErrorContains(t, err, "missing header row")
+}
+
+func TestList__validate(t *testing.T) {
+	require.ErrorContains(t, List{}.validate(), "missing name")
+	require.ErrorContains(t, List{Name: "a"}.validate(), "missing path")
+	require.ErrorContains(t, List{Name: "a", Path: "a.csv"}.validate(), "missing name column")
+}
+
+func TestSource(t *testing.T) {
+	lists, err := ReadConfig(filepath.Join("..", "..", "test", "testdata", "watchlists.json"))
+	require.NoError(t, err)
+
+	src := Source{Lists: lists}
+	files, err := src.Download(context.Background(), log.NewNopLogger(), "")
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	read, err := src.Read(files)
+	require.NoError(t, err)
+	require.Len(t, read, 2)
+	require.Equal(t, "fraudsters", read[0].Name)
+	require.Len(t, read[0].Entities, 3)
+	require.Equal(t, "fraudsters", read[0].Entities[0].SourceList)
+	require.Equal(t, "C-1001", read[0].Entities[0].SourceID)
+	require.Equal(t, "exited-customers", read[1].Name)
+
+	// Missing files are an error
+	src.Lists = append(src.Lists, List{Name: "missing", Path: "missing.csv", Columns: Columns{Name: "name"}})
+	_, err = src.Download(context.Background(), log.NewNopLogger(), "")
+	require.Error(t, err)
+}
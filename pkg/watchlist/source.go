@@ -0,0 +1,74 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package watchlist
+
+import (
+	"context"
+	"os"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/source"
+)
+
+// SourceName identifies internal lists in configuration, logs and metrics
+const SourceName = "Watchlists"
+
+// Source reads each configured internal list. It isn't registered as it needs the
+// lists from ReadConfig.
+type Source struct {
+	Lists []List
+}
+
+func (Source) Name() string {
+	return SourceName
+}
+
+// Filenames is empty as internal lists are local files which are never bundled
+func (Source) Filenames() []string {
+	return nil
+}
+
+// Download returns the path of each list, nothing is downloaded
+func (src Source) Download(_ context.Context, _ log.Logger, _ string) ([]string, error) {
+	var out []string
+	for i := range src.Lists {
+		if _, err := os.Stat(src.Lists[i].Path); err != nil {
+			return nil, err
+		}
+		out = append(out, src.Lists[i].Path)
+	}
+	return out, nil
+}
+
+// Read parses every list, each is published under its own name
+func (src Source) Read(_ []string) ([]source.List, error) {
+	var out []source.List
+	for i := range src.Lists {
+		entities, err := Read(src.Lists[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, source.List{
+			Name:     src.Lists[i].Name,
+			Entities: source.Entities(entities),
+		})
+	}
+	return out, nil
+}
+
+// ToEntity converts the entity into a source.Entity of its list
+func (e *Entity) ToEntity() *source.Entity {
+	return &source.Entity{
+		Name:           e.Name,
+		AlternateNames: e.AlternateNames,
+		Type:           source.ParseEntityType(e.Type),
+		Addresses:      e.Addresses,
+		IDs:            e.IDs,
+		DatesOfBirth:   e.DatesOfBirth,
+		SourceList:     e.ListName,
+		SourceID:       e.ID,
+		SourceData:     e,
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2022 The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+// Package watchlist reads internal lists, such as a blocklist of fraudsters or exited customers,
+// so they're screened alongside the government lists.
+package watchlist
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// List is an internal list read from a local CSV or JSON file
+type List struct {
+	// Name identifies the list in results (e.g. "fraudsters")
+	Name string `json:"name"`
+	// Path is the CSV or JSON file to read, the format is chosen by its extension
+	Path string `json:"path"`
+	// Columns maps each Entity field to a CSV header or JSON key of the file
+	Columns Columns `json:"columns"`
+	// Separator splits columns with multiple values (e.g. aliases), it defaults to ";"
+	Separator string `json:"separator"`
+}
+
+// Columns are the CSV headers or JSON keys read into each Entity field. Only Name is required.
+type Columns struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Aliases      string `json:"aliases"`
+	Type         string `json:"type"`
+	Addresses    string `json:"addresses"`
+	IDs          string `json:"ids"`
+	DatesOfBirth string `json:"dob"`
+}
+
+// Entity is a record of an internal list
+type Entity struct {
+	// ListName is the Name of the List the entity was read from
+	ListName string `json:"listName"`
+	// ID is the entity's identifier, which is its row or array index when no column is mapped
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	AlternateNames []string `json:"alternateNames"`
+	Type           string   `json:"type"`
+	Addresses      []string `json:"addresses"`
+	IDs            []string `json:"ids"`
+	DatesOfBirth   []string `json:"datesOfBirth"`
+}
+
+const defaultSeparator = ";"
+
+// ReadConfig reads the JSON array of Lists in the file at path. Relative list paths are
+// resolved from the directory of the config file.
+func ReadConfig(path string) ([]List, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var lists []List
+	if err := json.Unmarshal(bs, &lists); err != nil {
+		return nil, fmt.Errorf("watchlist: reading %s: %v", path, err)
+	}
+	seen := make(map[string]bool)
+	for i := range lists {
+		if err := lists[i].validate(); err != nil {
+			return nil, err
+		}
+		if !filepath.IsAbs(lists[i].Path) {
+			lists[i].Path = filepath.Join(filepath.Dir(path), lists[i].Path)
+		}
+		if seen[lists[i].Name] {
+			return nil, fmt.Errorf("watchlist: duplicate list %q", lists[i].Name)
+		}
+		seen[lists[i].Name] = true
+	}
+	return lists, nil
+}
+
+func (l List) validate() error {
+	if l.Name == "" {
+		return errors.New("watchlist: missing name")
+	}
+	if l.Path == "" {
+		return fmt.Errorf("watchlist: %s: missing path", l.Name)
+	}
+	if l.Columns.Name == "" {
+		return fmt.Errorf("watchlist: %s: missing name column", l.Name)
+	}
+	return nil
+}
+
+func (l List) separator() string {
+	if l.Separator != "" {
+		return l.Separator
+	}
+	return defaultSeparator
+}
+
+// split breaks value on the list's separator, dropping empty values
+func (l List) split(value string) []string {
+	var out []string
+	for _, v := range strings.Split(value, l.separator()) {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}